@@ -0,0 +1,230 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/mr-tron/base58"
+	"github.com/nspcc-dev/neofs-api-go/refs"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/localstore"
+	objstorage "github.com/nspcc-dev/neofs-node/pkg/services/object_manager/replication/storage"
+	"github.com/pkg/errors"
+)
+
+type command struct {
+	// number of the positional arguments
+	args int
+
+	// command modifies the storage
+	writable bool
+
+	// command reads the stored objects, so the keyring
+	// is opened to decrypt them, writable commands
+	// always open the keyring
+	decrypt bool
+
+	// run returns the number of found problems
+	run func(*storage, []string) (int, error)
+}
+
+var commands = map[string]command{
+	"list":         {run: listObjects},
+	"dump":         {args: 1, decrypt: true, run: dumpObject},
+	"verify":       {decrypt: true, run: verifyObjects},
+	"check":        {decrypt: true, run: checkConsistency},
+	"rebuild-meta": {writable: true, run: rebuildMeta},
+	"backup":       {args: 1, decrypt: true, run: backupObjects},
+	"restore":      {args: 1, writable: true, run: restoreObjects},
+	"migrate":      {writable: true, run: migrateFormat},
+}
+
+func listObjects(s *storage, _ []string) (int, error) {
+	return 0, s.Iterate(nil, func(meta *localstore.ObjectMeta) bool {
+		fmt.Printf("%s\tsize=%d\tepoch=%d\n",
+			meta.Object.Address(), meta.PayloadSize, meta.StoreEpoch)
+
+		return false
+	})
+}
+
+func dumpObject(s *storage, args []string) (int, error) {
+	addr, err := refs.ParseAddress(args[0])
+	if err != nil {
+		return 0, errors.Wrap(err, "invalid object address")
+	}
+
+	meta, err := s.Meta(*addr)
+	if err != nil {
+		return 0, err
+	}
+
+	fmt.Printf("Store epoch: %d\n", meta.StoreEpoch)
+	fmt.Printf("Payload size: %d\n", meta.PayloadSize)
+	fmt.Printf("Payload hash: %x\n", meta.PayloadHash)
+	fmt.Printf("Payload offset: %d\n", meta.PayloadOffset)
+
+	obj, err := s.Get(*addr)
+	if err != nil {
+		return 0, err
+	}
+
+	sh := obj.SystemHeader
+
+	fmt.Printf("ID: %s\n", sh.ID)
+	fmt.Printf("CID: %s\n", sh.CID)
+	fmt.Printf("Owner: %s\n", sh.OwnerID)
+	fmt.Printf("Version: %d\n", sh.Version)
+	fmt.Printf("Payload length: %d\n", sh.PayloadLength)
+	fmt.Printf("Created at: epoch %d, unix %d\n", sh.CreatedAt.Epoch, sh.CreatedAt.UnixTime)
+
+	for i := range obj.Headers {
+		fmt.Printf("Header: %s\n", obj.Headers[i].String())
+	}
+
+	if payloadFile != "" {
+		if err := ioutil.WriteFile(payloadFile, obj.Payload, 0600); err != nil {
+			return 0, errors.Wrap(err, "could not write payload")
+		}
+	}
+
+	return 0, nil
+}
+
+func verifyObjects(s *storage, _ []string) (int, error) {
+	verifier, err := objstorage.NewLocalIntegrityVerifier()
+	if err != nil {
+		return 0, err
+	}
+
+	var problems int
+
+	err = s.Iterate(nil, func(meta *localstore.ObjectMeta) bool {
+		addr := *meta.Object.Address()
+
+		obj, err := s.Get(addr)
+		if err == nil {
+			err = verifier.Verify(context.Background(), obj)
+		}
+
+		if err != nil {
+			fmt.Printf("%s\t%v\n", addr, err)
+			problems++
+		}
+
+		return false
+	})
+
+	return problems, err
+}
+
+func checkConsistency(s *storage, _ []string) (int, error) {
+	inspector, ok := s.Localstore.(localstore.Inspector)
+	if !ok {
+		return 0, errors.New("localstore does not support inspection")
+	}
+
+	var problems int
+
+	err := inspector.CheckConsistency(func(m localstore.Mismatch) {
+		// fsbucket file names are base58 keys too
+		if m.Err != nil {
+			fmt.Printf("%s\t%s\t%v\n", m.Type, base58.Encode(m.Key), m.Err)
+		} else {
+			fmt.Printf("%s\t%s\n", m.Type, base58.Encode(m.Key))
+		}
+
+		problems++
+	})
+
+	return problems, err
+}
+
+func rebuildMeta(s *storage, _ []string) (int, error) {
+	inspector, ok := s.Localstore.(localstore.Inspector)
+	if !ok {
+		return 0, errors.New("localstore does not support inspection")
+	}
+
+	cnt, err := inspector.RebuildMeta()
+	if err != nil {
+		return 0, err
+	}
+
+	fmt.Printf("%d meta items restored\n", cnt)
+
+	return 0, nil
+}
+
+func backupObjects(s *storage, args []string) (int, error) {
+	backuper, ok := s.Localstore.(localstore.Backuper)
+	if !ok {
+		return 0, errors.New("localstore does not support backup")
+	}
+
+	f, err := os.OpenFile(args[0], os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not create archive")
+	}
+
+	cnt, epoch, err := backuper.Backup(f, sinceEpoch)
+	if err == nil {
+		err = f.Sync()
+	}
+
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	fmt.Printf("%d objects written, backup epoch %d\n", cnt, epoch)
+
+	return 0, nil
+}
+
+func restoreObjects(s *storage, args []string) (int, error) {
+	backuper, ok := s.Localstore.(localstore.Backuper)
+	if !ok {
+		return 0, errors.New("localstore does not support backup")
+	}
+
+	f, err := os.Open(args[0])
+	if err != nil {
+		return 0, errors.Wrap(err, "could not open archive")
+	}
+
+	defer f.Close()
+
+	cnt, err := backuper.Restore(f)
+
+	fmt.Printf("%d objects restored\n", cnt)
+
+	return 0, err
+}
+
+func migrateFormat(s *storage, _ []string) (int, error) {
+	migrator, ok := s.Localstore.(localstore.Migrator)
+	if !ok {
+		return 0, errors.New("localstore does not support migrations")
+	}
+
+	ver, err := migrator.FormatVersion()
+	if err != nil {
+		return 0, err
+	}
+
+	fmt.Printf("Stored format version: %d\n", ver)
+	fmt.Printf("Current format version: %d\n", localstore.FormatVersion())
+
+	reports, err := migrator.Migrate(dryRun)
+
+	for _, r := range reports {
+		fmt.Printf("%d\t%s\tprocessed=%d\tchanged=%d\n", r.Version, r.Name, r.Processed, r.Changed)
+	}
+
+	return 0, err
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/nspcc-dev/neofs-node/cmd/neofs-node/modules/fix/config"
+	"github.com/nspcc-dev/neofs-node/misc"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/localstore"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+const (
+	// ErrorReturnCode returns when command failed.
+	ErrorReturnCode = 1
+
+	// ProblemsReturnCode returns when command found broken objects.
+	ProblemsReturnCode = 2
+
+	// SuccessReturnCode returns when command succeeded.
+	SuccessReturnCode = 0
+)
+
+const usage = `Usage: neofs-lens [flags] <command> [args]
+
+Inspects local object storage of the stopped neofs node.
+
+Commands:
+  list                 list stored objects with their meta
+  dump <cid/oid>       print object meta and headers, see -payload
+  verify               verify payload checksums and integrity headers
+  check                find mismatches between blob and meta buckets
+  rebuild-meta         recreate meta bucket from blobs (modifies storage)
+  backup <file>        write stored objects to the archive, see -since
+  restore <file>       put objects from the archive (modifies storage)
+  migrate              upgrade storage format (modifies storage), see -dry-run
+
+Flags:
+`
+
+const shardsSection = "storage.shards"
+
+var (
+	configFile  string
+	shardID     string
+	payloadFile string
+	sinceEpoch  uint64
+	dryRun      bool
+)
+
+func exitErr(err error) {
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(ErrorReturnCode)
+	}
+}
+
+// storageConfig returns the node config and its section of the inspected storage.
+func storageConfig() (*viper.Viper, *viper.Viper, error) {
+	cfg, err := config.NewConfig(config.Params{
+		File:    configFile,
+		Prefix:  misc.Prefix,
+		Name:    misc.LensName,
+		Version: misc.Version,
+	})
+	if err != nil {
+		return nil, nil, err
+	} else if shardID == "" {
+		return cfg, cfg, nil
+	}
+
+	v := cfg.Sub(shardsSection + "." + shardID)
+	if v == nil {
+		return nil, nil, errors.Errorf("shard %s is not configured", shardID)
+	}
+
+	return cfg, v, nil
+}
+
+func main() {
+	flag.StringVar(&configFile, "config", configFile, "path to the node config")
+	flag.StringVar(&shardID, "shard", shardID, "inspect the shard from `storage.shards` section")
+	flag.StringVar(&payloadFile, "payload", payloadFile, "write payload of the dumped object to the file")
+	flag.Uint64Var(&sinceEpoch, "since", sinceEpoch, "backup objects stored since the epoch only, e.g. the epoch of the previous backup")
+	flag.BoolVar(&dryRun, "dry-run", dryRun, "report the migrated objects without storage changes")
+	versionFlag := flag.Bool("version", false, "neofs-lens version")
+
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+
+	flag.Parse()
+
+	if *versionFlag {
+		fmt.Println("version:", misc.Version)
+		os.Exit(SuccessReturnCode)
+	}
+
+	cmd, ok := commands[flag.Arg(0)]
+	if !ok || flag.NArg()-1 != cmd.args {
+		flag.Usage()
+		os.Exit(ErrorReturnCode)
+	}
+
+	cfg, v, err := storageConfig()
+	exitErr(err)
+
+	var enc localstore.EncryptionParams
+	if cmd.writable || cmd.decrypt {
+		enc, err = encryptionParams(cfg)
+		exitErr(err)
+	}
+
+	log, err := zap.NewDevelopment()
+	exitErr(err)
+
+	s, err := openStorage(v, enc, log, cmd.writable)
+	exitErr(err)
+
+	problems, err := cmd.run(s, flag.Args()[1:])
+
+	s.close()
+	exitErr(err)
+
+	if problems > 0 {
+		fmt.Fprintf(os.Stderr, "%d problems found\n", problems)
+		os.Exit(ProblemsReturnCode)
+	}
+}
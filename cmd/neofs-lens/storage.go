@@ -0,0 +1,79 @@
+package main
+
+import (
+	"github.com/nspcc-dev/neofs-node/cmd/neofs-node/modules/node"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/localstore"
+	"github.com/nspcc-dev/neofs-node/pkg/services/metrics"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// storage is a localstore over the buckets of the node.
+type storage struct {
+	localstore.Localstore
+
+	v       *viper.Viper
+	l       *zap.Logger
+	buckets node.Buckets
+}
+
+// encryptionParams loads the storage keys the same way as the node does.
+func encryptionParams(v *viper.Viper) (p localstore.EncryptionParams, err error) {
+	if key := v.GetString("node.storage_key"); key != "" {
+		if p.MasterKey, err = localstore.LoadMasterKey(key); err != nil {
+			return p, errors.Wrap(err, "could not load storage key")
+		}
+	}
+
+	for _, key := range v.GetStringSlice("node.previous_storage_keys") {
+		prev, err := localstore.LoadMasterKey(key)
+		if err != nil {
+			return p, errors.Wrap(err, "could not load previous storage key")
+		}
+
+		p.PreviousMasterKeys = append(p.PreviousMasterKeys, prev)
+	}
+
+	return p, nil
+}
+
+// openStorage opens the buckets configured in the node config section
+// the same way as the node does and creates localstore over them.
+//
+// Buckets are opened read-only unless writable is set, see
+// node.BucketsOptions. Keyring bucket is opened if the storage
+// key is configured and the stored objects are decrypted.
+func openStorage(v *viper.Viper, enc localstore.EncryptionParams, l *zap.Logger, writable bool) (*storage, error) {
+	buckets, err := node.OpenBuckets(v, l, node.BucketsOptions{
+		ReadOnly:  !writable,
+		NoKeyring: len(enc.MasterKey) == 0,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not open buckets")
+	}
+
+	s := &storage{v: v, l: l, buckets: buckets}
+
+	p := buckets.LocalstoreParams()
+	p.Logger = l
+	// lens does not export metrics
+	p.Collector = metrics.NopCollector{}
+	p.Encryption = enc
+
+	// format is upgraded by the migrate command only
+	p.SkipMigrations = true
+
+	if s.Localstore, err = localstore.New(p); err != nil {
+		s.close()
+		return nil, errors.Wrap(err, "could not create localstore")
+	}
+
+	return s, nil
+}
+
+// close closes the buckets that are not backed by fsbucket:
+// fsbucket removes its directory on Close.
+func (s *storage) close() {
+	node.CloseBuckets(s.v, s.buckets, s.l)
+}
@@ -50,6 +50,13 @@ func setDefaults(v *viper.Viper) {
 		v.SetDefault("node.shutdown_ttl", "30s")
 		v.SetDefault("node.private_key", "keys/node_00.key")
 
+		// hex-encoded 32-byte master key of the stored objects encryption
+		// or the file with it, objects are stored in plaintext if it is empty
+		v.SetDefault("node.storage_key", "")
+		// master keys replaced by node.storage_key, they are needed
+		// until the first start with the new key only
+		v.SetDefault("node.previous_storage_keys", []string{})
+
 		v.SetDefault("node.grpc.logging", true)
 		v.SetDefault("node.grpc.metrics", true)
 		v.SetDefault("node.grpc.billing", true)
@@ -79,6 +86,10 @@ func setDefaults(v *viper.Viper) {
 		// set true to check container ACL rules
 		v.SetDefault("object.check_acl", true)
 
+		// file with the `rules` list checked after the built-in filters of the
+		// incoming objects, the file is reloaded on change by `policy_watcher`
+		v.SetDefault("object.acceptance_policy.path", "")
+
 		v.SetDefault("object.dial_timeout", "500ms")
 		rpcs := []string{"put", "get", "delete", "head", "search", "range", "range_hash"}
 		for i := range rpcs {
@@ -87,6 +98,91 @@ func setDefaults(v *viper.Viper) {
 		}
 	}
 
+	// Localstore section
+	{
+		// zstd compression of stored objects, objects with Content-Type
+		// user header from the list are stored uncompressed
+		v.SetDefault("localstore.compression.enabled", false)
+		v.SetDefault("localstore.compression.exclude_content_types", []string{
+			"image/*",
+			"video/*",
+			"audio/*",
+			"application/gzip",
+			"application/zip",
+			"application/zstd",
+		})
+	}
+
+	// FS bucket section
+	{
+		// fsync value files and their directories on write
+		v.SetDefault("fsbucket.sync", false)
+
+		// set true and describe the previous layout with `fsbucket.migrate.tree_enabled`,
+		// `fsbucket.migrate.depth` and `fsbucket.migrate.prefix_len` to move the stored
+		// values to the current layout, values are read from both layouts meanwhile
+		v.SetDefault("fsbucket.migrate.enabled", false)
+	}
+
+	// Pack bucket section
+	{
+		// small objects are packed to the files of `packbucket.directory` that must be
+		// set if packs are enabled, shards must not share the directory
+		v.SetDefault("packbucket.enabled", false)
+		v.SetDefault("packbucket.lock_timeout", "1s")
+	}
+
+	// Scrubber section
+	{
+		// payload bytes per second read by the scrubber, 0 is unlimited
+		v.SetDefault("scrubber.rate", 8<<20)
+	}
+
+	// Capacity section
+	{
+		// share of `node.capacity` used by the stored objects at which
+		// the node is marked full in the network map and stops
+		// receiving new objects
+		v.SetDefault("capacity.high_watermark", 0.95)
+		// share at which the full node accepts new objects again
+		v.SetDefault("capacity.low_watermark", 0.9)
+	}
+
+	// Expiration GC section
+	{
+		// lock objects of the expired objects are looked
+		// for on the other container nodes
+		v.SetDefault("expiration_gc.timeouts.search", "5s")
+		v.SetDefault("expiration_gc.timeouts.head", "5s")
+	}
+
+	// Tombstone GC section
+	{
+		// number of epochs after the object removal before the removed
+		// object children are collected and the tombstone removal is confirmed
+		v.SetDefault("tombstone_gc.grace_period", 2)
+		v.SetDefault("tombstone_gc.timeouts.search", "5s")
+		v.SetDefault("tombstone_gc.timeouts.head", "5s")
+	}
+
+	// Multipart upload GC section
+	{
+		// number of epochs without new parts after which
+		// the multipart upload is considered abandoned
+		v.SetDefault("multipart_gc.upload_ttl", 10)
+		v.SetDefault("multipart_gc.timeouts.search", "5s")
+		v.SetDefault("multipart_gc.timeouts.head", "5s")
+	}
+
+	// Storage section
+	{
+		// shards are configured in `storage.shards.<id>` sections with
+		// the same fsbucket, packbucket, writecache, boltbucket and buckets options as the root ones and
+		// optional `mode`, `write_error_limit` and `read_error_limit`
+		v.SetDefault("storage.write_error_limit", 100)
+		v.SetDefault("storage.read_error_limit", 100)
+	}
+
 	// Replication section
 	{
 		v.SetDefault("replication.manager.pool_size", 100)
@@ -158,6 +254,10 @@ func setDefaults(v *viper.Viper) {
 			"replicator",
 			"metrics",
 			"event_listener",
+			"write_cache",
+			"expiration_gc",
+			"tombstone_gc",
+			"multipart_gc",
 		}
 
 		for i := range workers {
@@ -166,6 +266,27 @@ func setDefaults(v *viper.Viper) {
 			// v.SetDefault("workers."+workers[i]+".timer", "5s") // run worker every 5sec and reset timer after job
 			// v.SetDefault("workers."+workers[i]+".ticker", "5s") // run worker every 5sec
 		}
+
+		// compacts packbucket files if it is enabled
+		v.SetDefault("workers.pack_compactor.disabled", false)
+		v.SetDefault("workers.pack_compactor.ticker", "1h")
+
+		// moves fsbucket values to the current layout if migration is enabled
+		v.SetDefault("workers.fs_migrator.disabled", false)
+		v.SetDefault("workers.fs_migrator.immediately", true)
+		v.SetDefault("workers.fs_migrator.timer", "1h")
+
+		// re-verifies all stored objects, see `scrubber` section
+		v.SetDefault("workers.scrubber.disabled", false)
+		v.SetDefault("workers.scrubber.timer", "24h")
+
+		// updates the node state, see `capacity` section
+		v.SetDefault("workers.capacity_watcher.disabled", false)
+		v.SetDefault("workers.capacity_watcher.timer", "1m")
+
+		// reloads the modified acceptance policy file
+		v.SetDefault("workers.policy_watcher.disabled", false)
+		v.SetDefault("workers.policy_watcher.timer", "1m")
 	}
 
 	// Morph section
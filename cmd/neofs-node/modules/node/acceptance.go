@@ -0,0 +1,14 @@
+package node
+
+import (
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/acceptance"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+func newAcceptancePolicy(v *viper.Viper, l *zap.Logger) (*acceptance.Policy, error) {
+	return acceptance.New(acceptance.Params{
+		Path:   v.GetString("object.acceptance_policy.path"),
+		Logger: l,
+	})
+}
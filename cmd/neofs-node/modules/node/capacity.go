@@ -0,0 +1,38 @@
+package node
+
+import (
+	"crypto/ecdsa"
+
+	crypto "github.com/nspcc-dev/neofs-crypto"
+	contract "github.com/nspcc-dev/neofs-node/pkg/morph/client/netmap/wrapper"
+	libboot "github.com/nspcc-dev/neofs-node/pkg/network/bootstrap"
+	object "github.com/nspcc-dev/neofs-node/pkg/network/transport/object/grpc"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/placement"
+	"github.com/spf13/viper"
+	"go.uber.org/dig"
+	"go.uber.org/zap"
+)
+
+type capacityWatcherParams struct {
+	dig.In
+
+	Viper  *viper.Viper
+	Logger *zap.Logger
+	Key    *ecdsa.PrivateKey
+
+	ObjectService object.Service
+	NetMapClient  *contract.Wrapper
+	Placement     placement.Component
+}
+
+func newCapacityWatcher(p capacityWatcherParams) (*libboot.CapacityWatcher, error) {
+	return libboot.NewCapacityWatcher(libboot.CapacityWatcherParams{
+		Meter:         p.ObjectService,
+		Client:        p.NetMapClient,
+		Local:         p.Placement,
+		Key:           crypto.MarshalPublicKey(&p.Key.PublicKey),
+		Logger:        p.Logger,
+		HighWatermark: p.Viper.GetFloat64("capacity.high_watermark"),
+		LowWatermark:  p.Viper.GetFloat64("capacity.low_watermark"),
+	})
+}
@@ -1,35 +1,348 @@
 package node
 
 import (
+	"context"
+	"path"
+	"sync"
+
+	"github.com/nspcc-dev/neofs-node/cmd/neofs-node/modules/fix/worker"
 	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket"
 	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket/boltdb"
 	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket/fsbucket"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket/membucket"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket/packbucket"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket/writecache"
+	"github.com/pkg/errors"
 	"github.com/spf13/viper"
+	"go.uber.org/zap"
 )
 
-type Buckets map[string]bucket.Bucket
+type (
+	Buckets map[string]bucket.Bucket
+
+	// BucketsOptions are the options of the buckets
+	// opened by the storage maintenance tools.
+	BucketsOptions struct {
+		// ReadOnly opens BoltDB and pack buckets read-only. Journal,
+		// index, quarantine and metrics stores are not opened, so
+		// pending writes are not recovered and indexes are not built.
+		ReadOnly bool
+
+		// NoKeyring skips the keyring store, so the stored
+		// objects can not be decrypted.
+		NoKeyring bool
+	}
+
+	// flusher is an interface of the bucket
+	// that writes data in background.
+	flusher interface {
+		Run(context.Context)
+	}
+)
 
 const (
-	fsBucket   = "fsbucket"
-	boltBucket = "bolt"
+	blobBucket       = "blob"
+	metaBucket       = "meta"
+	metricsBucket    = "metrics"
+	writeCacheBucket = "writecache"
+	journalBucket    = "journal"
+	indexBucket      = "index"
+	quarantineBucket = "quarantine"
+	keyBucket        = "keyring"
+
+	// fsbucket that is being migrated to the new layout,
+	// it is registered for the migration job only
+	migrationBucket = "fsbucket_migration"
+)
+
+const (
+	fsBackend     = "fsbucket"
+	boltBackend   = "bolt"
+	memoryBackend = "memory"
+
+	// logical stores `blob`, `meta`, `metrics`, `writecache`, `journal`, `index`,
+	// `quarantine` and `keyring` are configured in `buckets.<store>` sections
+	// with the backend `type` (fsbucket, bolt or memory) and the backend options
+	// that override the root `fsbucket` and `boltbucket` ones, metrics are kept
+	// in the blob store if `buckets.metrics` is not set
+	bucketsSection = "buckets"
 )
 
-func newBuckets(v *viper.Viper) (Buckets, error) {
+const (
+	defaultJournalFile    = "journal.db"
+	defaultIndexFile      = "index.db"
+	defaultQuarantineFile = "quarantine.db"
+	defaultKeyringFile    = "keyring.db"
+)
+
+var backends = bucket.Registry{
+	fsBackend:   fsbucket.NewBucket,
+	boltBackend: newBoltBucket,
+	memoryBackend: func(*viper.Viper) (bucket.Bucket, error) {
+		return membucket.NewBucket(), nil
+	},
+}
+
+func newBoltBucket(v *viper.Viper) (bucket.Bucket, error) {
+	opts, err := boltdb.NewOptions(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return boltdb.NewBucket(&opts)
+}
+
+// copyConfig returns the copy of the configuration
+// that can be modified without affecting the original one.
+func copyConfig(v *viper.Viper) (*viper.Viper, error) {
+	cp := viper.New()
+	if err := cp.MergeConfigMap(v.AllSettings()); err != nil {
+		return nil, err
+	}
+
+	return cp, nil
+}
+
+// storeBackend returns the backend type of the logical store.
+func storeBackend(v *viper.Viper, store, defType string) string {
+	if t := v.GetString(bucketsSection + "." + store + ".type"); t != "" {
+		return t
+	}
+
+	return defType
+}
+
+// newStoreBucket creates the bucket of the logical store.
+//
+// Backend options of the store section override the root ones and
+// the given defaults, so the stores of the same backend type can be
+// configured separately. Backend type is defType if the store section
+// does not set it. Read-only mode can not be overridden by the store.
+func newStoreBucket(v *viper.Viper, store, defType string, defaults map[string]interface{}, readOnly bool) (bucket.Bucket, error) {
+	sv, err := copyConfig(v)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not copy configuration of %s store", store)
+	}
+
+	for key, val := range defaults {
+		sv.Set(key, val)
+	}
+
+	if section := v.Sub(bucketsSection + "." + store); section != nil {
+		for _, key := range section.AllKeys() {
+			sv.Set(key, section.Get(key))
+		}
+	}
+
+	if readOnly {
+		sv.Set("boltbucket.read_only", true)
+	}
+
+	b, err := backends.New(storeBackend(v, store, defType), sv)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not create %s store", store)
+	}
+
+	return b, nil
+}
+
+// boltPath returns the default options of the bolt bucket stored
+// next to the meta database file if the path option is not set.
+func boltPath(v *viper.Viper, opt, defaultFile string) map[string]interface{} {
+	p := v.GetString(opt)
+	if p == "" {
+		meta := v.GetString(bucketsSection + "." + metaBucket + ".boltbucket.path")
+		if meta == "" {
+			meta = v.GetString("boltbucket.path")
+		}
+
+		if meta != "" {
+			p = path.Join(path.Dir(meta), defaultFile)
+		}
+	}
+
+	return map[string]interface{}{"boltbucket.path": p}
+}
+
+func newBuckets(v *viper.Viper, l *zap.Logger) (Buckets, error) {
+	return OpenBuckets(v, l, BucketsOptions{})
+}
+
+// OpenBuckets creates the buckets of the logical stores configured
+// in v the same way as the node does.
+//
+// Buckets of the fsbucket backend must not be closed by the tools:
+// fsbucket removes its directory on Close, see CloseBuckets.
+func OpenBuckets(v *viper.Viper, l *zap.Logger, opts BucketsOptions) (Buckets, error) {
 	var (
 		err      error
 		mBuckets = make(Buckets)
 	)
 
-	if mBuckets[fsBucket], err = fsbucket.NewBucket(v); err != nil {
+	if opts.ReadOnly {
+		if v, err = copyConfig(v); err != nil {
+			return nil, errors.Wrap(err, "could not copy configuration")
+		}
+
+		v.Set("packbucket.read_only", true)
+	}
+
+	if mBuckets[blobBucket], err = newStoreBucket(v, blobBucket, fsBackend, nil, opts.ReadOnly); err != nil {
 		return nil, err
 	}
 
-	boltOpts, err := boltdb.NewOptions(v)
-	if err != nil {
+	// fsbucket is wrapped below, so it is kept for the migration job
+	if _, ok := mBuckets[blobBucket].(bucket.Migrator); ok {
+		mBuckets[migrationBucket] = mBuckets[blobBucket]
+	}
+
+	// small objects are packed to save inodes of fsbucket
+	if v.GetBool("packbucket.enabled") {
+		if mBuckets[blobBucket], err = packbucket.NewBucket(v, mBuckets[blobBucket]); err != nil {
+			return nil, err
+		}
+	}
+
+	if mBuckets[metaBucket], err = newStoreBucket(v, metaBucket, boltBackend, nil, opts.ReadOnly); err != nil {
 		return nil, err
-	} else if mBuckets[boltBucket], err = boltdb.NewBucket(&boltOpts); err != nil {
+	}
+
+	// write cache on fast media acknowledges blob writes before they reach fsbucket
+	if v.GetBool("writecache.enabled") {
+		cache, err := newStoreBucket(v, writeCacheBucket, boltBackend,
+			map[string]interface{}{"boltbucket.path": v.GetString("writecache.path")}, opts.ReadOnly)
+		if err != nil {
+			return nil, err
+		}
+
+		if mBuckets[blobBucket], err = writecache.NewBucket(v, l, cache, mBuckets[blobBucket]); err != nil {
+			return nil, err
+		}
+	}
+
+	// data keys of the stored objects encryption wrapped by the master key
+	if !opts.NoKeyring {
+		if mBuckets[keyBucket], err = newStoreBucket(v, keyBucket, boltBackend,
+			boltPath(v, "boltbucket.keyring_path", defaultKeyringFile), opts.ReadOnly); err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.ReadOnly {
+		return mBuckets, nil
+	}
+
+	// metrics are kept in the blob bucket unless the metrics store is configured
+	if v.IsSet(bucketsSection + "." + metricsBucket) {
+		if mBuckets[metricsBucket], err = newStoreBucket(v, metricsBucket, fsBackend, nil, false); err != nil {
+			return nil, err
+		}
+	}
+
+	if mBuckets[journalBucket], err = newStoreBucket(v, journalBucket, boltBackend,
+		boltPath(v, "boltbucket.journal_path", defaultJournalFile), false); err != nil {
+		return nil, err
+	}
+
+	// secondary indexes of the search are built on the first start
+	if mBuckets[indexBucket], err = newStoreBucket(v, indexBucket, boltBackend,
+		boltPath(v, "boltbucket.index_path", defaultIndexFile), false); err != nil {
+		return nil, err
+	}
+
+	// corrupted objects found by the scrubber are kept for the investigation
+	if mBuckets[quarantineBucket], err = newStoreBucket(v, quarantineBucket, boltBackend,
+		boltPath(v, "boltbucket.quarantine_path", defaultQuarantineFile), false); err != nil {
 		return nil, err
 	}
 
 	return mBuckets, nil
 }
+
+// CloseBuckets closes the buckets of the stores that are not backed by
+// fsbucket, so their files can be opened again. Blob bucket is not closed:
+// fsbucket removes its directory on Close and write cache flushes the
+// cached items.
+func CloseBuckets(v *viper.Viper, buckets Buckets, l *zap.Logger) {
+	for name, b := range buckets {
+		defType := boltBackend
+
+		switch name {
+		case blobBucket, migrationBucket:
+			continue
+		case metricsBucket:
+			defType = fsBackend
+		}
+
+		if storeBackend(v, name, defType) == fsBackend {
+			continue
+		}
+
+		if err := b.Close(); err != nil {
+			l.Warn("could not close bucket",
+				zap.String("bucket", name),
+				zap.Error(err))
+		}
+	}
+}
+
+// compactBuckets returns the job that reclaims free space of the buckets.
+func compactBuckets(buckets Buckets, l *zap.Logger) worker.Handler {
+	return func(context.Context) {
+		for name, b := range buckets {
+			c, ok := b.(bucket.Compactor)
+			if !ok {
+				continue
+			}
+
+			if err := c.Compact(); err != nil {
+				l.Error("could not compact bucket",
+					zap.String("bucket", name),
+					zap.Error(err))
+			}
+		}
+	}
+}
+
+// migrateBuckets returns the job that moves the values
+// of the buckets to their current layout.
+func migrateBuckets(buckets Buckets, l *zap.Logger) worker.Handler {
+	return func(ctx context.Context) {
+		for name, b := range buckets {
+			m, ok := b.(bucket.Migrator)
+			if !ok {
+				continue
+			}
+
+			if err := m.Migrate(ctx); err != nil {
+				l.Error("could not migrate bucket",
+					zap.String("bucket", name),
+					zap.Error(err))
+			}
+		}
+	}
+}
+
+// flushBuckets returns the job that writes cached data of the buckets
+// in background. Remaining data is flushed on the job stop.
+func flushBuckets(buckets Buckets) worker.Handler {
+	return func(ctx context.Context) {
+		wg := new(sync.WaitGroup)
+
+		for _, b := range buckets {
+			f, ok := b.(flusher)
+			if !ok {
+				continue
+			}
+
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+				f.Run(ctx)
+			}()
+		}
+
+		wg.Wait()
+	}
+}
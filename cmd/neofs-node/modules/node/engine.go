@@ -0,0 +1,113 @@
+package node
+
+import (
+	"sort"
+
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/engine"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/localstore"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+const (
+	storageSection = "storage."
+	shardsSection  = storageSection + "shards"
+)
+
+// newStorageEngine creates the storage engine of the configured shards.
+// Returned buckets are the node buckets with the shard ones added.
+func newStorageEngine(p localstoreParams) (localstore.Localstore, Buckets, error) {
+	names := make([]string, 0)
+	for name := range p.Viper.GetStringMap(shardsSection) {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	shards := make([]*engine.Shard, 0, len(names))
+
+	// dig-provided buckets are shared, so they are not modified
+	all := make(Buckets, len(p.Buckets))
+	for k, b := range p.Buckets {
+		all[k] = b
+	}
+
+	for _, name := range names {
+		v := p.Viper.Sub(shardsSection + "." + name)
+		if v == nil {
+			return nil, nil, errors.Errorf("invalid configuration of shard %s", name)
+		}
+
+		mode, err := engine.ModeFromString(v.GetString("mode"))
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "invalid mode of shard %s", name)
+		}
+
+		buckets, ls, err := openShard(p, v)
+		if err != nil {
+			// the node keeps serving the objects of the remaining shards
+			p.Logger.Error("could not open shard, it is disabled",
+				zap.String("shard", name),
+				zap.Error(err))
+
+			continue
+		}
+
+		// register shard buckets for the maintenance jobs
+		for k, b := range buckets {
+			all[name+"/"+k] = b
+		}
+
+		writeLimit := p.Viper.GetUint32(storageSection + "write_error_limit")
+		if v.IsSet("write_error_limit") {
+			writeLimit = v.GetUint32("write_error_limit")
+		}
+
+		readLimit := p.Viper.GetUint32(storageSection + "read_error_limit")
+		if v.IsSet("read_error_limit") {
+			readLimit = v.GetUint32("read_error_limit")
+		}
+
+		s, err := engine.NewShard(engine.ShardParams{
+			ID:              name,
+			Localstore:      ls,
+			Logger:          p.Logger,
+			Mode:            mode,
+			WriteErrorLimit: writeLimit,
+			ReadErrorLimit:  readLimit,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		shards = append(shards, s)
+	}
+
+	e, err := engine.New(engine.Params{
+		Shards: shards,
+		Logger: p.Logger,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return e, all, nil
+}
+
+// openShard creates the buckets and the localstore of the shard.
+// Opened buckets are closed on failure, see CloseBuckets.
+func openShard(p localstoreParams, v *viper.Viper) (Buckets, localstore.Localstore, error) {
+	buckets, err := newBuckets(v, p.Logger)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not create buckets")
+	}
+
+	ls, err := newBucketsLocalstore(p, buckets)
+	if err != nil {
+		CloseBuckets(v, buckets, p.Logger)
+		return nil, nil, errors.Wrap(err, "could not create localstore")
+	}
+
+	return buckets, ls, nil
+}
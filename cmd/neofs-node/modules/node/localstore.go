@@ -4,6 +4,8 @@ import (
 	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/localstore"
 	meta2 "github.com/nspcc-dev/neofs-node/pkg/local_object_storage/meta"
 	metrics2 "github.com/nspcc-dev/neofs-node/pkg/services/metrics"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
 	"go.uber.org/atomic"
 	"go.uber.org/dig"
 	"go.uber.org/zap"
@@ -14,11 +16,22 @@ type (
 		dig.In
 
 		Logger    *zap.Logger
+		Viper     *viper.Viper
 		Buckets   Buckets
 		Counter   *atomic.Float64
 		Collector metrics2.Collector
 	}
 
+	localstoreResult struct {
+		dig.Out
+
+		Localstore localstore.Localstore
+
+		// Buckets are the node buckets including the ones
+		// of the storage engine shards.
+		Buckets Buckets `name:"storage_buckets"`
+	}
+
 	metaIterator struct {
 		iter localstore.Iterator
 	}
@@ -34,20 +47,73 @@ func (m *metaIterator) Iterate(handler meta2.IterateFunc) error {
 	})
 }
 
-func newLocalstore(p localstoreParams) (localstore.Localstore, error) {
-	local, err := localstore.New(localstore.Params{
-		BlobBucket: p.Buckets[fsBucket],
-		MetaBucket: p.Buckets[boltBucket],
-		Logger:     p.Logger,
-		Collector:  p.Collector,
-	})
+// encryptionParams loads the master keys of the stored objects encryption.
+func encryptionParams(v *viper.Viper) (p localstore.EncryptionParams, err error) {
+	if key := v.GetString("node.storage_key"); key != "" {
+		if p.MasterKey, err = localstore.LoadMasterKey(key); err != nil {
+			return p, errors.Wrap(err, "could not load storage key")
+		}
+	}
+
+	for _, key := range v.GetStringSlice("node.previous_storage_keys") {
+		prev, err := localstore.LoadMasterKey(key)
+		if err != nil {
+			return p, errors.Wrap(err, "could not load previous storage key")
+		}
+
+		p.PreviousMasterKeys = append(p.PreviousMasterKeys, prev)
+	}
+
+	return p, nil
+}
+
+func newBucketsLocalstore(p localstoreParams, buckets Buckets) (localstore.Localstore, error) {
+	enc, err := encryptionParams(p.Viper)
 	if err != nil {
 		return nil, err
 	}
 
-	iter := newMetaIterator(local)
-	p.Collector.SetCounter(local)
+	lp := buckets.LocalstoreParams()
+	lp.Logger = p.Logger
+	lp.Collector = p.Collector
+	lp.Compression = localstore.CompressionParams{
+		Enabled:              p.Viper.GetBool("localstore.compression.enabled"),
+		ExcludedContentTypes: p.Viper.GetStringSlice("localstore.compression.exclude_content_types"),
+	}
+	lp.Encryption = enc
+
+	return localstore.New(lp)
+}
+
+// LocalstoreParams returns the localstore parameters
+// with the buckets of the logical stores set.
+func (b Buckets) LocalstoreParams() localstore.Params {
+	return localstore.Params{
+		BlobBucket:       b[blobBucket],
+		MetaBucket:       b[metaBucket],
+		JournalBucket:    b[journalBucket],
+		IndexBucket:      b[indexBucket],
+		QuarantineBucket: b[quarantineBucket],
+		KeyBucket:        b[keyBucket],
+	}
+}
+
+func newLocalstore(p localstoreParams) (res localstoreResult, err error) {
+	// storage engine is used if node has several shards configured
+	if len(p.Viper.GetStringMap(shardsSection)) > 0 {
+		res.Localstore, res.Buckets, err = newStorageEngine(p)
+	} else {
+		res.Localstore, err = newBucketsLocalstore(p, p.Buckets)
+		res.Buckets = p.Buckets
+	}
+
+	if err != nil {
+		return
+	}
+
+	iter := newMetaIterator(res.Localstore)
+	p.Collector.SetCounter(res.Localstore)
 	p.Collector.SetIterator(iter)
 
-	return local, nil
+	return
 }
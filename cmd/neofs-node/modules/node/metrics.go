@@ -1,6 +1,7 @@
 package node
 
 import (
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket"
 	metrics "github.com/nspcc-dev/neofs-node/pkg/network/transport/metrics/grpc"
 	metrics2 "github.com/nspcc-dev/neofs-node/pkg/services/metrics"
 	"github.com/spf13/viper"
@@ -41,6 +42,16 @@ func newMetricsCollector(p metricsParams) (metrics2.Collector, error) {
 		Options:      p.Options,
 		Logger:       p.Logger,
 		Interval:     p.Viper.GetDuration("metrics_collector.interval"),
-		MetricsStore: p.Buckets[fsBucket],
+		MetricsStore: metricsStore(p.Buckets),
 	})
 }
+
+// metricsStore returns the metrics store bucket,
+// metrics are kept in the blob bucket by default.
+func metricsStore(buckets Buckets) bucket.Bucket {
+	if b, ok := buckets[metricsBucket]; ok {
+		return b
+	}
+
+	return buckets[blobBucket]
+}
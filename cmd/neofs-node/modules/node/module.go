@@ -14,7 +14,12 @@ import (
 	libboot "github.com/nspcc-dev/neofs-node/pkg/network/bootstrap"
 	"github.com/nspcc-dev/neofs-node/pkg/network/peers"
 	metrics2 "github.com/nspcc-dev/neofs-node/pkg/services/metrics"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/acceptance"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/expiration"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/multipart"
 	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/replication"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/scrubber"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/tombstone"
 	"github.com/spf13/viper"
 	"go.uber.org/dig"
 	"go.uber.org/zap"
@@ -23,17 +28,24 @@ import (
 type jobParams struct {
 	dig.In
 
-	Logger *zap.Logger
-	Viper  *viper.Viper
-	Peers  peers.Store
+	Logger  *zap.Logger
+	Viper   *viper.Viper
+	Peers   peers.Store
+	Buckets Buckets `name:"storage_buckets"`
 
 	Replicator     replication.Manager
+	Scrubber       *scrubber.Scrubber
+	ExpirationGC   *expiration.GC
+	TombstoneGC    *tombstone.GC
+	MultipartGC    *multipart.GC
+	Policy         *acceptance.Policy
 	PeersInterface peers.Interface
 	Metrics        metrics2.Collector
 
 	MorphEventListener event.Listener
 
-	NodeRegisterer *libboot.Registerer
+	NodeRegisterer  *libboot.Registerer
+	CapacityWatcher *libboot.CapacityWatcher
 }
 
 // Module is a NeoFS node module.
@@ -55,9 +67,15 @@ var Module = module.Module{
 
 	// -- Object manager -- //
 	{Constructor: newObjectManager},
+	{Constructor: newAcceptancePolicy},
 
 	// -- Replication manager -- //
 	{Constructor: newReplicationManager},
+	{Constructor: newScrubber},
+	{Constructor: newRetentionHolder},
+	{Constructor: newExpirationGC},
+	{Constructor: newTombstoneGC},
+	{Constructor: newMultipartGC},
 
 	// -- Session service -- //
 	{Constructor: session.NewMapTokenStore},
@@ -65,6 +83,7 @@ var Module = module.Module{
 
 	// -- Placement tool -- //
 	{Constructor: newPlacementTool},
+	{Constructor: newCapacityWatcher},
 
 	// metrics service -- //
 	{Constructor: newMetricsService},
@@ -80,10 +99,19 @@ var Module = module.Module{
 
 func attachJobs(p jobParams) worker.Jobs {
 	return worker.Jobs{
-		"peers":          p.PeersInterface.Job,
-		"metrics":        p.Metrics.Start,
-		"event_listener": p.MorphEventListener.Listen,
-		"replicator":     p.Replicator.Process,
-		"boot":           p.NodeRegisterer.Bootstrap,
+		"peers":            p.PeersInterface.Job,
+		"metrics":          p.Metrics.Start,
+		"event_listener":   p.MorphEventListener.Listen,
+		"replicator":       p.Replicator.Process,
+		"boot":             p.NodeRegisterer.Bootstrap,
+		"pack_compactor":   compactBuckets(p.Buckets, p.Logger),
+		"write_cache":      flushBuckets(p.Buckets),
+		"fs_migrator":      migrateBuckets(p.Buckets, p.Logger),
+		"expiration_gc":    p.ExpirationGC.Run,
+		"tombstone_gc":     p.TombstoneGC.Run,
+		"multipart_gc":     p.MultipartGC.Run,
+		"scrubber":         p.Scrubber.Scrub,
+		"capacity_watcher": p.CapacityWatcher.Check,
+		"policy_watcher":   p.Policy.Watch,
 	}
 }
@@ -0,0 +1,98 @@
+package node
+
+import (
+	"crypto/ecdsa"
+
+	"github.com/nspcc-dev/neofs-api-go/session"
+	"github.com/nspcc-dev/neofs-node/cmd/neofs-node/modules/morph"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/localstore"
+	"github.com/nspcc-dev/neofs-node/pkg/morph/event"
+	"github.com/nspcc-dev/neofs-node/pkg/morph/event/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/network/peers"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/epochgc"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/multipart"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/placement"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/retention"
+	"github.com/spf13/viper"
+	"go.uber.org/dig"
+	"go.uber.org/zap"
+)
+
+type multipartGCParams struct {
+	dig.In
+
+	Viper      *viper.Viper
+	Logger     *zap.Logger
+	LocalStore localstore.Localstore
+	Key        *ecdsa.PrivateKey
+
+	Placer         *placement.PlacementWrapper
+	Peers          peers.Store
+	PeersInterface peers.Interface
+
+	TokenStore session.PrivateTokenStore
+
+	RetentionHolder retention.Holder
+
+	MorphEventListener event.Listener
+	MorphEventHandlers morph.EventHandlers
+}
+
+const multipartGCPrefix = "multipart_gc"
+
+func newMultipartGC(p multipartGCParams) (*multipart.GC, error) {
+	och, err := newObjectsContainerHandler(cnrHandlerParams{
+		Viper:          p.Viper,
+		Logger:         p.Logger,
+		Placer:         p.Placer,
+		PeerStore:      p.Peers,
+		Peers:          p.PeersInterface,
+		TimeoutsPrefix: multipartGCPrefix,
+		Key:            p.Key,
+
+		TokenStore: p.TokenStore,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	checker, err := multipart.NewChecker(multipart.CheckerParams{
+		SelectiveContainerExecutor: och,
+		NodeLister:                 p.Placer,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	locator, err := retention.NewRemoteLocator(och)
+	if err != nil {
+		return nil, err
+	}
+
+	gc, err := multipart.New(multipart.Params{
+		Params: epochgc.Params{
+			Localstore: p.LocalStore,
+			Logger:     p.Logger,
+			Holder:     p.RetentionHolder,
+			Locator:    locator,
+		},
+		Checker:   checker,
+		UploadTTL: p.Viper.GetUint64(multipartGCPrefix + ".upload_ttl"),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if handlerInfo, ok := p.MorphEventHandlers[morph.ContractEventOptPath(
+		morph.NetmapContractName,
+		morph.NewEpochEventType,
+	)]; ok {
+		handlerInfo.SetHandler(func(ev event.Event) {
+			gc.HandleEpoch(ev.(netmap.NewEpoch).EpochNumber())
+		})
+
+		p.MorphEventListener.RegisterHandler(handlerInfo)
+	}
+
+	return gc, nil
+}
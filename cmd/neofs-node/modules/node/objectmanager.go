@@ -13,6 +13,8 @@ import (
 	contract "github.com/nspcc-dev/neofs-node/pkg/morph/client/netmap/wrapper"
 	"github.com/nspcc-dev/neofs-node/pkg/network/peers"
 	object "github.com/nspcc-dev/neofs-node/pkg/network/transport/object/grpc"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/acceptance"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/erasure"
 	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/placement"
 	storage2 "github.com/nspcc-dev/neofs-node/pkg/services/object_manager/replication/storage"
 	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/transformer"
@@ -46,6 +48,8 @@ type (
 		ExtendedACLStore eacl.Storage
 
 		ContainerStorage storage.Storage
+
+		AcceptancePolicy *acceptance.Policy
 	}
 )
 
@@ -122,11 +126,17 @@ func newObjectManager(p objectManagerParams) (object.Service, error) {
 		return nil, err
 	}
 
+	erasureSrc, err := erasure.NewSource(p.ContainerStorage)
+	if err != nil {
+		return nil, err
+	}
+
 	trans, err := transformer.NewTransformer(transformer.Params{
 		SGInfoReceiver: sgInfoRecv,
 		EpochReceiver:  p.Placer,
 		SizeLimit:      uint64(p.Viper.GetInt64(transformersSectionPath+"payload_limiter.max_payload_size") * apiobj.UnitsKB),
 		Verifier:       verifier,
+		ErasureSource:  erasureSrc,
 	})
 	if err != nil {
 		return nil, err
@@ -197,5 +207,7 @@ func newObjectManager(p objectManagerParams) (object.Service, error) {
 		SGInfoReceiver: sgInfoRecv,
 
 		ExtendedACLSource: p.ExtendedACLStore,
+
+		AcceptancePolicy: p.AcceptancePolicy.Pass,
 	})
 }
@@ -112,7 +112,12 @@ func newReplicationManager(p replicationManagerParams) (replication.Manager, err
 		return nil, err
 	}
 
-	restorer, err := newRestorer(p, ms)
+	partRestorer, err := newPartRestorer(p, ms)
+	if err != nil {
+		return nil, err
+	}
+
+	restorer, err := newRestorer(p, ms, partRestorer)
 	if err != nil {
 		return nil, err
 	}
@@ -136,6 +141,7 @@ func newReplicationManager(p replicationManagerParams) (replication.Manager, err
 		StorageValidator:        storageValidator,
 		ObjectReplicator:        replicator,
 		ObjectRestorer:          restorer,
+		PartRestorer:            partRestorer,
 		Scheduler:               schd,
 		Logger:                  p.Logger,
 	})
@@ -191,7 +197,7 @@ func newPlacementHonorer(p replicationManagerParams, rss replication.RemoteStora
 		ObjectSource:          storage,
 		ObjectReceptacle:      storage,
 		RemoteStorageSelector: rss,
-		PresenceChecker:       p.LocalStore,
+		PresenceChecker:       quarantinePresenceChecker{p.LocalStore},
 		Logger:                p.Logger,
 		TaskChanCap:           p.Viper.GetInt(prefix + ".chan_capacity"),
 		ResultTimeout:         p.Viper.GetDuration(prefix + ".result_timeout"),
@@ -323,14 +329,56 @@ func newObjectReplicator(p replicationManagerParams, rss replication.RemoteStora
 		RemoteStorageSelector: rss,
 		ObjectSource:          storage,
 		ObjectReceptacle:      storage,
-		PresenceChecker:       p.LocalStore,
+		PresenceChecker:       quarantinePresenceChecker{p.LocalStore},
 		Logger:                p.Logger,
 		TaskChanCap:           p.Viper.GetInt(prefix + ".chan_capacity"),
 		ResultTimeout:         p.Viper.GetDuration(prefix + ".result_timeout"),
 	})
 }
 
-func newRestorer(p replicationManagerParams, ms replication.MultiSolver) (replication.ObjectRestorer, error) {
+// quarantinePresenceChecker reports the quarantined objects as present,
+// so the restorer fetches their healthy copies.
+type quarantinePresenceChecker struct {
+	localstore.Localstore
+}
+
+func (c quarantinePresenceChecker) Has(addr localstore.Address) (bool, error) {
+	if ok, err := c.Localstore.Has(addr); err != nil || ok {
+		return ok, err
+	}
+
+	if q, ok := c.Localstore.(localstore.Quarantiner); ok {
+		return q.Quarantined(addr)
+	}
+
+	return false, nil
+}
+
+func newPartRestorer(p replicationManagerParams, rss replication.RemoteStorageSelector) (replication.PartRestorer, error) {
+	och, err := newObjectsContainerHandler(cnrHandlerParams{
+		Viper:          p.Viper,
+		Logger:         p.Logger,
+		Placer:         p.Placer,
+		PeerStore:      p.Peers,
+		Peers:          p.PeersInterface,
+		TimeoutsPrefix: mainReplicationPrefix + "." + restorerPrefix,
+		Key:            p.Key,
+
+		TokenStore: p.TokenStore,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return storage.NewPartRestorer(storage.PartRestorerParams{
+		Localstore:                 p.LocalStore,
+		SelectiveContainerExecutor: och,
+		RemoteStorageSelector:      rss,
+		Logger:                     p.Logger,
+	})
+}
+
+func newRestorer(p replicationManagerParams, ms replication.MultiSolver, pr replication.PartRestorer) (replication.ObjectRestorer, error) {
 	prefix := mainReplicationPrefix + "." + restorerPrefix
 
 	och, err := newObjectsContainerHandler(cnrHandlerParams{
@@ -377,7 +425,8 @@ func newRestorer(p replicationManagerParams, ms replication.MultiSolver) (replic
 		ObjectReceptacle:      storage,
 		EpochReceiver:         ms,
 		RemoteStorageSelector: ms,
-		PresenceChecker:       p.LocalStore,
+		PresenceChecker:       quarantinePresenceChecker{p.LocalStore},
+		PartRestorer:          pr,
 		Logger:                p.Logger,
 		TaskChanCap:           p.Viper.GetInt(prefix + ".chan_capacity"),
 		ResultTimeout:         p.Viper.GetDuration(prefix + ".result_timeout"),
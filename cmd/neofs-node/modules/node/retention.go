@@ -0,0 +1,17 @@
+package node
+
+import (
+	"github.com/nspcc-dev/neofs-node/pkg/core/container/storage"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/retention"
+	"go.uber.org/dig"
+)
+
+type retentionHolderParams struct {
+	dig.In
+
+	ContainerStorage storage.Storage
+}
+
+func newRetentionHolder(p retentionHolderParams) (retention.Holder, error) {
+	return retention.NewHolder(p.ContainerStorage)
+}
@@ -0,0 +1,36 @@
+package node
+
+import (
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/localstore"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/replication"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/replication/storage"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/scrubber"
+	"github.com/spf13/viper"
+	"go.uber.org/dig"
+	"go.uber.org/zap"
+)
+
+type scrubberParams struct {
+	dig.In
+
+	Viper      *viper.Viper
+	Logger     *zap.Logger
+	LocalStore localstore.Localstore
+	Replicator replication.Manager
+}
+
+func newScrubber(p scrubberParams) (*scrubber.Scrubber, error) {
+	// the same checks as for the incoming objects
+	verifier, err := storage.NewLocalIntegrityVerifier()
+	if err != nil {
+		return nil, err
+	}
+
+	return scrubber.New(scrubber.Params{
+		Localstore: p.LocalStore,
+		Restorer:   p.Replicator,
+		Verifier:   verifier,
+		Logger:     p.Logger,
+		Rate:       p.Viper.GetUint64("scrubber.rate"),
+	})
+}
@@ -12,6 +12,9 @@ const (
 
 	// InnerRingPrefix is an inner ring application prefix.
 	InnerRingPrefix = "neofs_ir"
+
+	// LensName is an offline storage inspection tool name.
+	LensName = "neofs-lens"
 )
 
 // These variables are changed in compile time.
@@ -29,7 +29,10 @@ type (
 
 const defaultFilePermission = 0777
 
-var errEmptyPath = errors.New("database empty path")
+var (
+	errEmptyPath     = errors.New("database empty path")
+	errMissingBucket = errors.New("bucket is missing in read-only database")
+)
 
 const name = "boltbucket"
 
@@ -93,11 +96,23 @@ func NewBucket(opts *Options) (bucket.Bucket, error) {
 		return nil, err
 	}
 
-	err = db.Update(func(tx *bbolt.Tx) error {
-		_, err := tx.CreateBucketIfNotExists(opts.Name)
-		return err
-	})
+	if opts.ReadOnly {
+		err = db.View(func(tx *bbolt.Tx) error {
+			if tx.Bucket(opts.Name) == nil {
+				return errMissingBucket
+			}
+
+			return nil
+		})
+	} else {
+		err = db.Update(func(tx *bbolt.Tx) error {
+			_, err := tx.CreateBucketIfNotExists(opts.Name)
+			return err
+		})
+	}
+
 	if err != nil {
+		_ = db.Close()
 		return nil, err
 	}
 
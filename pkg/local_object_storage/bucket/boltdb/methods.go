@@ -1,6 +1,7 @@
 package boltdb
 
 import (
+	"bytes"
 	"os"
 
 	"github.com/mr-tron/base58"
@@ -25,6 +26,25 @@ func (b *boltBucket) Get(key []byte) (data []byte, err error) {
 	return
 }
 
+// GetRange returns the part of the value by key.
+//
+// Only the requested part of the value is copied from the mapped database file.
+func (b *boltBucket) GetRange(key []byte, off, ln uint64) (data []byte, err error) {
+	err = b.db.View(func(txn *bbolt.Tx) error {
+		val := txn.Bucket(b.name).Get(key)
+		if val == nil {
+			return errors.Wrapf(bucket.ErrNotFound, "key=%s", base58.Encode(key))
+		} else if off > uint64(len(val)) || ln > uint64(len(val))-off {
+			return bucket.ErrOutOfRange
+		}
+
+		data = makeCopy(val[off : off+ln])
+		return nil
+	})
+
+	return
+}
+
 // Set value for key.
 func (b *boltBucket) Set(key, value []byte) error {
 	return b.db.Update(func(txn *bbolt.Tx) error {
@@ -88,6 +108,28 @@ func (b *boltBucket) Iterate(handler bucket.FilterHandler) error {
 	})
 }
 
+// IteratePrefix walks over the items with the key prefix.
+//
+// Iteration starts from the first matching key, so
+// it does not touch the rest of the bucket.
+func (b *boltBucket) IteratePrefix(prefix []byte, handler bucket.FilterHandler) error {
+	if handler == nil {
+		return bucket.ErrNilFilterHandler
+	}
+
+	return b.db.View(func(txn *bbolt.Tx) error {
+		c := txn.Bucket(b.name).Cursor()
+
+		for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			if !handler(makeCopy(k), makeCopy(v)) {
+				return bucket.ErrIteratingAborted
+			}
+		}
+
+		return nil
+	})
+}
+
 // Close bucket database.
 func (b *boltBucket) Close() error {
 	return b.db.Close()
@@ -0,0 +1,90 @@
+package boltdb
+
+import (
+	"bytes"
+
+	"github.com/mr-tron/base58"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket"
+	"github.com/pkg/errors"
+	"go.etcd.io/bbolt"
+)
+
+type (
+	valueReader struct {
+		*bytes.Reader
+	}
+
+	// valueWriter buffers the value, since BoltDB
+	// can not put the value in parts.
+	valueWriter struct {
+		b    *boltBucket
+		key  []byte
+		buf  *bytes.Buffer
+		done bool
+	}
+)
+
+func (valueReader) Close() error { return nil }
+
+// OpenReader returns the reader of the value by key.
+//
+// Reader works with the copy of the value, so it does not
+// keep the read transaction open.
+func (b *boltBucket) OpenReader(key []byte) (bucket.ReadSeekCloser, error) {
+	var data []byte
+
+	if err := b.db.View(func(txn *bbolt.Tx) error {
+		val := txn.Bucket(b.name).Get(key)
+		if val == nil {
+			return errors.Wrapf(bucket.ErrNotFound, "key=%s", base58.Encode(key))
+		}
+
+		data = makeCopy(val)
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return valueReader{Reader: bytes.NewReader(data)}, nil
+}
+
+// OpenWriter returns the writer of the value by key.
+//
+// Value is buffered in memory and stored in a single transaction on commit.
+func (b *boltBucket) OpenWriter(key []byte) (bucket.Writer, error) {
+	return &valueWriter{
+		b:   b,
+		key: makeCopy(key),
+		buf: new(bytes.Buffer),
+	}, nil
+}
+
+func (w *valueWriter) Write(p []byte) (int, error) {
+	if w.done {
+		return 0, bucket.ErrWriterClosed
+	}
+
+	return w.buf.Write(p)
+}
+
+func (w *valueWriter) Commit() error {
+	if w.done {
+		return bucket.ErrWriterClosed
+	}
+
+	w.done = true
+
+	return w.b.db.Update(func(txn *bbolt.Tx) error {
+		return txn.Bucket(w.b.name).Put(w.key, w.buf.Bytes())
+	})
+}
+
+func (w *valueWriter) Abort() error {
+	if !w.done {
+		w.done = true
+		w.buf.Reset()
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package boltdb
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket/test"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestBucket(t *testing.T) bucket.Bucket {
+	dir, err := ioutil.TempDir("", "boltBucket_test")
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	b, err := NewBucket(&Options{
+		Name: []byte(name),
+		Path: path.Join(dir, "bucket.db"),
+		Perm: 0600,
+	})
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = b.Close() })
+
+	return b
+}
+
+func TestBoltBucket(t *testing.T) {
+	test.BucketSuite(t, newTestBucket(t))
+}
+
+func TestBoltBucket_Streamer(t *testing.T) {
+	test.StreamerSuite(t, newTestBucket(t))
+}
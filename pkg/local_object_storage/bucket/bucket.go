@@ -1,7 +1,9 @@
 package bucket
 
 import (
+	"context"
 	"errors"
+	"io"
 )
 
 // FilterHandler where you receive key/val in your closure.
@@ -22,11 +24,68 @@ type Bucket interface {
 	Size() int64
 	List() ([][]byte, error)
 	Iterate(FilterHandler) error
-	// Steam can be implemented by badger.Stream, but not for now
-	// Stream(ctx context.Context, key []byte, cb func(io.ReadWriter) error) error
 	Close() error
 }
 
+// RangeReader is an interface of the Bucket that can read
+// a part of the value without loading the whole value.
+type RangeReader interface {
+	// GetRange returns ln bytes of the value starting from off.
+	GetRange(key []byte, off, ln uint64) ([]byte, error)
+}
+
+// PrefixIterator is an interface of the Bucket that can
+// iterate over the items with the key prefix without a full scan.
+type PrefixIterator interface {
+	IteratePrefix(prefix []byte, handler FilterHandler) error
+}
+
+// Compactor is an interface of the Bucket that can
+// reclaim the space left by removed values.
+type Compactor interface {
+	Compact() error
+}
+
+// Migrator is an interface of the Bucket that moves
+// the values stored in the previous layout to the current one.
+type Migrator interface {
+	// Migrate moves the values until all of them are moved
+	// or the context is done.
+	Migrate(context.Context) error
+}
+
+// Streamer is an interface of the Bucket that can read
+// and write the values without keeping them in memory.
+type Streamer interface {
+	// OpenReader returns the reader of the value by key.
+	OpenReader(key []byte) (ReadSeekCloser, error)
+
+	// OpenWriter returns the writer of the value by key.
+	// Written value replaces the stored one on Commit only.
+	OpenWriter(key []byte) (Writer, error)
+}
+
+// ReadSeekCloser groups the basic Read, Seek and Close methods.
+type ReadSeekCloser interface {
+	io.Reader
+	io.Seeker
+	io.Closer
+}
+
+// Writer is a value writer of the Streamer.
+//
+// Either Commit or Abort must be called to release the writer.
+// Abort after Commit does nothing, so it can be deferred.
+type Writer interface {
+	io.Writer
+
+	// Commit stores the written value.
+	Commit() error
+
+	// Abort discards the written value.
+	Abort() error
+}
+
 var (
 	// ErrNilFilterHandler when FilterHandler is empty
 	ErrNilFilterHandler = errors.New("handler can't be nil")
@@ -34,6 +93,14 @@ var (
 	// ErrNotFound is returned by key-value storage methods
 	// that could not find element by key.
 	ErrNotFound = errors.New("key not found")
+
+	// ErrOutOfRange is returned by RangeReader
+	// if requested range is out of value bounds.
+	ErrOutOfRange = errors.New("range is out of value bounds")
+
+	// ErrWriterClosed is returned by Writer methods
+	// after the writer has been committed or aborted.
+	ErrWriterClosed = errors.New("writer is already committed or aborted")
 )
 
 // ErrIteratingAborted is returned by storage iterator
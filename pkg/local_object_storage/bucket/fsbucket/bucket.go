@@ -14,11 +14,13 @@ type (
 	Bucket struct {
 		dir  string
 		perm os.FileMode
+		sync bool
 	}
 
 	treeBucket struct {
 		dir  string
 		perm os.FileMode
+		sync bool
 
 		depth        int
 		prefixLength int
@@ -35,7 +37,10 @@ const (
 	defaultPrefixLen   = 2
 )
 
-var errShortKey = errors.New("key is too short for tree fs bucket")
+var (
+	errShortKey   = errors.New("key is too short for tree fs bucket")
+	errSameLayout = errors.New("migration source layout is the same as the bucket one")
+)
 
 func stringifyKey(key []byte) string {
 	return base58.Encode(key)
@@ -51,13 +56,14 @@ func decodeKey(key string) []byte {
 }
 
 // NewBucket creates new file system bucket instance.
+//
+// Files left by the interrupted writes are removed. If migration is enabled,
+// bucket reads the values from the source layout configured in `migrate`
+// section too, values are moved to the bucket layout by Migrate.
 func NewBucket(v *viper.Viper) (bucket.Bucket, error) {
 	var (
 		dir  string
 		perm os.FileMode
-
-		prefixLen int
-		depth     int
 	)
 
 	if dir = v.GetString(name + ".directory"); dir == "" {
@@ -68,32 +74,63 @@ func NewBucket(v *viper.Viper) (bucket.Bucket, error) {
 		perm = defaultPermissions
 	}
 
-	if depth = v.GetInt(name + ".depth"); depth <= 0 {
-		depth = defaultDepth
+	if err := os.MkdirAll(dir, perm); err != nil {
+		return nil, errors.Wrapf(err, "could not create bucket %s", name)
 	}
 
-	if prefixLen = v.GetInt(name + ".prefix_len"); prefixLen <= 0 {
-		prefixLen = defaultPrefixLen
+	if err := removeTmpFiles(dir); err != nil {
+		return nil, errors.Wrapf(err, "could not clean bucket %s", name)
 	}
 
-	if err := os.MkdirAll(dir, perm); err != nil {
-		return nil, errors.Wrapf(err, "could not create bucket %s", name)
-	}
+	sync := v.GetBool(name + ".sync")
 
-	if v.GetBool(name + ".tree_enabled") {
-		b := &treeBucket{
-			dir:          dir,
-			perm:         perm,
-			depth:        depth,
-			prefixLength: prefixLen,
-		}
-		b.sz = atomic.NewInt64(b.size())
+	b := newLayout(v, name, dir, perm, sync)
 
+	if !v.GetBool(name + ".migrate.enabled") {
 		return b, nil
 	}
 
-	return &Bucket{
-		dir:  dir,
-		perm: perm,
+	src := newLayout(v, name+".migrate", dir, perm, sync)
+	if src.String() == b.String() {
+		return nil, errSameLayout
+	}
+
+	return &migratingBucket{
+		layout: b,
+		src:    src,
+		perm:   perm,
+		sync:   sync,
 	}, nil
 }
+
+// newLayout creates the bucket of the layout configured in the section.
+func newLayout(v *viper.Viper, section, dir string, perm os.FileMode, sync bool) layout {
+	if !v.GetBool(section + ".tree_enabled") {
+		return &Bucket{
+			dir:  dir,
+			perm: perm,
+			sync: sync,
+		}
+	}
+
+	depth := v.GetInt(section + ".depth")
+	if depth <= 0 {
+		depth = defaultDepth
+	}
+
+	prefixLen := v.GetInt(section + ".prefix_len")
+	if prefixLen <= 0 {
+		prefixLen = defaultPrefixLen
+	}
+
+	b := &treeBucket{
+		dir:          dir,
+		perm:         perm,
+		sync:         sync,
+		depth:        depth,
+		prefixLength: prefixLen,
+	}
+	b.sz = atomic.NewInt64(b.size())
+
+	return b
+}
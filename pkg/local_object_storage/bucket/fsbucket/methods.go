@@ -1,6 +1,7 @@
 package fsbucket
 
 import (
+	"io"
 	"io/ioutil"
 	"os"
 	"path"
@@ -19,11 +20,19 @@ func (b *Bucket) Get(key []byte) ([]byte, error) {
 	return ioutil.ReadFile(p)
 }
 
+// GetRange returns the part of the value by key.
+func (b *Bucket) GetRange(key []byte, off, ln uint64) ([]byte, error) {
+	return readRange(path.Join(b.dir, stringifyKey(key)), off, ln)
+}
+
 // Set value by key.
+//
+// Value is written to the temporary file that replaces the value file,
+// so the interrupted write does not leave the truncated value.
 func (b *Bucket) Set(key, value []byte) error {
 	p := path.Join(b.dir, stringifyKey(key))
 
-	return ioutil.WriteFile(p, value, b.perm)
+	return writeFile(p, value, b.perm, b.sync, nil)
 }
 
 // Del value by key.
@@ -44,10 +53,49 @@ func (b *Bucket) Has(key []byte) bool {
 	return err == nil
 }
 
+func readRange(p string, off, ln uint64) ([]byte, error) {
+	f, err := os.Open(p)
+	if os.IsNotExist(err) {
+		return nil, bucket.ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	} else if size := uint64(info.Size()); off > size || ln > size-off {
+		return nil, bucket.ErrOutOfRange
+	}
+
+	data := make([]byte, ln)
+
+	switch n, err := f.ReadAt(data, int64(off)); {
+	case n == len(data):
+		return data, nil
+	case err == io.EOF:
+		// file is truncated concurrently
+		return nil, bucket.ErrOutOfRange
+	default:
+		return nil, err
+	}
+}
+
+// listing walks the files of the root directory only,
+// subdirectories belong to the tree layouts.
 func listing(root string, fn func(path string, info os.FileInfo) error) error {
 	return filepath.Walk(root, func(p string, info os.FileInfo, err error) error {
-		if err != nil || info.IsDir() {
+		if err != nil {
 			return err
+		} else if info.IsDir() {
+			if p != root {
+				return filepath.SkipDir
+			}
+
+			return nil
+		} else if isTmpFile(info.Name()) {
+			return nil
 		}
 
 		if fn == nil {
@@ -0,0 +1,294 @@
+package fsbucket
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket"
+	"github.com/pkg/errors"
+)
+
+type (
+	// layout is a file system bucket of the
+	// particular file placement in the bucket directory.
+	//
+	// Layouts of the different types or parameters
+	// do not list the files of each other, so they can
+	// share the directory.
+	layout interface {
+		bucket.Bucket
+		bucket.RangeReader
+		bucket.Streamer
+		fmt.Stringer
+
+		// filePath returns the path of the value file by key.
+		filePath(key []byte) (string, error)
+
+		// addSize adjusts the bucket size on external file moves.
+		addSize(int64)
+	}
+
+	// migratingBucket is a bucket of the current layout that
+	// reads the values of the source layout until they are moved.
+	migratingBucket struct {
+		layout
+
+		src layout
+
+		perm os.FileMode
+		sync bool
+	}
+
+	// migratingWriter removes the source layout value on commit.
+	migratingWriter struct {
+		bucket.Writer
+
+		del func() error
+	}
+)
+
+var (
+	_ layout = (*Bucket)(nil)
+	_ layout = (*treeBucket)(nil)
+
+	_ bucket.Migrator = (*migratingBucket)(nil)
+)
+
+func (b *Bucket) String() string {
+	return "flat"
+}
+
+func (b *Bucket) filePath(key []byte) (string, error) {
+	return path.Join(b.dir, stringifyKey(key)), nil
+}
+
+func (b *Bucket) addSize(int64) {}
+
+func (b *treeBucket) String() string {
+	return fmt.Sprintf("tree(depth=%d, prefix_len=%d)", b.depth, b.prefixLength)
+}
+
+func (b *treeBucket) filePath(key []byte) (string, error) {
+	dirPaths, filename := b.treePath(key)
+	if dirPaths == nil {
+		return "", errShortKey
+	}
+
+	return path.Join(b.dir, path.Join(dirPaths...), filename), nil
+}
+
+func (b *treeBucket) addSize(delta int64) {
+	b.sz.Add(delta)
+}
+
+// isMissing checks if the value can not be found in the layout.
+func isMissing(err error) bool {
+	return err == bucket.ErrNotFound || err == errShortKey
+}
+
+// Get value by key from the bucket layout or the source one.
+func (b *migratingBucket) Get(key []byte) ([]byte, error) {
+	v, err := b.layout.Get(key)
+	if isMissing(err) {
+		return b.src.Get(key)
+	}
+
+	return v, err
+}
+
+// GetRange returns the part of the value by key
+// from the bucket layout or the source one.
+func (b *migratingBucket) GetRange(key []byte, off, ln uint64) ([]byte, error) {
+	v, err := b.layout.GetRange(key, off, ln)
+	if isMissing(err) {
+		return b.src.GetRange(key, off, ln)
+	}
+
+	return v, err
+}
+
+// OpenReader returns the reader of the value file by key
+// from the bucket layout or the source one.
+func (b *migratingBucket) OpenReader(key []byte) (bucket.ReadSeekCloser, error) {
+	r, err := b.layout.OpenReader(key)
+	if isMissing(err) {
+		return b.src.OpenReader(key)
+	}
+
+	return r, err
+}
+
+// Set value by key to the bucket layout, source layout value is removed.
+func (b *migratingBucket) Set(key, value []byte) error {
+	if err := b.layout.Set(key, value); err != nil {
+		return err
+	}
+
+	return b.delSource(key)
+}
+
+// OpenWriter returns the writer of the value by key to the
+// bucket layout, source layout value is removed on commit.
+func (b *migratingBucket) OpenWriter(key []byte) (bucket.Writer, error) {
+	w, err := b.layout.OpenWriter(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &migratingWriter{
+		Writer: w,
+		del: func() error {
+			return b.delSource(key)
+		},
+	}, nil
+}
+
+// Del value by key from both layouts.
+//
+// Source layout value is removed first, so it
+// can not be moved after the value removal.
+func (b *migratingBucket) Del(key []byte) error {
+	srcErr := b.src.Del(key)
+	if srcErr != nil && !isMissing(srcErr) {
+		return srcErr
+	}
+
+	err := b.layout.Del(key)
+	if isMissing(err) {
+		if srcErr == nil {
+			return nil
+		}
+
+		return bucket.ErrNotFound
+	}
+
+	return err
+}
+
+// Has checks key exists in any layout.
+func (b *migratingBucket) Has(key []byte) bool {
+	return b.layout.Has(key) || b.src.Has(key)
+}
+
+// Size returns the size of both layouts in bytes.
+func (b *migratingBucket) Size() int64 {
+	return b.layout.Size() + b.src.Size()
+}
+
+// List all bucket items of both layouts.
+func (b *migratingBucket) List() ([][]byte, error) {
+	keys, err := b.layout.List()
+	if err != nil {
+		return nil, err
+	}
+
+	srcKeys, err := b.src.List()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range srcKeys {
+		if !b.layout.Has(srcKeys[i]) {
+			keys = append(keys, srcKeys[i])
+		}
+	}
+
+	return keys, nil
+}
+
+// Filter bucket items of both layouts by closure.
+func (b *migratingBucket) Iterate(handler bucket.FilterHandler) error {
+	if err := b.layout.Iterate(handler); err != nil {
+		return err
+	}
+
+	return b.src.Iterate(func(key, val []byte) bool {
+		return b.layout.Has(key) || handler(key, val)
+	})
+}
+
+// Migrate moves the values of the source layout to the bucket one.
+//
+// Value files are hard linked to the new place, so the value written
+// to the bucket layout concurrently is never replaced by the old one.
+func (b *migratingBucket) Migrate(ctx context.Context) error {
+	keys, err := b.src.List()
+	if err != nil {
+		return errors.Wrap(err, "could not list source layout")
+	}
+
+	for i := range keys {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		if err := b.move(keys[i]); err != nil {
+			return errors.Wrapf(err, "could not move %s", stringifyKey(keys[i]))
+		}
+	}
+
+	return nil
+}
+
+func (b *migratingBucket) move(key []byte) error {
+	src, err := b.src.filePath(key)
+	if err != nil {
+		return err
+	}
+
+	dst, err := b.layout.filePath(key)
+	if err != nil {
+		return err
+	}
+
+	fi, err := os.Stat(src)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(path.Dir(dst), b.perm); err != nil {
+		return err
+	}
+
+	// existing value has been written after the source one
+	switch err := os.Link(src, dst); {
+	case err == nil:
+		b.layout.addSize(fi.Size())
+	case os.IsNotExist(err):
+		return nil
+	case !os.IsExist(err):
+		return err
+	}
+
+	if err := os.Remove(src); err == nil {
+		b.src.addSize(-fi.Size())
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	if b.sync {
+		return syncDir(path.Dir(dst))
+	}
+
+	return nil
+}
+
+func (b *migratingBucket) delSource(key []byte) error {
+	if err := b.src.Del(key); err != nil && !isMissing(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (w *migratingWriter) Commit() error {
+	if err := w.Writer.Commit(); err != nil {
+		return err
+	}
+
+	return w.del()
+}
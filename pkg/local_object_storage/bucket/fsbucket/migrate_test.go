@@ -0,0 +1,161 @@
+package fsbucket
+
+import (
+	"context"
+	"crypto/sha256"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBucket_TmpFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fsBucket_test")
+	require.NoError(t, err)
+
+	defer os.RemoveAll(dir)
+
+	// file of the interrupted write
+	tmp := path.Join(dir, "key.123"+tmpSuffix)
+	require.NoError(t, ioutil.WriteFile(tmp, []byte("truncated"), 0600))
+
+	v := viper.New()
+	v.Set(name+".directory", dir)
+
+	_, err = NewBucket(v)
+	require.NoError(t, err)
+
+	_, err = os.Stat(tmp)
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestMigratingBucket(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fsBucket_test")
+	require.NoError(t, err)
+
+	defer os.RemoveAll(dir)
+
+	v := viper.New()
+	v.Set(name+".directory", dir)
+
+	flat, err := NewBucket(v)
+	require.NoError(t, err)
+
+	keys := make([][]byte, 4)
+
+	for i := range keys {
+		k := sha256.Sum256([]byte{byte(i)})
+		keys[i] = k[:]
+
+		require.NoError(t, flat.Set(keys[i], []byte{byte(i)}))
+	}
+
+	v.Set(name+".tree_enabled", true)
+	v.Set(name+".migrate.enabled", true)
+	v.Set(name+".migrate.tree_enabled", true)
+
+	_, err = NewBucket(v)
+	require.EqualError(t, err, errSameLayout.Error())
+
+	v.Set(name+".migrate.tree_enabled", false)
+
+	b, err := NewBucket(v)
+	require.NoError(t, err)
+
+	// values of the source layout are available
+	for i := range keys {
+		val, err := b.Get(keys[i])
+		require.NoError(t, err)
+		require.Equal(t, []byte{byte(i)}, val)
+	}
+
+	list, err := b.List()
+	require.NoError(t, err)
+	require.Len(t, list, len(keys))
+	require.Equal(t, int64(len(keys)), b.Size())
+
+	// new value replaces the source one
+	require.NoError(t, b.Set(keys[0], []byte("new")))
+	require.False(t, flat.Has(keys[0]))
+
+	require.NoError(t, b.Del(keys[1]))
+	require.False(t, b.Has(keys[1]))
+	require.Equal(t, bucket.ErrNotFound, b.Del(keys[1]))
+
+	require.NoError(t, b.(bucket.Migrator).Migrate(context.Background()))
+
+	srcKeys, err := flat.List()
+	require.NoError(t, err)
+	require.Empty(t, srcKeys)
+
+	tree := b.(*migratingBucket).layout
+
+	val, err := tree.Get(keys[0])
+	require.NoError(t, err)
+	require.Equal(t, []byte("new"), val)
+
+	for _, i := range []int{2, 3} {
+		val, err := tree.Get(keys[i])
+		require.NoError(t, err)
+		require.Equal(t, []byte{byte(i)}, val)
+	}
+
+	require.False(t, tree.Has(keys[1]))
+	require.Equal(t, int64(len("new")+2), b.Size())
+}
+
+func TestMigratingBucket_MixedTrees(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fsBucket_test")
+	require.NoError(t, err)
+
+	defer os.RemoveAll(dir)
+
+	v := viper.New()
+	v.Set(name+".directory", dir)
+	v.Set(name+".tree_enabled", true)
+	v.Set(name+".prefix_len", 2)
+
+	src, err := NewBucket(v)
+	require.NoError(t, err)
+
+	keys := make([][]byte, 4)
+
+	for i := range keys {
+		k := sha256.Sum256([]byte{byte(i)})
+		keys[i] = k[:]
+
+		require.NoError(t, src.Set(keys[i], []byte{byte(i)}))
+	}
+
+	// layout with longer prefixes must not list the directories of the source one
+	v.Set(name+".prefix_len", 4)
+	v.Set(name+".migrate.enabled", true)
+	v.Set(name+".migrate.tree_enabled", true)
+	v.Set(name+".migrate.prefix_len", 2)
+
+	b, err := NewBucket(v)
+	require.NoError(t, err)
+
+	layout := b.(*migratingBucket).layout
+
+	list, err := layout.List()
+	require.NoError(t, err)
+	require.Empty(t, list)
+	require.Zero(t, layout.Size())
+
+	list, err = b.List()
+	require.NoError(t, err)
+	require.Len(t, list, len(keys))
+	require.Equal(t, int64(len(keys)), b.Size())
+
+	require.NoError(t, b.(bucket.Migrator).Migrate(context.Background()))
+
+	list, err = b.List()
+	require.NoError(t, err)
+	require.Len(t, list, len(keys))
+	require.Equal(t, int64(len(keys)), b.Size())
+}
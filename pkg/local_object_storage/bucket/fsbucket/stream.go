@@ -0,0 +1,224 @@
+package fsbucket
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket"
+)
+
+// fileWriter writes the value to the temporary file
+// and moves it in place of the value file on commit.
+type fileWriter struct {
+	f    *os.File
+	path string
+	done bool
+
+	// sync flushes the file and its directory to the disk on commit
+	sync bool
+
+	written int64
+
+	// onCommit is called after the value file is replaced,
+	// replaced is the size of the previous value.
+	onCommit func(written, replaced int64)
+}
+
+// tmpSuffix is a suffix of the files that are being written,
+// such files are not listed as the bucket items.
+const tmpSuffix = ".tmp"
+
+func isTmpFile(name string) bool {
+	return strings.HasSuffix(name, tmpSuffix)
+}
+
+func openReader(p string) (bucket.ReadSeekCloser, error) {
+	f, err := os.Open(p)
+	if os.IsNotExist(err) {
+		return nil, bucket.ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+func newFileWriter(p string, perm os.FileMode, sync bool) (*fileWriter, error) {
+	f, err := ioutil.TempFile(path.Dir(p), path.Base(p)+".*"+tmpSuffix)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := f.Chmod(perm); err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+
+		return nil, err
+	}
+
+	return &fileWriter{f: f, path: p, sync: sync}, nil
+}
+
+// writeFile atomically replaces the value file,
+// onCommit is passed to the writer if it is not nil.
+func writeFile(p string, v []byte, perm os.FileMode, sync bool, onCommit func(written, replaced int64)) error {
+	w, err := newFileWriter(p, perm, sync)
+	if err != nil {
+		return err
+	}
+
+	w.onCommit = onCommit
+
+	if _, err := w.Write(v); err != nil {
+		_ = w.Abort()
+		return err
+	}
+
+	return w.Commit()
+}
+
+// syncDir flushes the directory entries to the disk.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+
+	err = d.Sync()
+
+	if cerr := d.Close(); err == nil {
+		err = cerr
+	}
+
+	return err
+}
+
+// removeTmpFiles removes the files left by the interrupted writes.
+func removeTmpFiles(root string) error {
+	return filepath.Walk(root, func(p string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || !isTmpFile(info.Name()) {
+			return err
+		}
+
+		return os.Remove(p)
+	})
+}
+
+func (w *fileWriter) Write(p []byte) (int, error) {
+	if w.done {
+		return 0, bucket.ErrWriterClosed
+	}
+
+	n, err := w.f.Write(p)
+	w.written += int64(n)
+
+	return n, err
+}
+
+func (w *fileWriter) Commit() error {
+	if w.done {
+		return bucket.ErrWriterClosed
+	}
+
+	w.done = true
+
+	if w.sync {
+		if err := w.f.Sync(); err != nil {
+			_ = w.f.Close()
+			_ = os.Remove(w.f.Name())
+
+			return err
+		}
+	}
+
+	if err := w.f.Close(); err != nil {
+		_ = os.Remove(w.f.Name())
+		return err
+	}
+
+	var replaced int64
+	if fi, err := os.Stat(w.path); err == nil {
+		replaced = fi.Size()
+	}
+
+	if err := os.Rename(w.f.Name(), w.path); err != nil {
+		_ = os.Remove(w.f.Name())
+		return err
+	}
+
+	if w.onCommit != nil {
+		w.onCommit(w.written, replaced)
+	}
+
+	if w.sync {
+		return syncDir(path.Dir(w.path))
+	}
+
+	return nil
+}
+
+func (w *fileWriter) Abort() error {
+	if w.done {
+		return nil
+	}
+
+	w.done = true
+
+	_ = w.f.Close()
+
+	return os.Remove(w.f.Name())
+}
+
+// OpenReader returns the reader of the value file by key.
+func (b *Bucket) OpenReader(key []byte) (bucket.ReadSeekCloser, error) {
+	return openReader(path.Join(b.dir, stringifyKey(key)))
+}
+
+// OpenWriter returns the writer of the value by key.
+//
+// Value is written to the temporary file in the bucket directory
+// and replaces the value file on commit.
+func (b *Bucket) OpenWriter(key []byte) (bucket.Writer, error) {
+	return newFileWriter(path.Join(b.dir, stringifyKey(key)), b.perm, b.sync)
+}
+
+// OpenReader returns the reader of the value file by key.
+func (b *treeBucket) OpenReader(key []byte) (bucket.ReadSeekCloser, error) {
+	dirPaths, filename := b.treePath(key)
+	if dirPaths == nil {
+		return nil, errShortKey
+	}
+
+	return openReader(path.Join(b.dir, path.Join(dirPaths...), filename))
+}
+
+// OpenWriter returns the writer of the value by key.
+//
+// Value is written to the temporary file in the value directory
+// and replaces the value file on commit.
+func (b *treeBucket) OpenWriter(key []byte) (bucket.Writer, error) {
+	dirPaths, filename := b.treePath(key)
+	if dirPaths == nil {
+		return nil, errShortKey
+	}
+
+	dir := path.Join(b.dir, path.Join(dirPaths...))
+
+	if err := os.MkdirAll(dir, b.perm); err != nil {
+		return nil, err
+	}
+
+	w, err := newFileWriter(path.Join(dir, filename), b.perm, b.sync)
+	if err != nil {
+		return nil, err
+	}
+
+	w.onCommit = func(written, replaced int64) {
+		b.sz.Add(written - replaced)
+	}
+
+	return w, nil
+}
@@ -0,0 +1,58 @@
+package fsbucket
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket/test"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestBucket(t *testing.T, tree bool) bucket.Bucket {
+	dir, err := ioutil.TempDir("", "fsBucket_test")
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	v := viper.New()
+	v.Set(name+".directory", dir)
+	v.Set(name+".tree_enabled", tree)
+
+	b, err := NewBucket(v)
+	require.NoError(t, err)
+
+	return b
+}
+
+func TestBucket(t *testing.T) {
+	test.BucketSuite(t, newTestBucket(t, false))
+}
+
+func TestTreeBucket(t *testing.T) {
+	test.BucketSuite(t, newTestBucket(t, true))
+}
+
+func TestBucket_Streamer(t *testing.T) {
+	test.StreamerSuite(t, newTestBucket(t, false))
+}
+
+func TestTreeBucket_Streamer(t *testing.T) {
+	b := newTestBucket(t, true)
+
+	test.StreamerSuite(t, b)
+
+	k := make([]byte, 32)
+
+	w, err := b.(bucket.Streamer).OpenWriter(k)
+	require.NoError(t, err)
+
+	_, err = w.Write([]byte("value"))
+	require.NoError(t, err)
+	require.Equal(t, int64(0), b.Size())
+
+	require.NoError(t, w.Commit())
+	require.Equal(t, int64(len("value")), b.Size())
+}
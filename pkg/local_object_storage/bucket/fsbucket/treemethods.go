@@ -61,6 +61,16 @@ func (b *treeBucket) Get(key []byte) ([]byte, error) {
 	return ioutil.ReadFile(p)
 }
 
+// GetRange returns the part of the value by key.
+func (b *treeBucket) GetRange(key []byte, off, ln uint64) ([]byte, error) {
+	dirPaths, filename := b.treePath(key)
+	if dirPaths == nil {
+		return nil, errShortKey
+	}
+
+	return readRange(path.Join(b.dir, path.Join(dirPaths...), filename), off, ln)
+}
+
 // Set value by key.
 func (b *treeBucket) Set(key, value []byte) error {
 	dirPaths, filename := b.treePath(key)
@@ -77,12 +87,9 @@ func (b *treeBucket) Set(key, value []byte) error {
 		return err
 	}
 
-	err := ioutil.WriteFile(p, value, b.perm)
-	if err == nil {
-		b.sz.Add(int64(len(value)))
-	}
-
-	return err
+	return writeFile(p, value, b.perm, b.sync, func(written, replaced int64) {
+		b.sz.Add(written - replaced)
+	})
 }
 
 // Del value by key.
@@ -143,7 +150,8 @@ func (b *treeBucket) listing(root string, fn func(path string, info os.FileInfo)
 		if !s.IsDir() {
 			// we accept files that located in excepted depth and have correct prefix
 			// e.g. file 'abcdef0123' => /ab/cd/abcdef0123
-			if e.depth == b.depth+1 && strings.HasPrefix(s.Name(), e.prefix) {
+			// files that are being written are skipped
+			if e.depth == b.depth+1 && strings.HasPrefix(s.Name(), e.prefix) && !isTmpFile(s.Name()) {
 				err = fn(e.path, s)
 				if err != nil {
 					// might be better to log and ignore
@@ -155,7 +163,7 @@ func (b *treeBucket) listing(root string, fn func(path string, info os.FileInfo)
 		}
 
 		// ignore dirs with inappropriate length or depth
-		if e.depth > b.depth || (e.depth > 0 && len(s.Name()) > b.prefixLength) {
+		if e.depth > b.depth || (e.depth > 0 && len(s.Name()) != b.prefixLength) {
 			continue
 		}
 
@@ -173,6 +173,27 @@ func TestTreebucket(t *testing.T) {
 		require.Error(t, err)
 	})
 
+	t.Run("GetRange", func(t *testing.T) {
+		keyHash := sha256.Sum256([]byte("Range this key"))
+		key := keyHash[:]
+		value := make([]byte, 32)
+		rand.Read(value)
+
+		err := b.Set(key, value)
+		require.NoError(t, err)
+
+		data, err := b.GetRange(key, 8, 16)
+		require.NoError(t, err)
+		require.Equal(t, value[8:24], data)
+
+		_, err = b.GetRange(key, 16, 17)
+		require.EqualError(t, err, bucket.ErrOutOfRange.Error())
+
+		keyHash = sha256.Sum256([]byte("Unknown key"))
+		_, err = b.GetRange(keyHash[:], 0, 1)
+		require.EqualError(t, err, bucket.ErrNotFound.Error())
+	})
+
 	t.Run("Delete", func(t *testing.T) {
 		keyHash := sha256.Sum256([]byte("Delete this key"))
 		key := keyHash[:]
@@ -0,0 +1,237 @@
+package membucket
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket"
+)
+
+type (
+	// Bucket is an in-memory bucket.Bucket implementation.
+	//
+	// Values are lost on Close and process restart,
+	// so the bucket fits the tests and development nodes only.
+	Bucket struct {
+		mtx   sync.RWMutex
+		items map[string][]byte
+	}
+
+	reader struct {
+		*bytes.Reader
+	}
+
+	writer struct {
+		b    *Bucket
+		key  []byte
+		buf  bytes.Buffer
+		done bool
+	}
+)
+
+var (
+	_ bucket.Bucket         = (*Bucket)(nil)
+	_ bucket.RangeReader    = (*Bucket)(nil)
+	_ bucket.PrefixIterator = (*Bucket)(nil)
+	_ bucket.Streamer       = (*Bucket)(nil)
+)
+
+func makeCopy(val []byte) []byte {
+	tmp := make([]byte, len(val))
+	copy(tmp, val)
+
+	return tmp
+}
+
+// NewBucket creates an empty in-memory bucket.
+func NewBucket() *Bucket {
+	return &Bucket{items: make(map[string][]byte)}
+}
+
+// Get value by key.
+func (b *Bucket) Get(key []byte) ([]byte, error) {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+
+	val, ok := b.items[string(key)]
+	if !ok {
+		return nil, bucket.ErrNotFound
+	}
+
+	return makeCopy(val), nil
+}
+
+// GetRange returns the part of the value by key.
+func (b *Bucket) GetRange(key []byte, off, ln uint64) ([]byte, error) {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+
+	val, ok := b.items[string(key)]
+	if !ok {
+		return nil, bucket.ErrNotFound
+	} else if off > uint64(len(val)) || ln > uint64(len(val))-off {
+		return nil, bucket.ErrOutOfRange
+	}
+
+	return makeCopy(val[off : off+ln]), nil
+}
+
+// Set value by key.
+func (b *Bucket) Set(key, value []byte) error {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	b.items[string(key)] = makeCopy(value)
+
+	return nil
+}
+
+// Del removes value by key.
+func (b *Bucket) Del(key []byte) error {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	delete(b.items, string(key))
+
+	return nil
+}
+
+// Has checks key exists.
+func (b *Bucket) Has(key []byte) bool {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+
+	_, ok := b.items[string(key)]
+
+	return ok
+}
+
+// Size returns the total size of the values.
+func (b *Bucket) Size() (size int64) {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+
+	for _, v := range b.items {
+		size += int64(len(v))
+	}
+
+	return
+}
+
+// List all bucket keys.
+func (b *Bucket) List() ([][]byte, error) {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+
+	res := make([][]byte, 0, len(b.items))
+	for k := range b.items {
+		res = append(res, []byte(k))
+	}
+
+	return res, nil
+}
+
+// Iterate walks over the items in the key order.
+//
+// Items are not locked during the handler call,
+// so the handler can modify the bucket.
+func (b *Bucket) Iterate(handler bucket.FilterHandler) error {
+	return b.IteratePrefix(nil, handler)
+}
+
+// IteratePrefix walks over the items with the key prefix in the key order.
+func (b *Bucket) IteratePrefix(prefix []byte, handler bucket.FilterHandler) error {
+	if handler == nil {
+		return bucket.ErrNilFilterHandler
+	}
+
+	b.mtx.RLock()
+
+	keys := make([]string, 0, len(b.items))
+
+	for k := range b.items {
+		if bytes.HasPrefix([]byte(k), prefix) {
+			keys = append(keys, k)
+		}
+	}
+
+	b.mtx.RUnlock()
+
+	sort.Strings(keys)
+
+	for i := range keys {
+		b.mtx.RLock()
+		val, ok := b.items[keys[i]]
+		b.mtx.RUnlock()
+
+		// item is removed during the iteration
+		if !ok {
+			continue
+		}
+
+		if !handler([]byte(keys[i]), makeCopy(val)) {
+			return bucket.ErrIteratingAborted
+		}
+	}
+
+	return nil
+}
+
+// Close removes all the values.
+func (b *Bucket) Close() error {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	b.items = make(map[string][]byte)
+
+	return nil
+}
+
+// OpenReader returns the reader of the value by key.
+func (b *Bucket) OpenReader(key []byte) (bucket.ReadSeekCloser, error) {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+
+	val, ok := b.items[string(key)]
+	if !ok {
+		return nil, bucket.ErrNotFound
+	}
+
+	// values are never modified in place, so the reader does not copy it
+	return reader{Reader: bytes.NewReader(val)}, nil
+}
+
+// OpenWriter returns the writer of the value by key.
+func (b *Bucket) OpenWriter(key []byte) (bucket.Writer, error) {
+	return &writer{b: b, key: makeCopy(key)}, nil
+}
+
+func (reader) Close() error { return nil }
+
+func (w *writer) Write(p []byte) (int, error) {
+	if w.done {
+		return 0, bucket.ErrWriterClosed
+	}
+
+	return w.buf.Write(p)
+}
+
+func (w *writer) Commit() error {
+	if w.done {
+		return bucket.ErrWriterClosed
+	}
+
+	w.done = true
+
+	w.b.mtx.Lock()
+	w.b.items[string(w.key)] = w.buf.Bytes()
+	w.b.mtx.Unlock()
+
+	return nil
+}
+
+func (w *writer) Abort() error {
+	w.done = true
+	return nil
+}
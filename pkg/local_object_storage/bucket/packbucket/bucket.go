@@ -0,0 +1,358 @@
+package packbucket
+
+import (
+	"hash/fnv"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"go.etcd.io/bbolt"
+	"go.uber.org/atomic"
+)
+
+type (
+	// packBucket is a bucket that keeps small values in a set of
+	// pack files and passes the large ones to the underlying bucket.
+	//
+	// It allows to keep millions of small objects without
+	// creating a separate file for each of them.
+	packBucket struct {
+		dir  string
+		perm os.FileMode
+
+		// time to wait for the file lock of the pack
+		lockTimeout time.Duration
+
+		packSize     uint64
+		smallLimit   uint64
+		compactRatio float64
+
+		// packs are opened read-only and modifications are rejected
+		readOnly bool
+
+		large bucket.Bucket
+
+		// guards packs and index
+		mtx   sync.RWMutex
+		packs []*pack
+		index map[string]*pack
+
+		// serialize the modifications of the same key, so the
+		// value is never written to several packs concurrently
+		keyLocks [keyLockCount]sync.Mutex
+	}
+
+	// pack is a single BoltDB file with the small values.
+	pack struct {
+		// guards db that is replaced on compaction
+		mtx sync.RWMutex
+
+		id   uint64
+		path string
+		db   *bbolt.DB
+
+		// total size of the values stored in pack
+		live *atomic.Uint64
+	}
+)
+
+const name = "packbucket"
+
+const (
+	defaultPermissions  = 0755
+	defaultLockTimeout  = time.Second
+	defaultPackSize     = 256 << 20 // 256 MiB
+	defaultSmallLimit   = 64 << 10  // 64 KiB
+	defaultCompactRatio = 0.5
+
+	packExt    = ".db"
+	compactExt = ".compact"
+
+	keyLockCount = 256
+)
+
+var bucketName = []byte(name)
+
+var (
+	errEmptyDirectory = errors.New("empty pack directory")
+	errNilLargeBucket = errors.New("large bucket is nil")
+	errReadOnly       = errors.New("bucket is read-only")
+)
+
+func makeCopy(val []byte) []byte {
+	tmp := make([]byte, len(val))
+	copy(tmp, val)
+
+	return tmp
+}
+
+// NewBucket creates new pack bucket instance over the bucket for large values.
+//
+// Bucket takes the ownership of the large bucket and closes it on Close.
+// If `packbucket.read_only` is set, packs are opened read-only and
+// modifications of the bucket are rejected.
+//
+// `packbucket.directory` is required, since the packs of the different
+// stores must not be mixed in the same directory.
+func NewBucket(v *viper.Viper, large bucket.Bucket) (bucket.Bucket, error) {
+	if large == nil {
+		return nil, errNilLargeBucket
+	}
+
+	b := &packBucket{
+		large: large,
+		index: make(map[string]*pack),
+	}
+
+	if b.dir = v.GetString(name + ".directory"); b.dir == "" {
+		return nil, errEmptyDirectory
+	}
+
+	if b.perm = os.FileMode(v.GetInt(name + ".permissions")); b.perm == 0 {
+		b.perm = defaultPermissions
+	}
+
+	if b.lockTimeout = v.GetDuration(name + ".lock_timeout"); b.lockTimeout <= 0 {
+		b.lockTimeout = defaultLockTimeout
+	}
+
+	if b.packSize = v.GetUint64(name + ".pack_size"); b.packSize == 0 {
+		b.packSize = defaultPackSize
+	}
+
+	if b.smallLimit = v.GetUint64(name + ".small_size_limit"); b.smallLimit == 0 {
+		b.smallLimit = defaultSmallLimit
+	}
+
+	if b.compactRatio = v.GetFloat64(name + ".compact_ratio"); b.compactRatio <= 0 || b.compactRatio > 1 {
+		b.compactRatio = defaultCompactRatio
+	}
+
+	b.readOnly = v.GetBool(name + ".read_only")
+
+	if err := os.MkdirAll(b.dir, b.perm); err != nil {
+		return nil, errors.Wrapf(err, "could not create bucket %s", name)
+	}
+
+	if err := b.open(); err != nil {
+		_ = b.closePacks()
+		return nil, errors.Wrapf(err, "could not open bucket %s", name)
+	}
+
+	return b, nil
+}
+
+// open opens all pack files of the bucket directory and fills the index.
+func (b *packBucket) open() error {
+	files, err := ioutil.ReadDir(b.dir)
+	if err != nil {
+		return err
+	}
+
+	ids := make([]uint64, 0, len(files))
+
+	for i := range files {
+		fname := files[i].Name()
+
+		switch {
+		case files[i].IsDir():
+		case strings.HasSuffix(fname, compactExt):
+			if b.readOnly {
+				continue
+			}
+
+			// compaction has been interrupted, original pack is untouched
+			if err := os.Remove(path.Join(b.dir, fname)); err != nil {
+				return err
+			}
+		case strings.HasSuffix(fname, packExt):
+			id, err := strconv.ParseUint(strings.TrimSuffix(fname, packExt), 10, 64)
+			if err != nil {
+				continue
+			}
+
+			ids = append(ids, id)
+		}
+	}
+
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	for _, id := range ids {
+		p, err := b.openPack(id)
+		if err != nil {
+			return errors.Wrapf(err, "could not open pack %d", id)
+		}
+
+		b.packs = append(b.packs, p)
+
+		if err := p.db.View(func(tx *bbolt.Tx) error {
+			return tx.Bucket(bucketName).ForEach(func(k, v []byte) error {
+				b.index[string(k)] = p
+				p.live.Add(uint64(len(v)))
+				return nil
+			})
+		}); err != nil {
+			return errors.Wrapf(err, "could not index pack %d", id)
+		}
+	}
+
+	return nil
+}
+
+func (b *packBucket) packPath(id uint64) string {
+	return path.Join(b.dir, strconv.FormatUint(id, 10)+packExt)
+}
+
+func (b *packBucket) openPack(id uint64) (*pack, error) {
+	p := &pack{
+		id:   id,
+		path: b.packPath(id),
+		live: atomic.NewUint64(0),
+	}
+
+	var (
+		db  *bbolt.DB
+		err error
+	)
+
+	if b.readOnly {
+		db, err = bbolt.Open(p.path, b.perm, &bbolt.Options{ReadOnly: true, Timeout: b.lockTimeout})
+	} else {
+		db, err = openDB(p.path, b.perm, b.lockTimeout)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	p.db = db
+
+	return p, nil
+}
+
+// openDB opens the pack file for writing. Pack file is locked by the
+// opened bucket, so the other process fails after the lock timeout
+// instead of waiting forever.
+func openDB(p string, perm os.FileMode, timeout time.Duration) (*bbolt.DB, error) {
+	db, err := bbolt.Open(p, perm, &bbolt.Options{Timeout: timeout})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(bucketName)
+		return err
+	}); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// packOf returns the pack that keeps the value by key.
+func (b *packBucket) packOf(key []byte) *pack {
+	b.mtx.RLock()
+	p := b.index[string(key)]
+	b.mtx.RUnlock()
+
+	return p
+}
+
+// lockKey locks the modifications of the value by key
+// and returns the unlock function.
+func (b *packBucket) lockKey(key []byte) func() {
+	h := fnv.New32a()
+	_, _ = h.Write(key)
+
+	m := &b.keyLocks[h.Sum32()%keyLockCount]
+	m.Lock()
+
+	return m.Unlock
+}
+
+// activePack returns the pack for the new values,
+// a new pack is created if the last one is full.
+func (b *packBucket) activePack() (*pack, error) {
+	if b.readOnly {
+		return nil, errReadOnly
+	}
+
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	if ln := len(b.packs); ln > 0 && b.packs[ln-1].live.Load() < b.packSize {
+		return b.packs[ln-1], nil
+	}
+
+	var id uint64
+	if ln := len(b.packs); ln > 0 {
+		id = b.packs[ln-1].id + 1
+	}
+
+	p, err := b.openPack(id)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not create pack %d", id)
+	}
+
+	b.packs = append(b.packs, p)
+
+	return p, nil
+}
+
+func (b *packBucket) packList() []*pack {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+
+	return append([]*pack(nil), b.packs...)
+}
+
+func (b *packBucket) closePacks() error {
+	var firstErr error
+
+	for _, p := range b.packList() {
+		p.mtx.Lock()
+		if err := p.db.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		p.mtx.Unlock()
+	}
+
+	return firstErr
+}
+
+// fileSize returns the size of pack file on disk.
+func (p *pack) fileSize() int64 {
+	info, err := os.Stat(p.path)
+	if err != nil {
+		return 0
+	}
+
+	return info.Size()
+}
+
+// space returns the size of the used part of pack file
+// and the size of free pages in it.
+func (p *pack) space() (used, free int64) {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+
+	if err := p.db.View(func(tx *bbolt.Tx) error {
+		used = tx.Size()
+		return nil
+	}); err != nil {
+		return 0, 0
+	}
+
+	free = int64(p.db.Stats().FreePageN) * int64(p.db.Info().PageSize)
+
+	return
+}
@@ -0,0 +1,329 @@
+package packbucket
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"io/ioutil"
+	"os"
+	"path"
+	"sync"
+	"testing"
+
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket/fsbucket"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket/test"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/bbolt"
+)
+
+const testSmallLimit = 128
+
+func newTestBucket(t *testing.T, dir string, packSize int, large bucket.Bucket) *packBucket {
+	v := viper.New()
+	v.Set("fsbucket.directory", path.Join(dir, "large"))
+	v.Set("packbucket.directory", path.Join(dir, "packs"))
+	v.Set("packbucket.small_size_limit", testSmallLimit)
+	v.Set("packbucket.pack_size", packSize)
+
+	if large == nil {
+		var err error
+
+		large, err = fsbucket.NewBucket(v)
+		require.NoError(t, err)
+	}
+
+	b, err := NewBucket(v, large)
+	require.NoError(t, err)
+
+	return b.(*packBucket)
+}
+
+func testDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "packBucket_test")
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	return dir
+}
+
+func testValue(t *testing.T, sz int) ([]byte, []byte) {
+	v := make([]byte, sz)
+	_, err := rand.Read(v)
+	require.NoError(t, err)
+
+	k := sha256.Sum256(v)
+
+	return k[:], v
+}
+
+func TestPackBucket(t *testing.T) {
+	dir := testDir(t)
+	b := newTestBucket(t, dir, 4<<10, nil)
+
+	items := make(map[string][]byte)
+
+	for i := 0; i < 300; i++ {
+		sz := 1 + i%(2*testSmallLimit)
+
+		k, v := testValue(t, sz)
+		require.NoError(t, b.Set(k, v))
+
+		items[string(k)] = v
+	}
+
+	require.True(t, len(b.packs) > 1, "small values must fill several packs")
+
+	check := func(t *testing.T, b *packBucket) {
+		for k, v := range items {
+			require.True(t, b.Has([]byte(k)), len(v))
+
+			_, packed := b.index[k]
+			require.Equal(t, len(v) <= testSmallLimit, packed)
+
+			res, err := b.Get([]byte(k))
+			require.NoError(t, err)
+			require.Equal(t, v, res)
+
+			res, err = b.GetRange([]byte(k), 1, uint64(len(v)-1))
+			require.NoError(t, err)
+			require.Equal(t, v[1:], res)
+
+			_, err = b.GetRange([]byte(k), 1, uint64(len(v)))
+			require.True(t, errors.Is(errors.Cause(err), bucket.ErrOutOfRange))
+		}
+
+		keys, err := b.List()
+		require.NoError(t, err)
+		require.Len(t, keys, len(items))
+
+		cnt := 0
+		require.NoError(t, b.Iterate(func(k, v []byte) bool {
+			require.Equal(t, items[string(k)], v)
+			cnt++
+			return true
+		}))
+		require.Equal(t, len(items), cnt)
+	}
+
+	check(t, b)
+
+	t.Run("overwrite", func(t *testing.T) {
+		var k string
+		for k = range items {
+			break
+		}
+
+		// move value between packs and large bucket both ways
+		for _, sz := range []int{2 * testSmallLimit, testSmallLimit / 2, testSmallLimit * 3} {
+			_, v := testValue(t, sz)
+			require.NoError(t, b.Set([]byte(k), v))
+
+			items[k] = v
+		}
+
+		check(t, b)
+	})
+
+	t.Run("reopen", func(t *testing.T) {
+		// fsbucket removes its data on Close, so reopen packs only
+		require.NoError(t, b.closePacks())
+
+		b = newTestBucket(t, dir, 4<<10, b.large)
+		check(t, b)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		for k := range items {
+			require.NoError(t, b.Del([]byte(k)))
+			require.False(t, b.Has([]byte(k)))
+
+			_, err := b.Get([]byte(k))
+			require.True(t, errors.Is(errors.Cause(err), bucket.ErrNotFound))
+
+			delete(items, k)
+
+			if len(items) < 10 {
+				break
+			}
+		}
+
+		check(t, b)
+	})
+
+	require.NoError(t, b.Close())
+}
+
+func TestPackBucket_ConcurrentSet(t *testing.T) {
+	// small packs are rotated during the test
+	b := newTestBucket(t, testDir(t), 1<<10, nil)
+
+	key, _ := testValue(t, 1)
+
+	values := make([][]byte, 50)
+	for i := range values {
+		_, values[i] = testValue(t, testSmallLimit)
+	}
+
+	wg := new(sync.WaitGroup)
+
+	for i := range values {
+		wg.Add(1)
+
+		go func(v []byte) {
+			defer wg.Done()
+			require.NoError(t, b.Set(key, v))
+		}(values[i])
+	}
+
+	wg.Wait()
+
+	// value must be kept in a single pack
+	cnt := 0
+	require.NoError(t, b.Iterate(func(k, _ []byte) bool {
+		if bytes.Equal(k, key) {
+			cnt++
+		}
+		return true
+	}))
+	require.Equal(t, 1, cnt)
+
+	require.NoError(t, b.Close())
+}
+
+func TestPackBucket_Streamer(t *testing.T) {
+	b := newTestBucket(t, testDir(t), 4<<10, nil)
+	test.StreamerSuite(t, b)
+
+	t.Run("packed value", func(t *testing.T) {
+		k, v := testValue(t, testSmallLimit)
+		require.NoError(t, b.Set(k, v))
+
+		r, err := b.OpenReader(k)
+		require.NoError(t, err)
+
+		data, err := ioutil.ReadAll(r)
+		require.NoError(t, err)
+		require.Equal(t, v, data)
+		require.NoError(t, r.Close())
+
+		// streamed value replaces the packed one
+		w, err := b.OpenWriter(k)
+		require.NoError(t, err)
+
+		_, err = w.Write([]byte("streamed"))
+		require.NoError(t, err)
+		require.NoError(t, w.Commit())
+
+		require.Nil(t, b.packOf(k))
+
+		data, err = b.Get(k)
+		require.NoError(t, err)
+		require.Equal(t, []byte("streamed"), data)
+	})
+}
+
+func TestPackBucket_Compact(t *testing.T) {
+	b := newTestBucket(t, testDir(t), 1<<20, nil)
+
+	var keys [][]byte
+
+	for i := 0; i < 2000; i++ {
+		k, v := testValue(t, testSmallLimit)
+		require.NoError(t, b.Set(k, v))
+
+		keys = append(keys, k)
+	}
+
+	sz := b.Size()
+
+	// nothing to compact yet
+	require.NoError(t, b.Compact())
+	require.Equal(t, sz, b.Size())
+
+	for _, k := range keys[:1900] {
+		require.NoError(t, b.Del(k))
+	}
+
+	require.NoError(t, b.Compact())
+	require.True(t, b.Size() < sz, "compaction must reduce size")
+
+	for i, k := range keys {
+		v, err := b.Get(k)
+		if i < 1900 {
+			require.True(t, errors.Is(errors.Cause(err), bucket.ErrNotFound))
+			continue
+		}
+
+		require.NoError(t, err)
+		require.Len(t, v, testSmallLimit)
+	}
+
+	// compacted packs accept new values
+	k, v := testValue(t, testSmallLimit)
+	require.NoError(t, b.Set(k, v))
+
+	res, err := b.Get(k)
+	require.NoError(t, err)
+	require.Equal(t, v, res)
+
+	require.NoError(t, b.Close())
+}
+
+func TestPackBucket_ReadOnly(t *testing.T) {
+	dir := testDir(t)
+	b := newTestBucket(t, dir, 4<<10, nil)
+
+	k, v := testValue(t, testSmallLimit)
+	require.NoError(t, b.Set(k, v))
+	require.NoError(t, b.closePacks())
+
+	vp := viper.New()
+	vp.Set("packbucket.directory", path.Join(dir, "packs"))
+	vp.Set("packbucket.read_only", true)
+
+	ro, err := NewBucket(vp, b.large)
+	require.NoError(t, err)
+
+	res, err := ro.Get(k)
+	require.NoError(t, err)
+	require.Equal(t, v, res)
+
+	require.True(t, errors.Is(ro.Set(k, v), errReadOnly))
+	require.True(t, errors.Is(ro.Del(k), errReadOnly))
+	require.True(t, errors.Is(ro.(bucket.Compactor).Compact(), errReadOnly))
+
+	require.NoError(t, ro.(*packBucket).closePacks())
+}
+
+func TestNewBucket(t *testing.T) {
+	dir := testDir(t)
+
+	v := viper.New()
+	v.Set("fsbucket.directory", path.Join(dir, "large"))
+
+	large, err := fsbucket.NewBucket(v)
+	require.NoError(t, err)
+
+	_, err = NewBucket(v, large)
+	require.True(t, errors.Is(err, errEmptyDirectory))
+
+	t.Run("locked pack", func(t *testing.T) {
+		b := newTestBucket(t, dir, 4<<10, large)
+
+		k, val := testValue(t, testSmallLimit)
+		require.NoError(t, b.Set(k, val))
+
+		v.Set("packbucket.directory", path.Join(dir, "packs"))
+		v.Set("packbucket.lock_timeout", "10ms")
+
+		// packs are locked by the opened bucket
+		_, err := NewBucket(v, large)
+		require.True(t, errors.Is(errors.Cause(err), bbolt.ErrTimeout))
+
+		require.NoError(t, b.closePacks())
+	})
+}
@@ -0,0 +1,123 @@
+package packbucket
+
+import (
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.etcd.io/bbolt"
+)
+
+// compactBatchSize is a number of values copied in a single transaction.
+const compactBatchSize = 1000
+
+// Compact rewrites the packs where the pages freed by removed
+// values take more than compaction ratio of the used file space.
+//
+// Pack is locked during the compaction, the other packs are
+// available for reading and writing.
+func (b *packBucket) Compact() error {
+	if b.readOnly {
+		return errReadOnly
+	}
+
+	for _, p := range b.packList() {
+		if !b.fragmented(p) {
+			continue
+		}
+
+		if err := p.compact(b.perm, b.lockTimeout); err != nil {
+			return errors.Wrapf(err, "could not compact pack %d", p.id)
+		}
+	}
+
+	return nil
+}
+
+func (b *packBucket) fragmented(p *pack) bool {
+	used, free := p.space()
+
+	// do not touch small files, BoltDB reuses free pages anyway
+	if uint64(used) < b.packSize/4 {
+		return false
+	}
+
+	return float64(free) > b.compactRatio*float64(used)
+}
+
+// compact copies all values of the pack to a new file and replaces the pack file with it.
+func (p *pack) compact(perm os.FileMode, timeout time.Duration) error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	tmpPath := p.path + compactExt
+
+	dst, err := openDB(tmpPath, perm, timeout)
+	if err != nil {
+		return err
+	}
+
+	if err := copyDB(dst, p.db); err != nil {
+		_ = dst.Close()
+		_ = os.Remove(tmpPath)
+
+		return err
+	}
+
+	if err := dst.Close(); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+
+	if err := p.db.Close(); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+
+	// rename is atomic, so the pack file is always either old or compacted one
+	if err := os.Rename(tmpPath, p.path); err != nil {
+		return p.reopen(perm, timeout, err)
+	}
+
+	return p.reopen(perm, timeout, nil)
+}
+
+// reopen opens the pack file after compaction and returns cause error if any.
+func (p *pack) reopen(perm os.FileMode, timeout time.Duration, cause error) error {
+	db, err := openDB(p.path, perm, timeout)
+	if err != nil {
+		// closed BoltDB fails all operations, so the pack
+		// stays unavailable until the node restart
+		return errors.Wrapf(err, "could not reopen pack %s", p.path)
+	}
+
+	p.db = db
+
+	return cause
+}
+
+func copyDB(dst, src *bbolt.DB) error {
+	return src.View(func(srcTx *bbolt.Tx) error {
+		c := srcTx.Bucket(bucketName).Cursor()
+
+		for k, v := c.First(); k != nil; {
+			if err := dst.Update(func(dstTx *bbolt.Tx) error {
+				bkt := dstTx.Bucket(bucketName)
+
+				for i := 0; k != nil && i < compactBatchSize; i++ {
+					if err := bkt.Put(k, v); err != nil {
+						return err
+					}
+
+					k, v = c.Next()
+				}
+
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
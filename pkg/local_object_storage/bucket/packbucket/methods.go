@@ -0,0 +1,258 @@
+package packbucket
+
+import (
+	"github.com/mr-tron/base58"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket"
+	"github.com/pkg/errors"
+	"go.etcd.io/bbolt"
+)
+
+// Get value by key.
+func (b *packBucket) Get(key []byte) ([]byte, error) {
+	p := b.packOf(key)
+	if p == nil {
+		return b.large.Get(key)
+	}
+
+	return p.getRange(key, func(val []byte) ([]byte, error) {
+		return makeCopy(val), nil
+	})
+}
+
+// GetRange returns the part of the value by key.
+func (b *packBucket) GetRange(key []byte, off, ln uint64) ([]byte, error) {
+	p := b.packOf(key)
+	if p == nil {
+		if rr, ok := b.large.(bucket.RangeReader); ok {
+			return rr.GetRange(key, off, ln)
+		}
+
+		val, err := b.large.Get(key)
+		if err != nil {
+			return nil, err
+		} else if off > uint64(len(val)) || ln > uint64(len(val))-off {
+			return nil, bucket.ErrOutOfRange
+		}
+
+		return val[off : off+ln], nil
+	}
+
+	return p.getRange(key, func(val []byte) ([]byte, error) {
+		if off > uint64(len(val)) || ln > uint64(len(val))-off {
+			return nil, bucket.ErrOutOfRange
+		}
+
+		return makeCopy(val[off : off+ln]), nil
+	})
+}
+
+// Set value by key.
+//
+// Values that are not greater than small size limit are packed,
+// the other ones are stored in the large bucket.
+func (b *packBucket) Set(key, value []byte) error {
+	if b.readOnly {
+		return errReadOnly
+	}
+
+	defer b.lockKey(key)()
+
+	if uint64(len(value)) > b.smallLimit {
+		if err := b.large.Set(key, value); err != nil {
+			return err
+		}
+
+		// value could be packed before
+		return b.delPacked(key)
+	}
+
+	p := b.packOf(key)
+	if p == nil {
+		var err error
+		if p, err = b.activePack(); err != nil {
+			return err
+		}
+	}
+
+	if err := p.set(key, value); err != nil {
+		return err
+	}
+
+	b.mtx.Lock()
+	b.index[string(key)] = p
+	b.mtx.Unlock()
+
+	// value could be stored in large bucket before
+	if b.large.Has(key) {
+		if err := b.large.Del(key); err != nil && !errors.Is(errors.Cause(err), bucket.ErrNotFound) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Del removes item from bucket by key.
+func (b *packBucket) Del(key []byte) error {
+	if b.readOnly {
+		return errReadOnly
+	}
+
+	defer b.lockKey(key)()
+
+	if b.packOf(key) == nil {
+		return b.large.Del(key)
+	}
+
+	return b.delPacked(key)
+}
+
+func (b *packBucket) delPacked(key []byte) error {
+	p := b.packOf(key)
+	if p == nil {
+		return nil
+	}
+
+	if err := p.del(key); err != nil {
+		return err
+	}
+
+	b.mtx.Lock()
+	if b.index[string(key)] == p {
+		delete(b.index, string(key))
+	}
+	b.mtx.Unlock()
+
+	return nil
+}
+
+// Has checks key exists.
+func (b *packBucket) Has(key []byte) bool {
+	return b.packOf(key) != nil || b.large.Has(key)
+}
+
+// Size returns the size of pack files and large bucket.
+func (b *packBucket) Size() int64 {
+	sz := b.large.Size()
+
+	for _, p := range b.packList() {
+		sz += p.fileSize()
+	}
+
+	return sz
+}
+
+// List all items in bucket.
+func (b *packBucket) List() ([][]byte, error) {
+	items, err := b.large.List()
+	if err != nil {
+		return nil, err
+	}
+
+	b.mtx.RLock()
+	for k := range b.index {
+		items = append(items, []byte(k))
+	}
+	b.mtx.RUnlock()
+
+	return items, nil
+}
+
+// Iterate walks over packed items first and then over the large ones.
+func (b *packBucket) Iterate(handler bucket.FilterHandler) error {
+	if handler == nil {
+		return bucket.ErrNilFilterHandler
+	}
+
+	for _, p := range b.packList() {
+		if err := p.iterate(handler); err != nil {
+			return err
+		}
+	}
+
+	return b.large.Iterate(handler)
+}
+
+// Close pack files and large bucket.
+func (b *packBucket) Close() error {
+	err := b.closePacks()
+
+	if lErr := b.large.Close(); err == nil {
+		err = lErr
+	}
+
+	return err
+}
+
+func (p *pack) getRange(key []byte, f func([]byte) ([]byte, error)) (data []byte, err error) {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+
+	err = p.db.View(func(tx *bbolt.Tx) error {
+		val := tx.Bucket(bucketName).Get(key)
+		if val == nil {
+			return errors.Wrapf(bucket.ErrNotFound, "key=%s", base58.Encode(key))
+		}
+
+		data, err = f(val)
+
+		return err
+	})
+
+	return
+}
+
+func (p *pack) set(key, value []byte) error {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+
+	var oldLen int
+
+	if err := p.db.Update(func(tx *bbolt.Tx) error {
+		bkt := tx.Bucket(bucketName)
+		oldLen = len(bkt.Get(key))
+
+		return bkt.Put(makeCopy(key), makeCopy(value))
+	}); err != nil {
+		return err
+	}
+
+	p.live.Add(uint64(len(value)))
+	p.live.Sub(uint64(oldLen))
+
+	return nil
+}
+
+func (p *pack) del(key []byte) error {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+
+	var oldLen int
+
+	if err := p.db.Update(func(tx *bbolt.Tx) error {
+		bkt := tx.Bucket(bucketName)
+		oldLen = len(bkt.Get(key))
+
+		return bkt.Delete(key)
+	}); err != nil {
+		return err
+	}
+
+	p.live.Sub(uint64(oldLen))
+
+	return nil
+}
+
+func (p *pack) iterate(handler bucket.FilterHandler) error {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+
+	return p.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(bucketName).ForEach(func(k, v []byte) error {
+			if !handler(makeCopy(k), makeCopy(v)) {
+				return bucket.ErrIteratingAborted
+			}
+			return nil
+		})
+	})
+}
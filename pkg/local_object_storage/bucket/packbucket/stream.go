@@ -0,0 +1,84 @@
+package packbucket
+
+import (
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket"
+)
+
+// largeWriter writes the value to the large bucket
+// and removes the packed one on commit.
+type largeWriter struct {
+	bucket.Writer
+
+	b   *packBucket
+	key []byte
+}
+
+var _ bucket.Streamer = (*packBucket)(nil)
+
+// OpenReader returns the reader of the value by key.
+//
+// Packed values are small, so they are read in memory.
+func (b *packBucket) OpenReader(key []byte) (bucket.ReadSeekCloser, error) {
+	if p := b.packOf(key); p != nil {
+		val, err := p.getRange(key, func(val []byte) ([]byte, error) {
+			return makeCopy(val), nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return bucket.BytesReader(val), nil
+	}
+
+	if s, ok := b.large.(bucket.Streamer); ok {
+		return s.OpenReader(key)
+	}
+
+	val, err := b.large.Get(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return bucket.BytesReader(val), nil
+}
+
+// OpenWriter returns the writer of the value by key.
+//
+// Size of the streamed value is not known in advance, so it
+// is written to the large bucket if the bucket is a Streamer.
+func (b *packBucket) OpenWriter(key []byte) (bucket.Writer, error) {
+	if b.readOnly {
+		return nil, errReadOnly
+	}
+
+	key = makeCopy(key)
+
+	s, ok := b.large.(bucket.Streamer)
+	if !ok {
+		return bucket.BufferWriter(func(val []byte) error {
+			return b.Set(key, val)
+		}), nil
+	}
+
+	w, err := s.OpenWriter(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &largeWriter{
+		Writer: w,
+		b:      b,
+		key:    key,
+	}, nil
+}
+
+func (w *largeWriter) Commit() error {
+	defer w.b.lockKey(w.key)()
+
+	if err := w.Writer.Commit(); err != nil {
+		return err
+	}
+
+	// value could be packed before
+	return w.b.delPacked(w.key)
+}
@@ -0,0 +1,45 @@
+package bucket
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// Constructor creates the Bucket of the backend from the configuration.
+type Constructor func(*viper.Viper) (Bucket, error)
+
+// Registry is a set of the bucket backend constructors by backend type.
+type Registry map[string]Constructor
+
+// ErrUnknownBackend is returned by Registry.New
+// if the backend type is not registered.
+var ErrUnknownBackend = errors.New("unknown bucket backend")
+
+// New creates the Bucket of the backend type from the configuration.
+func (r Registry) New(typ string, v *viper.Viper) (Bucket, error) {
+	c, ok := r[typ]
+	if !ok {
+		return nil, errors.Wrapf(ErrUnknownBackend, "type `%s`, expected one of %v", typ, r.Types())
+	}
+
+	b, err := c(v)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not create %s bucket", typ)
+	}
+
+	return b, nil
+}
+
+// Types returns the sorted list of the registered backend types.
+func (r Registry) Types() []string {
+	res := make([]string, 0, len(r))
+	for typ := range r {
+		res = append(res, typ)
+	}
+
+	sort.Strings(res)
+
+	return res
+}
@@ -0,0 +1,29 @@
+package bucket
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry(t *testing.T) {
+	errBackend := errors.New("backend failure")
+
+	r := Registry{
+		"b": func(*viper.Viper) (Bucket, error) { return nil, errBackend },
+		"a": func(*viper.Viper) (Bucket, error) { return nil, nil },
+	}
+
+	require.Equal(t, []string{"a", "b"}, r.Types())
+
+	_, err := r.New("a", viper.New())
+	require.NoError(t, err)
+
+	_, err = r.New("b", viper.New())
+	require.True(t, errors.Is(errors.Cause(err), errBackend))
+
+	_, err = r.New("c", viper.New())
+	require.True(t, errors.Is(errors.Cause(err), ErrUnknownBackend))
+}
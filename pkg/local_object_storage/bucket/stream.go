@@ -0,0 +1,59 @@
+package bucket
+
+import (
+	"bytes"
+)
+
+type (
+	bytesReader struct {
+		*bytes.Reader
+	}
+
+	bufferWriter struct {
+		buf    bytes.Buffer
+		commit func([]byte) error
+		done   bool
+	}
+)
+
+// BytesReader returns the ReadSeekCloser of the value in memory.
+//
+// It allows the Bucket wrappers to implement Streamer
+// over the buckets that do not implement it.
+func BytesReader(val []byte) ReadSeekCloser {
+	return bytesReader{Reader: bytes.NewReader(val)}
+}
+
+// BufferWriter returns the Writer that keeps the value
+// in memory and passes it to commit function, e.g. Set.
+//
+// It allows the Bucket wrappers to implement Streamer
+// over the buckets that do not implement it.
+func BufferWriter(commit func([]byte) error) Writer {
+	return &bufferWriter{commit: commit}
+}
+
+func (bytesReader) Close() error { return nil }
+
+func (w *bufferWriter) Write(p []byte) (int, error) {
+	if w.done {
+		return 0, ErrWriterClosed
+	}
+
+	return w.buf.Write(p)
+}
+
+func (w *bufferWriter) Commit() error {
+	if w.done {
+		return ErrWriterClosed
+	}
+
+	w.done = true
+
+	return w.commit(w.buf.Bytes())
+}
+
+func (w *bufferWriter) Abort() error {
+	w.done = true
+	return nil
+}
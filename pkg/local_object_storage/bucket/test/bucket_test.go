@@ -0,0 +1,13 @@
+package test
+
+import (
+	"testing"
+)
+
+func TestBucket(t *testing.T) {
+	BucketSuite(t, Bucket())
+}
+
+func TestBucket_Streamer(t *testing.T) {
+	StreamerSuite(t, Bucket())
+}
@@ -0,0 +1,295 @@
+package test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"io"
+	"io/ioutil"
+	"math"
+	"sort"
+	"testing"
+
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func suiteKey(name string) []byte {
+	k := sha256.Sum256([]byte(name))
+	return k[:]
+}
+
+func sortKeys(keys [][]byte) [][]byte {
+	sort.Slice(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i], keys[j]) < 0
+	})
+
+	return keys
+}
+
+// BucketSuite checks that the bucket implements bucket.Bucket and the
+// optional RangeReader and PrefixIterator the way the storage expects.
+//
+// Bucket must be empty, it is empty after the suite too. Keys are 32 bytes
+// long, so the suite fits the buckets that need long keys.
+func BucketSuite(t *testing.T, b bucket.Bucket) {
+	notFound := func(t *testing.T, err error) {
+		require.True(t, errors.Is(errors.Cause(err), bucket.ErrNotFound))
+	}
+
+	t.Run("missing value", func(t *testing.T) {
+		k := suiteKey("missing")
+
+		require.False(t, b.Has(k))
+
+		_, err := b.Get(k)
+		notFound(t, err)
+	})
+
+	t.Run("set, get and delete", func(t *testing.T) {
+		k := suiteKey("value")
+
+		require.NoError(t, b.Set(k, []byte("old value")))
+		require.NoError(t, b.Set(k, []byte("value")))
+		require.True(t, b.Has(k))
+
+		val, err := b.Get(k)
+		require.NoError(t, err)
+		require.Equal(t, []byte("value"), val)
+
+		require.NoError(t, b.Del(k))
+		require.False(t, b.Has(k))
+
+		_, err = b.Get(k)
+		notFound(t, err)
+	})
+
+	t.Run("list and iterate", func(t *testing.T) {
+		items := make(map[string][]byte)
+		keys := make([][]byte, 0, 3)
+
+		for _, name := range []string{"a", "b", "c"} {
+			k := suiteKey(name)
+			require.NoError(t, b.Set(k, []byte(name)))
+
+			items[string(k)] = []byte(name)
+			keys = append(keys, k)
+		}
+
+		list, err := b.List()
+		require.NoError(t, err)
+		require.Equal(t, sortKeys(keys), sortKeys(list))
+
+		visited := make(map[string][]byte)
+
+		require.NoError(t, b.Iterate(func(key, val []byte) bool {
+			visited[string(key)] = val
+			return true
+		}))
+		require.Equal(t, items, visited)
+
+		calls := 0
+
+		err = b.Iterate(func(key, val []byte) bool {
+			calls++
+			return false
+		})
+		require.True(t, errors.Is(errors.Cause(err), bucket.ErrIteratingAborted))
+		require.Equal(t, 1, calls)
+
+		for i := range keys {
+			require.NoError(t, b.Del(keys[i]))
+		}
+
+		list, err = b.List()
+		require.NoError(t, err)
+		require.Empty(t, list)
+	})
+
+	if r, ok := b.(bucket.RangeReader); ok {
+		t.Run("range", func(t *testing.T) {
+			k := suiteKey("range")
+
+			_, err := r.GetRange(k, 0, 1)
+			notFound(t, err)
+
+			require.NoError(t, b.Set(k, []byte("0123456789")))
+
+			val, err := r.GetRange(k, 2, 5)
+			require.NoError(t, err)
+			require.Equal(t, []byte("23456"), val)
+
+			for _, rng := range [][2]uint64{
+				{8, 3},
+				{11, 0},
+				{2, math.MaxUint64},
+				{math.MaxUint64, 2},
+			} {
+				_, err = r.GetRange(k, rng[0], rng[1])
+				require.True(t, errors.Is(errors.Cause(err), bucket.ErrOutOfRange), rng)
+			}
+
+			require.NoError(t, b.Del(k))
+		})
+	}
+
+	if p, ok := b.(bucket.PrefixIterator); ok {
+		t.Run("prefix", func(t *testing.T) {
+			var (
+				prefix = []byte{0xAB, 0xCD}
+				keys   [][]byte
+			)
+
+			for i := 0; i < 3; i++ {
+				k := suiteKey(string(rune('a' + i)))
+
+				// the last key does not have the prefix
+				if i < 2 {
+					copy(k, prefix)
+					keys = append(keys, k)
+				} else {
+					k[0] = 0xAC
+				}
+
+				require.NoError(t, b.Set(k, []byte{byte(i)}))
+			}
+
+			var visited [][]byte
+
+			require.NoError(t, p.IteratePrefix(prefix, func(key, _ []byte) bool {
+				visited = append(visited, key)
+				return true
+			}))
+			require.Equal(t, sortKeys(keys), sortKeys(visited))
+
+			list, err := b.List()
+			require.NoError(t, err)
+
+			for i := range list {
+				require.NoError(t, b.Del(list[i]))
+			}
+		})
+	}
+}
+
+// StreamerSuite checks that the bucket implements
+// bucket.Streamer the way the storage expects.
+//
+// Bucket must be empty. Keys are 32 bytes long, so the suite
+// fits the buckets that need long keys, e.g. tree fsbucket.
+func StreamerSuite(t *testing.T, b bucket.Bucket) {
+	s, ok := b.(bucket.Streamer)
+	require.True(t, ok, "bucket does not implement Streamer")
+
+	write := func(t *testing.T, k []byte, parts ...string) bucket.Writer {
+		w, err := s.OpenWriter(k)
+		require.NoError(t, err)
+
+		for i := range parts {
+			n, err := w.Write([]byte(parts[i]))
+			require.NoError(t, err)
+			require.Equal(t, len(parts[i]), n)
+		}
+
+		return w
+	}
+
+	t.Run("missing value", func(t *testing.T) {
+		_, err := s.OpenReader(suiteKey("missing"))
+		require.True(t, errors.Is(errors.Cause(err), bucket.ErrNotFound))
+	})
+
+	t.Run("commit", func(t *testing.T) {
+		k := suiteKey("commit")
+
+		w := write(t, k, "streamed ", "value")
+
+		require.False(t, b.Has(k))
+
+		list, err := b.List()
+		require.NoError(t, err)
+		require.Empty(t, list)
+
+		require.NoError(t, w.Commit())
+
+		val, err := b.Get(k)
+		require.NoError(t, err)
+		require.Equal(t, []byte("streamed value"), val)
+
+		_, err = w.Write([]byte("late"))
+		require.True(t, errors.Is(err, bucket.ErrWriterClosed))
+		require.True(t, errors.Is(w.Commit(), bucket.ErrWriterClosed))
+		require.NoError(t, w.Abort())
+
+		require.NoError(t, b.Del(k))
+	})
+
+	t.Run("read and seek", func(t *testing.T) {
+		k := suiteKey("read")
+		require.NoError(t, b.Set(k, []byte("0123456789")))
+
+		r, err := s.OpenReader(k)
+		require.NoError(t, err)
+
+		data, err := ioutil.ReadAll(r)
+		require.NoError(t, err)
+		require.Equal(t, []byte("0123456789"), data)
+
+		off, err := r.Seek(4, io.SeekStart)
+		require.NoError(t, err)
+		require.Equal(t, int64(4), off)
+
+		part := make([]byte, 3)
+		_, err = io.ReadFull(r, part)
+		require.NoError(t, err)
+		require.Equal(t, []byte("456"), part)
+
+		off, err = r.Seek(-2, io.SeekEnd)
+		require.NoError(t, err)
+		require.Equal(t, int64(8), off)
+
+		data, err = ioutil.ReadAll(r)
+		require.NoError(t, err)
+		require.Equal(t, []byte("89"), data)
+
+		require.NoError(t, r.Close())
+		require.NoError(t, b.Del(k))
+	})
+
+	t.Run("abort", func(t *testing.T) {
+		k := suiteKey("abort")
+
+		require.NoError(t, write(t, k, "discarded").Abort())
+		require.False(t, b.Has(k))
+
+		require.NoError(t, b.Set(k, []byte("stored")))
+		require.NoError(t, write(t, k, "discarded").Abort())
+
+		val, err := b.Get(k)
+		require.NoError(t, err)
+		require.Equal(t, []byte("stored"), val)
+
+		list, err := b.List()
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+
+		require.NoError(t, b.Del(k))
+	})
+
+	t.Run("overwrite", func(t *testing.T) {
+		k := suiteKey("overwrite")
+
+		require.NoError(t, b.Set(k, []byte("old value")))
+		require.NoError(t, write(t, k, "new").Commit())
+
+		r, err := s.OpenReader(k)
+		require.NoError(t, err)
+
+		data, err := ioutil.ReadAll(r)
+		require.NoError(t, err)
+		require.NoError(t, r.Close())
+		require.Equal(t, []byte("new"), data)
+
+		require.NoError(t, b.Del(k))
+	})
+}
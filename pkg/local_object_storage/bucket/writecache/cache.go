@@ -0,0 +1,210 @@
+package writecache
+
+import (
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+type (
+	// writeCache is a bucket that acknowledges writes as soon as
+	// they are saved to the cache bucket on fast media and moves
+	// them to the main bucket in background.
+	writeCache struct {
+		log *zap.Logger
+
+		// metrics label of the cache
+		name string
+
+		cache, main bucket.Bucket
+
+		maxSize       uint64
+		flushInterval time.Duration
+
+		// serialize the modifications and the flush of the same key,
+		// so the bucket I/O is done without the lock below
+		keyLocks [keyLockCount]sync.Mutex
+
+		// guards all the fields below
+		mtx sync.Mutex
+
+		// signals about the end of the flush
+		flushed *sync.Cond
+
+		// items of the cache bucket that are not flushed yet
+		pending map[string]pendingItem
+
+		// flush queue, items replaced since enqueuing are skipped
+		queue []queueItem
+
+		flushing bool
+
+		size uint64
+		gen  uint64
+
+		// size of the items that are being written to the cache bucket
+		reserved uint64
+
+		wake chan struct{}
+	}
+
+	pendingItem struct {
+		gen   uint64
+		size  uint64
+		added time.Time
+	}
+
+	queueItem struct {
+		key string
+		gen uint64
+	}
+)
+
+const name = "writecache"
+
+const (
+	defaultMaxSize       = 1 << 30 // 1 GiB
+	defaultFlushInterval = time.Second
+
+	keyLockCount = 256
+)
+
+var (
+	errNilCacheBucket = errors.New("cache bucket is nil")
+	errNilMainBucket  = errors.New("main bucket is nil")
+	errNilLogger      = errors.New("logger is nil")
+)
+
+func isNotFound(err error) bool {
+	return errors.Is(errors.Cause(err), bucket.ErrNotFound)
+}
+
+// NewBucket creates write cache instance over the main bucket.
+//
+// Items of the cache bucket that were not flushed before the
+// restart are enqueued for the flush again.
+//
+// Bucket takes the ownership of both buckets and closes them on Close.
+func NewBucket(v *viper.Viper, l *zap.Logger, cache, main bucket.Bucket) (bucket.Bucket, error) {
+	switch {
+	case cache == nil:
+		return nil, errNilCacheBucket
+	case main == nil:
+		return nil, errNilMainBucket
+	case l == nil:
+		return nil, errNilLogger
+	}
+
+	b := &writeCache{
+		name:    v.GetString(name + ".path"),
+		cache:   cache,
+		main:    main,
+		pending: make(map[string]pendingItem),
+		wake:    make(chan struct{}, 1),
+	}
+
+	b.log = l.With(zap.String("write cache", b.name))
+	b.flushed = sync.NewCond(&b.mtx)
+
+	if b.maxSize = v.GetUint64(name + ".max_size"); b.maxSize == 0 {
+		b.maxSize = defaultMaxSize
+	}
+
+	if b.flushInterval = v.GetDuration(name + ".flush_interval"); b.flushInterval <= 0 {
+		b.flushInterval = defaultFlushInterval
+	}
+
+	now := time.Now()
+
+	if err := cache.Iterate(func(k, v []byte) bool {
+		b.push(string(k), uint64(len(v)), now)
+		return true
+	}); err != nil {
+		return nil, errors.Wrap(err, "could not read cache bucket")
+	}
+
+	if len(b.pending) > 0 {
+		b.log.Info("write cache has items to flush",
+			zap.Int("count", len(b.pending)),
+			zap.Uint64("size", b.size))
+	}
+
+	b.updateMetrics()
+
+	return b, nil
+}
+
+// push adds new pending item and enqueues it to flush.
+//
+// Must be called under the lock.
+func (b *writeCache) push(k string, sz uint64, added time.Time) {
+	b.gen++
+
+	if old, ok := b.pending[k]; ok {
+		b.size -= old.size
+	}
+
+	b.pending[k] = pendingItem{
+		gen:   b.gen,
+		size:  sz,
+		added: added,
+	}
+
+	b.size += sz
+	b.queue = append(b.queue, queueItem{key: k, gen: b.gen})
+}
+
+// remove drops pending item.
+//
+// Must be called under the lock.
+func (b *writeCache) remove(k string) {
+	if old, ok := b.pending[k]; ok {
+		b.size -= old.size
+		delete(b.pending, k)
+	}
+}
+
+// lockKey locks the modifications and the flush of the item
+// by key and returns the unlock function.
+func (b *writeCache) lockKey(k string) func() {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(k))
+
+	m := &b.keyLocks[h.Sum32()%keyLockCount]
+	m.Lock()
+
+	return m.Unlock
+}
+
+// oldest returns the oldest pending item of the queue.
+//
+// Must be called under the lock.
+func (b *writeCache) oldest() (queueItem, pendingItem, bool) {
+	for len(b.queue) > 0 {
+		qi := b.queue[0]
+
+		if p, ok := b.pending[qi.key]; ok && p.gen == qi.gen {
+			return qi, p, true
+		}
+
+		// item has been removed or replaced since enqueuing
+		b.queue = b.queue[1:]
+	}
+
+	// release the memory of the drained queue
+	b.queue = nil
+
+	return queueItem{}, pendingItem{}, false
+}
+
+func (b *writeCache) notify() {
+	select {
+	case b.wake <- struct{}{}:
+	default:
+	}
+}
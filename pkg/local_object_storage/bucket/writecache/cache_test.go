@@ -0,0 +1,322 @@
+package writecache
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket/boltdb"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket/test"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type (
+	testBuckets struct {
+		dir         string
+		cache, main bucket.Bucket
+	}
+
+	// failingBucket fails all the writes.
+	failingBucket struct {
+		bucket.Bucket
+	}
+)
+
+var errWrite = errors.New("write failure")
+
+func (failingBucket) Set([]byte, []byte) error { return errWrite }
+
+func newTestBuckets(t *testing.T) *testBuckets {
+	dir, err := ioutil.TempDir("", "writeCache_test")
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	b := &testBuckets{dir: dir}
+	b.open(t)
+
+	return b
+}
+
+func (b *testBuckets) open(t *testing.T) {
+	newBolt := func(name string) bucket.Bucket {
+		res, err := boltdb.NewBucket(&boltdb.Options{
+			Name: []byte(name),
+			Path: path.Join(b.dir, name+".db"),
+			Perm: 0600,
+		})
+		require.NoError(t, err)
+
+		return res
+	}
+
+	b.cache, b.main = newBolt("cache"), newBolt("main")
+}
+
+func (b *testBuckets) writeCache(t *testing.T, maxSize int) *writeCache {
+	v := viper.New()
+	v.Set("writecache.path", path.Join(b.dir, "cache.db"))
+	v.Set("writecache.max_size", maxSize)
+
+	res, err := NewBucket(v, zap.L(), b.cache, b.main)
+	require.NoError(t, err)
+
+	return res.(*writeCache)
+}
+
+func requireValue(t *testing.T, b bucket.Bucket, k, v []byte) {
+	res, err := b.Get(k)
+	require.NoError(t, err)
+	require.Equal(t, v, res)
+}
+
+func requireMissing(t *testing.T, b bucket.Bucket, k []byte) {
+	_, err := b.Get(k)
+	require.True(t, errors.Is(errors.Cause(err), bucket.ErrNotFound))
+}
+
+func TestWriteCache(t *testing.T) {
+	b := newTestBuckets(t)
+	wc := b.writeCache(t, 1<<20)
+
+	k, v := []byte("key"), []byte("value")
+
+	require.NoError(t, wc.Set(k, v))
+	require.True(t, wc.Has(k))
+	requireValue(t, wc, k, v)
+	requireValue(t, b.cache, k, v)
+	requireMissing(t, b.main, k)
+
+	res, err := wc.GetRange(k, 1, 3)
+	require.NoError(t, err)
+	require.Equal(t, v[1:4], res)
+
+	keys, err := wc.List()
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{k}, keys)
+
+	require.NoError(t, wc.Flush())
+	require.Empty(t, wc.pending)
+	require.Zero(t, wc.size)
+	requireValue(t, wc, k, v)
+	requireValue(t, b.main, k, v)
+	requireMissing(t, b.cache, k)
+
+	t.Run("delete pending", func(t *testing.T) {
+		v2 := []byte("new value")
+
+		require.NoError(t, wc.Set(k, v2))
+		requireValue(t, wc, k, v2)
+
+		require.NoError(t, wc.Del(k))
+		require.False(t, wc.Has(k))
+		requireMissing(t, b.cache, k)
+		requireMissing(t, b.main, k)
+	})
+
+	t.Run("write through", func(t *testing.T) {
+		wc.maxSize = uint64(len(v))
+
+		k1, k2 := []byte("key1"), []byte("key2")
+
+		require.NoError(t, wc.Set(k1, v))
+		requireValue(t, b.cache, k1, v)
+
+		// cache is full
+		require.NoError(t, wc.Set(k2, v))
+		requireMissing(t, b.cache, k2)
+		requireValue(t, b.main, k2, v)
+
+		cnt := 0
+		require.NoError(t, wc.Iterate(func(k, val []byte) bool {
+			require.Equal(t, v, val)
+			cnt++
+			return true
+		}))
+		require.Equal(t, 2, cnt)
+
+		// pending value is replaced by the written through one
+		v2 := []byte("new value")
+
+		require.NoError(t, wc.Set(k1, v2))
+		require.False(t, wc.cached(k1))
+		requireMissing(t, b.cache, k1)
+		requireValue(t, wc, k1, v2)
+	})
+
+	t.Run("write through failure", func(t *testing.T) {
+		k := []byte("key3")
+
+		wc.maxSize = 1 << 20
+		require.NoError(t, wc.Set(k, v))
+
+		wc.maxSize = uint64(len(v))
+		main := wc.main
+		wc.main = failingBucket{main}
+
+		require.Error(t, wc.Set(k, []byte("new value")))
+
+		wc.main = main
+
+		// pending value is kept
+		require.True(t, wc.cached(k))
+		requireValue(t, wc, k, v)
+	})
+}
+
+func TestWriteCache_Run(t *testing.T) {
+	b := newTestBuckets(t)
+	wc := b.writeCache(t, 1<<20)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		wc.Run(ctx)
+		close(done)
+	}()
+
+	items := map[string][]byte{
+		"key1": []byte("value1"),
+		"key2": []byte("value2"),
+		"key3": []byte("value3"),
+	}
+
+	for k, v := range items {
+		require.NoError(t, wc.Set([]byte(k), v))
+	}
+
+	// shutdown flushes remaining items
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("write cache was not stopped")
+	}
+
+	for k, v := range items {
+		requireValue(t, b.main, []byte(k), v)
+		requireMissing(t, b.cache, []byte(k))
+	}
+}
+
+func TestWriteCache_Concurrent(t *testing.T) {
+	b := newTestBuckets(t)
+
+	// small cache mixes cached writes and write-through ones
+	wc := b.writeCache(t, 64)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		wc.Run(ctx)
+		close(done)
+	}()
+
+	wg := new(sync.WaitGroup)
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			k := []byte(fmt.Sprintf("key%d", i%3))
+
+			for j := 0; j < 20; j++ {
+				v := []byte(fmt.Sprintf("value%d-%d", i, j))
+
+				if err := wc.Set(k, v); err != nil {
+					t.Error(err)
+					return
+				}
+
+				if _, err := wc.Get(k); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}(i)
+	}
+
+	wg.Wait()
+	cancel()
+	<-done
+
+	for i := 0; i < 3; i++ {
+		k := []byte(fmt.Sprintf("key%d", i))
+
+		res, err := wc.Get(k)
+		require.NoError(t, err)
+		requireValue(t, b.main, k, res)
+		requireMissing(t, b.cache, k)
+	}
+}
+
+func TestWriteCache_Streamer(t *testing.T) {
+	t.Run("buffered", func(t *testing.T) {
+		test.StreamerSuite(t, newTestBuckets(t).writeCache(t, 1<<20))
+	})
+
+	t.Run("main streamer", func(t *testing.T) {
+		main := test.Bucket()
+
+		res, err := NewBucket(viper.New(), zap.L(), test.Bucket(), main)
+		require.NoError(t, err)
+
+		test.StreamerSuite(t, res)
+
+		wc := res.(*writeCache)
+		k := []byte("key")
+
+		require.NoError(t, wc.Set(k, []byte("cached")))
+
+		w, err := wc.OpenWriter(k)
+		require.NoError(t, err)
+
+		_, err = w.Write([]byte("streamed"))
+		require.NoError(t, err)
+		require.NoError(t, w.Commit())
+
+		// cached value is not flushed over the streamed one
+		require.NoError(t, wc.Flush())
+		requireValue(t, wc, k, []byte("streamed"))
+		requireValue(t, main, k, []byte("streamed"))
+	})
+}
+
+func TestWriteCache_Restart(t *testing.T) {
+	b := newTestBuckets(t)
+	wc := b.writeCache(t, 1<<20)
+
+	k, v := []byte("key"), []byte("value")
+	require.NoError(t, wc.Set(k, v))
+
+	// simulate crash without flush
+	require.NoError(t, b.cache.Close())
+	require.NoError(t, b.main.Close())
+
+	b.open(t)
+	wc = b.writeCache(t, 1<<20)
+
+	require.Len(t, wc.pending, 1)
+	require.Equal(t, uint64(len(v)), wc.size)
+	requireValue(t, wc, k, v)
+
+	require.NoError(t, wc.Close())
+
+	b.open(t)
+	requireValue(t, b.main, k, v)
+	requireMissing(t, b.cache, k)
+}
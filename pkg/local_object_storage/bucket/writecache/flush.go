@@ -0,0 +1,121 @@
+package writecache
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// Run flushes cached items to the main bucket until the context is done.
+//
+// All the remaining items are flushed after the context is done, so
+// the cache is empty after the graceful shutdown of the node.
+func (b *writeCache) Run(ctx context.Context) {
+	ticker := time.NewTicker(b.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		for ctx.Err() == nil {
+			ok, err := b.flushOldest()
+			if err != nil {
+				b.log.Warn("could not flush cached item",
+					zap.Error(err))
+
+				// retry on the next tick
+				break
+			} else if !ok {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			b.log.Info("flush write cache before shutdown")
+
+			if err := b.Flush(); err != nil {
+				b.log.Error("could not flush write cache",
+					zap.Error(err))
+			}
+
+			return
+		case <-b.wake:
+		case <-ticker.C:
+			b.mtx.Lock()
+			b.updateMetrics()
+			b.mtx.Unlock()
+		}
+	}
+}
+
+// Flush moves all cached items to the main bucket.
+func (b *writeCache) Flush() error {
+	for {
+		ok, err := b.flushOldest()
+		if err != nil {
+			return err
+		} else if !ok {
+			return nil
+		}
+	}
+}
+
+// flushOldest moves the oldest cached item to the main bucket.
+// Returns false if there is nothing to flush.
+func (b *writeCache) flushOldest() (bool, error) {
+	b.mtx.Lock()
+
+	// wait for the concurrent Flush call
+	for b.flushing {
+		b.flushed.Wait()
+	}
+
+	qi, _, ok := b.oldest()
+	if !ok {
+		b.mtx.Unlock()
+		return false, nil
+	}
+
+	b.flushing = true
+	b.mtx.Unlock()
+
+	defer func() {
+		b.mtx.Lock()
+		b.flushing = false
+		b.flushed.Broadcast()
+		b.mtx.Unlock()
+	}()
+
+	defer b.lockKey(qi.key)()
+
+	// item could be replaced or removed before the key was locked,
+	// then it is skipped by the next oldest call
+	b.mtx.Lock()
+	p, ok := b.pending[qi.key]
+	b.mtx.Unlock()
+
+	if !ok || p.gen != qi.gen {
+		return true, nil
+	}
+
+	val, err := b.cache.Get([]byte(qi.key))
+	if err != nil {
+		return false, errors.Wrap(err, "could not read cached item")
+	}
+
+	if err := b.main.Set([]byte(qi.key), val); err != nil {
+		return false, errors.Wrap(err, "could not write item to main bucket")
+	}
+
+	if err := b.cache.Del([]byte(qi.key)); err != nil && !isNotFound(err) {
+		return false, errors.Wrap(err, "could not remove flushed item")
+	}
+
+	b.mtx.Lock()
+	b.remove(qi.key)
+	b.updateMetrics()
+	b.mtx.Unlock()
+
+	return true, nil
+}
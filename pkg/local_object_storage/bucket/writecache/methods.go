@@ -0,0 +1,251 @@
+package writecache
+
+import (
+	"time"
+
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket"
+	"go.uber.org/zap"
+)
+
+// Get value by key.
+//
+// Cache bucket is checked first.
+func (b *writeCache) Get(key []byte) ([]byte, error) {
+	if b.cached(key) {
+		val, err := b.cache.Get(key)
+		if err == nil || !isNotFound(err) {
+			return val, err
+		}
+	}
+
+	// item is removed from the cache only after
+	// it is saved in the main bucket
+	return b.main.Get(key)
+}
+
+// GetRange returns the part of the value by key.
+func (b *writeCache) GetRange(key []byte, off, ln uint64) ([]byte, error) {
+	if b.cached(key) {
+		val, err := getRange(b.cache, key, off, ln)
+		if err == nil || !isNotFound(err) {
+			return val, err
+		}
+	}
+
+	return getRange(b.main, key, off, ln)
+}
+
+func getRange(src bucket.Bucket, key []byte, off, ln uint64) ([]byte, error) {
+	if rr, ok := src.(bucket.RangeReader); ok {
+		return rr.GetRange(key, off, ln)
+	}
+
+	val, err := src.Get(key)
+	if err != nil {
+		return nil, err
+	} else if off > uint64(len(val)) || ln > uint64(len(val))-off {
+		return nil, bucket.ErrOutOfRange
+	}
+
+	return val[off : off+ln], nil
+}
+
+// cached checks if the item is pending in the cache bucket.
+//
+// Item can be flushed concurrently, so it must be
+// read from the main bucket if it is missing in cache.
+func (b *writeCache) cached(key []byte) bool {
+	b.mtx.Lock()
+	_, ok := b.pending[string(key)]
+	b.mtx.Unlock()
+
+	return ok
+}
+
+// Set value by key.
+//
+// Value is saved in the cache bucket and flushed to the main bucket
+// later. If the cache is full, value is written to the main bucket
+// directly, so the writers are slowed down to the speed of the main
+// bucket until the flush frees the cache.
+func (b *writeCache) Set(key, value []byte) error {
+	k, sz := string(key), uint64(len(value))
+
+	defer b.lockKey(k)()
+
+	b.mtx.Lock()
+
+	old, cached := b.pending[k]
+
+	full := b.size+b.reserved-old.size+sz > b.maxSize
+	if !full {
+		b.reserved += sz
+	}
+
+	b.mtx.Unlock()
+
+	if full {
+		writeThroughCounter.Inc()
+
+		if err := b.main.Set(key, value); err != nil {
+			return err
+		}
+
+		// pending value is dropped only after the new one is saved,
+		// so the failed write does not lose it
+		if cached {
+			return b.dropCached(key)
+		}
+
+		return nil
+	}
+
+	err := b.cache.Set(key, value)
+
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	b.reserved -= sz
+
+	if err != nil {
+		return err
+	}
+
+	b.push(k, sz, time.Now())
+	b.updateMetrics()
+	b.notify()
+
+	return nil
+}
+
+// Del removes item from both cache and main buckets.
+func (b *writeCache) Del(key []byte) error {
+	// flush of the item must not restore it in the main bucket
+	defer b.lockKey(string(key))()
+
+	cached := b.cached(key)
+	if cached {
+		if err := b.dropCached(key); err != nil {
+			return err
+		}
+	}
+
+	if err := b.main.Del(key); err != nil && !(cached && isNotFound(err)) {
+		return err
+	}
+
+	return nil
+}
+
+// dropCached removes pending item from the cache bucket.
+//
+// Must be called under the key lock.
+func (b *writeCache) dropCached(key []byte) error {
+	if err := b.cache.Del(key); err != nil && !isNotFound(err) {
+		return err
+	}
+
+	b.mtx.Lock()
+	b.remove(string(key))
+	b.updateMetrics()
+	b.mtx.Unlock()
+
+	return nil
+}
+
+// Has checks key exists.
+func (b *writeCache) Has(key []byte) bool {
+	return b.cached(key) || b.main.Has(key)
+}
+
+// Size returns the size of cache and main buckets.
+func (b *writeCache) Size() int64 {
+	return b.cache.Size() + b.main.Size()
+}
+
+// List all items in bucket.
+func (b *writeCache) List() ([][]byte, error) {
+	items, err := b.main.List()
+	if err != nil {
+		return nil, err
+	}
+
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	known := make(map[string]struct{}, len(items))
+	for i := range items {
+		known[string(items[i])] = struct{}{}
+	}
+
+	for k := range b.pending {
+		if _, ok := known[k]; !ok {
+			items = append(items, []byte(k))
+		}
+	}
+
+	return items, nil
+}
+
+// Iterate walks over cached items first and then over the main ones.
+//
+// Iteration is not atomic, items that are flushed during
+// the iteration can be skipped.
+func (b *writeCache) Iterate(handler bucket.FilterHandler) error {
+	if handler == nil {
+		return bucket.ErrNilFilterHandler
+	}
+
+	b.mtx.Lock()
+	cached := make(map[string]struct{}, len(b.pending))
+	for k := range b.pending {
+		cached[k] = struct{}{}
+	}
+	b.mtx.Unlock()
+
+	if err := b.cache.Iterate(func(k, v []byte) bool {
+		if _, ok := cached[string(k)]; !ok {
+			return true
+		}
+
+		return handler(k, v)
+	}); err != nil {
+		return err
+	}
+
+	return b.main.Iterate(func(k, v []byte) bool {
+		if _, ok := cached[string(k)]; ok {
+			return true
+		}
+
+		return handler(k, v)
+	})
+}
+
+// Close flushes all cached items and closes both buckets.
+func (b *writeCache) Close() error {
+	err := b.Flush()
+	if err != nil {
+		b.log.Error("could not flush write cache before close",
+			zap.Error(err))
+	}
+
+	if cErr := b.cache.Close(); err == nil {
+		err = cErr
+	}
+
+	if mErr := b.main.Close(); err == nil {
+		err = mErr
+	}
+
+	return err
+}
+
+// Compact reclaims the space of the main bucket if it supports compaction.
+func (b *writeCache) Compact() error {
+	if c, ok := b.main.(bucket.Compactor); ok {
+		return c.Compact()
+	}
+
+	return nil
+}
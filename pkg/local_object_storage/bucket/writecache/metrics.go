@@ -0,0 +1,59 @@
+package writecache
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const cacheLabel = "cache"
+
+var (
+	cacheSize = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "neofs",
+		Name:      "write_cache_size",
+		Help:      "Size of the items in write cache that are not flushed yet",
+	}, []string{cacheLabel})
+
+	cacheObjects = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "neofs",
+		Name:      "write_cache_objects",
+		Help:      "Number of the items in write cache that are not flushed yet",
+	}, []string{cacheLabel})
+
+	flushLag = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "neofs",
+		Name:      "write_cache_flush_lag_seconds",
+		Help:      "Age of the oldest item in write cache",
+	}, []string{cacheLabel})
+
+	writeThroughCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "neofs",
+		Name:      "write_cache_write_through",
+		Help:      "Number of writes passed to the main bucket due to full write cache",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(
+		cacheSize,
+		cacheObjects,
+		flushLag,
+		writeThroughCounter,
+	)
+}
+
+// updateMetrics must be called under the lock.
+func (b *writeCache) updateMetrics() {
+	labels := prometheus.Labels{cacheLabel: b.name}
+
+	cacheSize.With(labels).Set(float64(b.size))
+	cacheObjects.With(labels).Set(float64(len(b.pending)))
+
+	var lag time.Duration
+	if _, p, ok := b.oldest(); ok {
+		lag = time.Since(p.added)
+	}
+
+	flushLag.With(labels).Set(lag.Seconds())
+}
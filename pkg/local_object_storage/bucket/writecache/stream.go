@@ -0,0 +1,92 @@
+package writecache
+
+import (
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket"
+)
+
+// mainWriter writes the value to the main bucket
+// and drops the cached one on commit.
+type mainWriter struct {
+	bucket.Writer
+
+	b   *writeCache
+	key []byte
+}
+
+var _ bucket.Streamer = (*writeCache)(nil)
+
+// OpenReader returns the reader of the value by key.
+//
+// Cache bucket is checked first.
+func (b *writeCache) OpenReader(key []byte) (bucket.ReadSeekCloser, error) {
+	if b.cached(key) {
+		r, err := openReader(b.cache, key)
+		if err == nil || !isNotFound(err) {
+			return r, err
+		}
+	}
+
+	// item is removed from the cache only after
+	// it is saved in the main bucket
+	return openReader(b.main, key)
+}
+
+func openReader(src bucket.Bucket, key []byte) (bucket.ReadSeekCloser, error) {
+	if s, ok := src.(bucket.Streamer); ok {
+		return s.OpenReader(key)
+	}
+
+	val, err := src.Get(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return bucket.BytesReader(val), nil
+}
+
+// OpenWriter returns the writer of the value by key.
+//
+// Streamed values are usually large, so they are written to the main
+// bucket directly if it is a Streamer. Otherwise the value is kept in
+// memory and saved with Set on commit.
+func (b *writeCache) OpenWriter(key []byte) (bucket.Writer, error) {
+	key = makeCopy(key)
+
+	s, ok := b.main.(bucket.Streamer)
+	if !ok {
+		return bucket.BufferWriter(func(val []byte) error {
+			return b.Set(key, val)
+		}), nil
+	}
+
+	w, err := s.OpenWriter(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mainWriter{
+		Writer: w,
+		b:      b,
+		key:    key,
+	}, nil
+}
+
+func (w *mainWriter) Commit() error {
+	defer w.b.lockKey(string(w.key))()
+
+	// cached value must not be flushed over the committed one
+	if w.b.cached(w.key) {
+		if err := w.b.dropCached(w.key); err != nil {
+			return err
+		}
+	}
+
+	return w.Writer.Commit()
+}
+
+func makeCopy(val []byte) []byte {
+	tmp := make([]byte, len(val))
+	copy(tmp, val)
+
+	return tmp
+}
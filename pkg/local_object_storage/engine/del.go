@@ -0,0 +1,111 @@
+package engine
+
+import (
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/localstore"
+	"github.com/pkg/errors"
+)
+
+// errNotWritableShard is returned by Del if the object
+// is stored in the shard that does not accept writes.
+var errNotWritableShard = errors.New("object is stored in not writable shard")
+
+// Del removes the object from all writable shards that store it.
+//
+// Del fails if any of the available not writable shards stores the object,
+// since it remains readable there.
+func (e *StorageEngine) Del(addr localstore.Address) error {
+	var err error
+
+	for _, s := range e.shards {
+		if !s.readable() {
+			continue
+		}
+
+		ok, hasErr := s.ls.Has(addr)
+		if hasErr != nil {
+			s.readFailed(hasErr)
+			err = hasErr
+
+			continue
+		} else if !ok {
+			continue
+		} else if !s.writable() {
+			err = errors.Wrapf(errNotWritableShard, "%s (%s)", s.ID(), s.Mode())
+
+			continue
+		}
+
+		if delErr := s.ls.Del(addr); delErr != nil {
+			s.writeFailed(delErr)
+			err = delErr
+		}
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "StorageEngine Del failed")
+	}
+
+	return nil
+}
+
+// Quarantine moves the object to the quarantine of all writable shards that store it.
+func (e *StorageEngine) Quarantine(addr localstore.Address) error {
+	var err error
+
+	for _, s := range e.shards {
+		if !s.writable() {
+			continue
+		}
+
+		q, ok := s.ls.(localstore.Quarantiner)
+		if !ok {
+			continue
+		}
+
+		ok, hasErr := s.ls.Has(addr)
+		if hasErr != nil {
+			s.readFailed(hasErr)
+			err = hasErr
+
+			continue
+		} else if !ok {
+			continue
+		}
+
+		if qErr := q.Quarantine(addr); qErr != nil {
+			if !errors.Is(errors.Cause(qErr), localstore.ErrQuarantineDisabled) {
+				s.writeFailed(qErr)
+			}
+
+			err = qErr
+		}
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "StorageEngine Quarantine failed")
+	}
+
+	return nil
+}
+
+// Quarantined checks whether any of the available shards keeps the object in the quarantine.
+func (e *StorageEngine) Quarantined(addr localstore.Address) (bool, error) {
+	for _, s := range e.shards {
+		if !s.readable() {
+			continue
+		}
+
+		q, ok := s.ls.(localstore.Quarantiner)
+		if !ok {
+			continue
+		}
+
+		if ok, err := q.Quarantined(addr); err != nil {
+			s.readFailed(err)
+		} else if ok {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
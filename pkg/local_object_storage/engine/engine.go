@@ -0,0 +1,140 @@
+package engine
+
+import (
+	"github.com/nspcc-dev/hrw"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/localstore"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+type (
+	// StorageEngine is a local object storage that spreads
+	// objects over several shards.
+	//
+	// StorageEngine implements localstore.Localstore interface.
+	StorageEngine struct {
+		log *zap.Logger
+
+		shards  []*Shard
+		mShards map[string]*Shard
+	}
+
+	// Params groups the parameters of StorageEngine constructor.
+	Params struct {
+		Shards []*Shard
+		Logger *zap.Logger
+	}
+)
+
+// ErrNoShards is returned by StorageEngine if no shard
+// is available for the operation.
+var ErrNoShards = errors.New("no available shards")
+
+var errNilLogger = errors.New("logger is nil")
+
+var _ localstore.Localstore = (*StorageEngine)(nil)
+
+// New is a StorageEngine constructor.
+func New(p Params) (*StorageEngine, error) {
+	switch {
+	case len(p.Shards) == 0:
+		return nil, errors.New("empty shard list")
+	case p.Logger == nil:
+		return nil, errNilLogger
+	}
+
+	e := &StorageEngine{
+		log:     p.Logger,
+		shards:  p.Shards,
+		mShards: make(map[string]*Shard, len(p.Shards)),
+	}
+
+	for i := range p.Shards {
+		id := p.Shards[i].ID()
+		if _, ok := e.mShards[id]; ok {
+			return nil, errors.Errorf("duplicate shard ID %s", id)
+		}
+
+		e.mShards[id] = p.Shards[i]
+	}
+
+	return e, nil
+}
+
+// Shards returns the list of storage engine shards.
+func (e *StorageEngine) Shards() []*Shard {
+	return e.shards
+}
+
+// SetShardMode switches the shard with the ID to the mode.
+func (e *StorageEngine) SetShardMode(id string, m Mode) error {
+	s, ok := e.mShards[id]
+	if !ok {
+		return errors.Errorf("unknown shard %s", id)
+	} else if m > ModeDisabled {
+		return errUnknownMode
+	}
+
+	s.SetMode(m)
+
+	return nil
+}
+
+// sortedShards returns the shards sorted by HRW on the object address.
+func (e *StorageEngine) sortedShards(addr localstore.Address) ([]*Shard, error) {
+	k, err := addr.Hash()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not calculate address hash")
+	}
+
+	ids := make([]string, len(e.shards))
+	for i := range e.shards {
+		ids[i] = e.shards[i].ID()
+	}
+
+	hrw.SortSliceByValue(ids, hrw.Hash(k))
+
+	res := make([]*Shard, len(ids))
+	for i := range ids {
+		res[i] = e.mShards[ids[i]]
+	}
+
+	return res, nil
+}
+
+// Size returns the summary size of the available shards.
+func (e *StorageEngine) Size() (sz int64) {
+	for _, s := range e.shards {
+		if s.readable() {
+			sz += s.ls.Size()
+		}
+	}
+
+	return
+}
+
+// ObjectsCount returns the summary number of objects in the available shards.
+func (e *StorageEngine) ObjectsCount() (uint64, error) {
+	var res uint64
+
+	for _, s := range e.shards {
+		if !s.readable() {
+			continue
+		}
+
+		n, err := s.ls.ObjectsCount()
+		if err != nil {
+			s.readFailed(err)
+			continue
+		}
+
+		res += n
+	}
+
+	return res, nil
+}
+
+func isNotFound(err error) bool {
+	return errors.Is(errors.Cause(err), bucket.ErrNotFound)
+}
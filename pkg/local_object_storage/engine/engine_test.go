@@ -0,0 +1,315 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/nspcc-dev/neofs-api-go/container"
+	"github.com/nspcc-dev/neofs-api-go/object"
+	"github.com/nspcc-dev/neofs-api-go/refs"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket/test"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/localstore"
+	metrics2 "github.com/nspcc-dev/neofs-node/pkg/services/metrics"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type (
+	// brokenBucket simulates failed disk.
+	brokenBucket struct {
+		bucket.Bucket
+	}
+
+	// brokenIterator simulates failed disk reads on iteration.
+	brokenIterator struct {
+		bucket.Bucket
+	}
+)
+
+var errBrokenDisk = errors.New("broken disk")
+
+func (brokenBucket) Get([]byte) ([]byte, error)                     { return nil, errBrokenDisk }
+func (brokenBucket) Set([]byte, []byte) error                       { return errBrokenDisk }
+func (brokenBucket) Del([]byte) error                               { return errBrokenDisk }
+func (brokenBucket) Iterate(bucket.FilterHandler) error             { return errBrokenDisk }
+func (brokenBucket) List() ([][]byte, error)                        { return nil, errBrokenDisk }
+func (brokenBucket) GetRange(_ []byte, _, _ uint64) ([]byte, error) { return nil, errBrokenDisk }
+
+func (brokenIterator) Iterate(bucket.FilterHandler) error { return errBrokenDisk }
+
+func testObject(t *testing.T) *localstore.Object {
+	cnr, err := container.NewTestContainer()
+	require.NoError(t, err)
+
+	cid, err := cnr.ID()
+	require.NoError(t, err)
+
+	id, err := uuid.NewRandom()
+	require.NoError(t, err)
+
+	obj := &localstore.Object{
+		SystemHeader: object.SystemHeader{
+			Version: 1,
+			ID:      refs.UUID(id),
+			CID:     cid,
+		},
+	}
+	obj.SetPayload([]byte("Hello, world"))
+
+	return obj
+}
+
+func newShard(t *testing.T, id string, blob, meta bucket.Bucket) *Shard {
+	ls, err := localstore.New(localstore.Params{
+		BlobBucket: blob,
+		MetaBucket: meta,
+		Logger:     zap.L(),
+		Collector:  metrics2.NopCollector{},
+	})
+	require.NoError(t, err)
+
+	s, err := NewShard(ShardParams{
+		ID:              id,
+		Localstore:      ls,
+		Logger:          zap.L(),
+		WriteErrorLimit: 2,
+		ReadErrorLimit:  2,
+	})
+	require.NoError(t, err)
+
+	return s
+}
+
+func newEngine(t *testing.T, shards ...*Shard) *StorageEngine {
+	e, err := New(Params{
+		Shards: shards,
+		Logger: zap.L(),
+	})
+	require.NoError(t, err)
+
+	return e
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(Params{Logger: zap.L()})
+	require.Error(t, err)
+
+	s := newShard(t, "0", test.Bucket(), test.Bucket())
+
+	_, err = New(Params{
+		Shards: []*Shard{s, s},
+		Logger: zap.L(),
+	})
+	require.Error(t, err)
+}
+
+func TestStorageEngine(t *testing.T) {
+	const shardNum, objNum = 4, 20
+
+	shards := make([]*Shard, 0, shardNum)
+	for i := 0; i < shardNum; i++ {
+		shards = append(shards, newShard(t, fmt.Sprintf("shard%d", i), test.Bucket(), test.Bucket()))
+	}
+
+	e := newEngine(t, shards...)
+
+	objs := make([]*localstore.Object, 0, objNum)
+	for i := 0; i < objNum; i++ {
+		obj := testObject(t)
+		require.NoError(t, e.Put(context.Background(), obj))
+
+		objs = append(objs, obj)
+	}
+
+	cnt, err := e.ObjectsCount()
+	require.NoError(t, err)
+	require.EqualValues(t, objNum, cnt)
+
+	for _, obj := range objs {
+		addr := *obj.Address()
+
+		sorted, err := e.sortedShards(addr)
+		require.NoError(t, err)
+
+		// object is stored in the first shard in HRW order only
+		for i, s := range sorted {
+			ok, err := s.ls.Has(addr)
+			require.NoError(t, err)
+			require.Equal(t, i == 0, ok)
+		}
+
+		o, err := e.Get(addr)
+		require.NoError(t, err)
+		require.Equal(t, obj, o)
+
+		ok, err := e.Has(addr)
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		data, err := e.PRead(context.Background(), addr, object.Range{Offset: 7, Length: 5})
+		require.NoError(t, err)
+		require.Equal(t, []byte("world"), data)
+	}
+
+	items, err := localstore.ListItems(e, nil)
+	require.NoError(t, err)
+	require.Len(t, items, objNum)
+
+	for _, obj := range objs {
+		addr := *obj.Address()
+
+		require.NoError(t, e.Del(addr))
+
+		ok, err := e.Has(addr)
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		_, err = e.Get(addr)
+		require.True(t, isNotFound(err))
+	}
+}
+
+func TestStorageEngine_ShardModes(t *testing.T) {
+	var (
+		s1 = newShard(t, "shard1", test.Bucket(), test.Bucket())
+		s2 = newShard(t, "shard2", test.Bucket(), test.Bucket())
+		e  = newEngine(t, s1, s2)
+	)
+
+	require.EqualError(t, e.SetShardMode("unknown", ModeReadOnly), "unknown shard unknown")
+
+	require.NoError(t, e.SetShardMode(s1.ID(), ModeReadOnly))
+	require.Equal(t, ModeReadOnly, s1.Mode())
+
+	obj := testObject(t)
+	addr := *obj.Address()
+
+	require.NoError(t, e.Put(context.Background(), obj))
+
+	ok, err := s2.ls.Has(addr)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	require.NoError(t, e.SetShardMode(s2.ID(), ModeDisabled))
+
+	ok, err = e.Has(addr)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	err = e.Put(context.Background(), testObject(t))
+	require.EqualError(t, errors.Cause(err), ErrNoShards.Error())
+}
+
+func TestStorageEngine_BrokenShard(t *testing.T) {
+	var (
+		broken  = newShard(t, "broken", brokenBucket{test.Bucket()}, test.Bucket())
+		healthy = newShard(t, "healthy", test.Bucket(), test.Bucket())
+		e       = newEngine(t, broken, healthy)
+	)
+
+	// all writes succeed while broken shard is degraded after the write errors
+	for i := 0; i < 30; i++ {
+		obj := testObject(t)
+		require.NoError(t, e.Put(context.Background(), obj))
+
+		ok, err := healthy.ls.Has(*obj.Address())
+		require.NoError(t, err)
+		require.True(t, ok)
+	}
+
+	require.Equal(t, ModeDegraded, broken.Mode())
+	require.Equal(t, ModeReadWrite, healthy.Mode())
+	require.EqualValues(t, 2, broken.WriteErrors())
+
+	// broken shard is switched off after the read errors
+	for i := 0; i < 2; i++ {
+		_, err := e.Get(*testObject(t).Address())
+		require.EqualError(t, errors.Cause(err), errBrokenDisk.Error())
+	}
+
+	_, err := e.Get(*testObject(t).Address())
+	require.True(t, isNotFound(err))
+
+	require.Equal(t, ModeDisabled, broken.Mode())
+	require.Equal(t, ModeReadWrite, healthy.Mode())
+
+	broken.SetMode(ModeReadWrite)
+	require.Zero(t, broken.WriteErrors())
+	require.Zero(t, broken.ReadErrors())
+}
+
+func TestStorageEngine_RequestErrors(t *testing.T) {
+	var (
+		s = newShard(t, "shard", test.Bucket(), test.Bucket())
+		e = newEngine(t, s)
+	)
+
+	obj := testObject(t)
+	require.NoError(t, e.Put(context.Background(), obj))
+
+	// invalid ranges do not switch the shard off
+	for i := 0; i < 3; i++ {
+		_, err := e.PRead(context.Background(), *obj.Address(), object.Range{Offset: 7, Length: 50})
+		require.Error(t, err)
+	}
+
+	require.Zero(t, s.ReadErrors())
+	require.Equal(t, ModeReadWrite, s.Mode())
+}
+
+func TestStorageEngine_DelNotWritable(t *testing.T) {
+	var (
+		s1 = newShard(t, "shard1", test.Bucket(), test.Bucket())
+		s2 = newShard(t, "shard2", test.Bucket(), test.Bucket())
+		e  = newEngine(t, s1, s2)
+	)
+
+	obj := testObject(t)
+	addr := *obj.Address()
+
+	require.NoError(t, s1.ls.Put(context.Background(), obj))
+	require.NoError(t, s2.ls.Put(context.Background(), obj))
+
+	s1.SetMode(ModeReadOnly)
+
+	err := e.Del(addr)
+	require.True(t, errors.Is(errors.Cause(err), errNotWritableShard))
+
+	// object is removed from the writable shard anyway
+	ok, err := s2.ls.Has(addr)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	s1.SetMode(ModeReadWrite)
+	require.NoError(t, e.Del(addr))
+
+	ok, err = e.Has(addr)
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestStorageEngine_IterateBroken(t *testing.T) {
+	var (
+		broken  = newShard(t, "broken", test.Bucket(), brokenIterator{test.Bucket()})
+		healthy = newShard(t, "healthy", test.Bucket(), test.Bucket())
+		e       = newEngine(t, broken, healthy)
+	)
+
+	obj := testObject(t)
+	require.NoError(t, healthy.ls.Put(context.Background(), obj))
+
+	var res []localstore.Address
+
+	err := e.Iterate(nil, func(meta *localstore.ObjectMeta) bool {
+		res = append(res, *meta.Object.Address())
+		return false
+	})
+	require.EqualError(t, errors.Cause(err), errBrokenDisk.Error())
+
+	// healthy shard is visited regardless of the failure
+	require.Equal(t, []localstore.Address{*obj.Address()}, res)
+}
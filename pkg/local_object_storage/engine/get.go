@@ -0,0 +1,101 @@
+package engine
+
+import (
+	"context"
+
+	"github.com/nspcc-dev/neofs-api-go/object"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/localstore"
+	"github.com/pkg/errors"
+)
+
+// read calls f on the available shards in HRW order until it succeeds.
+//
+// Shard errors except bucket.ErrNotFound and the errors
+// of invalid request are counted as read errors.
+func (e *StorageEngine) read(addr localstore.Address, f func(*Shard) error) error {
+	shards, err := e.sortedShards(addr)
+	if err != nil {
+		return err
+	}
+
+	err = bucket.ErrNotFound
+
+	for _, s := range shards {
+		if !s.readable() {
+			continue
+		}
+
+		sErr := f(s)
+		if sErr == nil || isRequestError(sErr) {
+			return sErr
+		} else if !isNotFound(sErr) {
+			s.readFailed(sErr)
+			err = sErr
+		}
+	}
+
+	return err
+}
+
+// Get returns the object from the first shard that stores it.
+func (e *StorageEngine) Get(addr localstore.Address) (obj *localstore.Object, err error) {
+	err = e.read(addr, func(s *Shard) (err error) {
+		obj, err = s.ls.Get(addr)
+		return
+	})
+
+	return obj, errors.Wrap(err, "StorageEngine Get failed")
+}
+
+// Meta returns the object meta from the first shard that stores it.
+func (e *StorageEngine) Meta(addr localstore.Address) (meta *localstore.ObjectMeta, err error) {
+	err = e.read(addr, func(s *Shard) (err error) {
+		meta, err = s.ls.Meta(addr)
+		return
+	})
+
+	return meta, errors.Wrap(err, "StorageEngine Meta failed")
+}
+
+// PRead returns the payload range from the first shard that stores the object.
+func (e *StorageEngine) PRead(ctx context.Context, addr localstore.Address, rng object.Range) (data []byte, err error) {
+	err = e.read(addr, func(s *Shard) (err error) {
+		data, err = s.ls.PRead(ctx, addr, rng)
+		return
+	})
+
+	return data, errors.Wrap(err, "StorageEngine PRead failed")
+}
+
+// Has checks whether any of the available shards stores the object.
+func (e *StorageEngine) Has(addr localstore.Address) (bool, error) {
+	err := e.read(addr, func(s *Shard) error {
+		ok, err := s.ls.Has(addr)
+		if err == nil && !ok {
+			err = bucket.ErrNotFound
+		}
+
+		return err
+	})
+
+	switch {
+	case err == nil:
+		return true, nil
+	case isNotFound(err):
+		return false, nil
+	default:
+		return false, errors.Wrap(err, "StorageEngine Has failed")
+	}
+}
+
+// isRequestError checks if the error is caused by the invalid
+// request rather than the shard failure.
+func isRequestError(err error) bool {
+	switch errors.Cause(err) {
+	case localstore.ErrOutOfRange, bucket.ErrOutOfRange, localstore.ErrEmptyMetaHandler:
+		return true
+	default:
+		return false
+	}
+}
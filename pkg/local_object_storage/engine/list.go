@@ -0,0 +1,93 @@
+package engine
+
+import (
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/localstore"
+	"github.com/pkg/errors"
+)
+
+// Iterate iterates over the objects of all available shards.
+//
+// Shard failure does not stop the iteration over the other shards,
+// the error is returned after all of them are visited.
+func (e *StorageEngine) Iterate(filter localstore.FilterPipeline, handler localstore.MetaHandler) error {
+	if handler == nil {
+		return localstore.ErrEmptyMetaHandler
+	}
+
+	var (
+		stop    bool
+		lastErr error
+	)
+
+	for _, s := range e.shards {
+		if !s.readable() {
+			continue
+		}
+
+		err := s.ls.Iterate(filter, func(meta *localstore.ObjectMeta) bool {
+			stop = handler(meta)
+			return stop
+		})
+
+		if stop {
+			return nil
+		} else if err != nil && !errors.Is(errors.Cause(err), bucket.ErrIteratingAborted) {
+			s.readFailed(err)
+			lastErr = err
+		}
+	}
+
+	if lastErr != nil {
+		return errors.Wrap(lastErr, "StorageEngine Iterate failed")
+	}
+
+	return nil
+}
+
+// Search selects the objects through the indexes of all available shards.
+//
+// localstore.ErrIndexDisabled is returned if any of the shards
+// does not maintain the indexes. Other shard errors are returned
+// after all the shards are visited.
+func (e *StorageEngine) Search(fs []localstore.IndexFilter, handler localstore.MetaHandler) error {
+	if handler == nil {
+		return localstore.ErrEmptyMetaHandler
+	}
+
+	var (
+		stop    bool
+		lastErr error
+	)
+
+	for _, s := range e.shards {
+		if !s.readable() {
+			continue
+		}
+
+		sr, ok := s.ls.(localstore.Searcher)
+		if !ok {
+			return localstore.ErrIndexDisabled
+		}
+
+		err := sr.Search(fs, func(meta *localstore.ObjectMeta) bool {
+			stop = handler(meta)
+			return stop
+		})
+
+		if stop {
+			return nil
+		} else if errors.Is(errors.Cause(err), localstore.ErrIndexDisabled) {
+			return err
+		} else if err != nil {
+			s.readFailed(err)
+			lastErr = err
+		}
+	}
+
+	if lastErr != nil {
+		return errors.Wrap(lastErr, "StorageEngine Search failed")
+	}
+
+	return nil
+}
@@ -0,0 +1,34 @@
+package engine
+
+import (
+	"context"
+
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/localstore"
+	"github.com/pkg/errors"
+)
+
+// Put saves the object to the first writable shard in HRW order.
+//
+// If the write to the shard fails, the next one is tried.
+func (e *StorageEngine) Put(ctx context.Context, obj *localstore.Object) error {
+	shards, err := e.sortedShards(*obj.Address())
+	if err != nil {
+		return errors.Wrap(err, "StorageEngine Put failed")
+	}
+
+	err = ErrNoShards
+
+	for _, s := range shards {
+		if !s.writable() {
+			continue
+		}
+
+		if err = s.ls.Put(ctx, obj); err == nil {
+			return nil
+		}
+
+		s.writeFailed(err)
+	}
+
+	return errors.Wrap(err, "StorageEngine Put failed")
+}
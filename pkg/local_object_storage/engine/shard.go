@@ -0,0 +1,201 @@
+package engine
+
+import (
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/localstore"
+	"github.com/pkg/errors"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+)
+
+type (
+	// Mode is an enumeration of shard work modes.
+	Mode uint32
+
+	// Shard is a part of the storage engine with its own
+	// local object storage placed on a separate disk.
+	Shard struct {
+		id string
+
+		ls localstore.Localstore
+
+		log *zap.Logger
+
+		mode *atomic.Uint32
+
+		writeErrs, readErrs *atomic.Uint32
+
+		writeErrLimit, readErrLimit uint32
+	}
+
+	// ShardParams groups the parameters of Shard constructor.
+	ShardParams struct {
+		// ID is a unique identifier of the shard.
+		// It is used as a HRW sorting key, so it
+		// must not change between node restarts.
+		ID string
+
+		Localstore localstore.Localstore
+		Logger     *zap.Logger
+		Mode       Mode
+
+		// WriteErrorLimit is a number of write errors
+		// after which the shard is switched to ModeDegraded.
+		// Zero value disables switching.
+		WriteErrorLimit uint32
+
+		// ReadErrorLimit is a number of read errors
+		// after which the shard is switched to ModeDisabled.
+		// Zero value disables switching.
+		ReadErrorLimit uint32
+	}
+)
+
+const (
+	// ModeReadWrite is a Mode of the shard that serves reads and writes.
+	ModeReadWrite Mode = iota
+
+	// ModeReadOnly is a Mode of the shard that serves reads only.
+	ModeReadOnly
+
+	// ModeDegraded is a Mode of the shard that serves reads only
+	// after the writes to it have repeatedly failed.
+	ModeDegraded
+
+	// ModeDisabled is a Mode of the switched off shard.
+	ModeDisabled
+)
+
+var errUnknownMode = errors.New("unknown shard mode")
+
+func (m Mode) String() string {
+	switch m {
+	case ModeReadWrite:
+		return "READ_WRITE"
+	case ModeReadOnly:
+		return "READ_ONLY"
+	case ModeDegraded:
+		return "DEGRADED"
+	case ModeDisabled:
+		return "DISABLED"
+	default:
+		return "UNDEFINED"
+	}
+}
+
+// ModeFromString parses Mode from its config representation,
+// e.g. "read-write" or "degraded".
+func ModeFromString(s string) (Mode, error) {
+	switch s {
+	case "", "read-write":
+		return ModeReadWrite, nil
+	case "read-only":
+		return ModeReadOnly, nil
+	case "degraded":
+		return ModeDegraded, nil
+	case "disabled":
+		return ModeDisabled, nil
+	default:
+		return 0, errors.Wrapf(errUnknownMode, "%q", s)
+	}
+}
+
+// NewShard is a Shard constructor.
+func NewShard(p ShardParams) (*Shard, error) {
+	switch {
+	case p.ID == "":
+		return nil, errors.New("empty shard ID")
+	case p.Localstore == nil:
+		return nil, errors.New("shard localstore is nil")
+	case p.Logger == nil:
+		return nil, errNilLogger
+	case p.Mode > ModeDisabled:
+		return nil, errUnknownMode
+	}
+
+	return &Shard{
+		id:            p.ID,
+		ls:            p.Localstore,
+		log:           p.Logger.With(zap.String("shard", p.ID)),
+		mode:          atomic.NewUint32(uint32(p.Mode)),
+		writeErrs:     atomic.NewUint32(0),
+		readErrs:      atomic.NewUint32(0),
+		writeErrLimit: p.WriteErrorLimit,
+		readErrLimit:  p.ReadErrorLimit,
+	}, nil
+}
+
+// ID returns the identifier of the shard.
+func (s *Shard) ID() string {
+	return s.id
+}
+
+// Mode returns current work mode of the shard.
+func (s *Shard) Mode() Mode {
+	return Mode(s.mode.Load())
+}
+
+// SetMode switches the shard to the mode and resets its error counters.
+func (s *Shard) SetMode(m Mode) {
+	s.writeErrs.Store(0)
+	s.readErrs.Store(0)
+	s.mode.Store(uint32(m))
+
+	s.log.Info("shard mode changed",
+		zap.Stringer("mode", m))
+}
+
+// WriteErrors returns the number of failed writes
+// since the last mode change.
+func (s *Shard) WriteErrors() uint32 {
+	return s.writeErrs.Load()
+}
+
+// ReadErrors returns the number of failed reads
+// since the last mode change.
+func (s *Shard) ReadErrors() uint32 {
+	return s.readErrs.Load()
+}
+
+func (s *Shard) readable() bool {
+	return s.Mode() != ModeDisabled
+}
+
+func (s *Shard) writable() bool {
+	return s.Mode() == ModeReadWrite
+}
+
+func (s *Shard) writeFailed(err error) {
+	s.log.Warn("shard write failure",
+		zap.Error(err))
+
+	if n := s.writeErrs.Inc(); s.writeErrLimit > 0 && n == s.writeErrLimit {
+		s.switchMode(ModeReadWrite, ModeDegraded)
+	}
+}
+
+func (s *Shard) readFailed(err error) {
+	s.log.Warn("shard read failure",
+		zap.Error(err))
+
+	if n := s.readErrs.Inc(); s.readErrLimit > 0 && n == s.readErrLimit {
+		for _, m := range []Mode{ModeReadWrite, ModeReadOnly, ModeDegraded} {
+			if s.switchMode(m, ModeDisabled) {
+				break
+			}
+		}
+	}
+}
+
+// switchMode changes the mode of the shard if it has not been changed concurrently.
+func (s *Shard) switchMode(from, to Mode) bool {
+	if !s.mode.CAS(uint32(from), uint32(to)) {
+		return false
+	}
+
+	s.log.Error("shard mode changed due to errors",
+		zap.Stringer("mode", to),
+		zap.Uint32("write errors", s.writeErrs.Load()),
+		zap.Uint32("read errors", s.readErrs.Load()))
+
+	return true
+}
@@ -0,0 +1,331 @@
+package localstore
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"github.com/nspcc-dev/neofs-api-go/hash"
+	"github.com/nspcc-dev/neofs-api-go/refs"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket"
+	"github.com/pkg/errors"
+)
+
+// Backuper is an interface of local object storage
+// that copies the stored objects to the archive and back.
+type Backuper interface {
+	// Backup writes the objects stored since the epoch inclusively
+	// to w and returns the number of written objects and the backup
+	// epoch to make the next incremental backup since. Zero since
+	// epoch means full backup.
+	Backup(w io.Writer, since uint64) (int, uint64, error)
+
+	// Restore puts the objects from the archive written by Backup
+	// to the storage and returns the number of restored objects.
+	Restore(r io.Reader) (int, error)
+}
+
+// Backup archive is a tar stream that starts with the PAX global header with
+// the backup epoch record followed by two entries per object: <cid>/<oid>.meta
+// with ObjectMeta that keeps the object header and <cid>/<oid>.payload with
+// the object payload. Objects are archived decoded, so the archive does not
+// depend on the compression and encryption settings of the storage. Meta
+// entry carries the SHA-256 checksum of its content in the PAX record, payload
+// entry is checked against the payload size and hash of the meta.
+const (
+	backupMetaSuffix    = ".meta"
+	backupPayloadSuffix = ".payload"
+
+	backupChecksumRecord = "NEOFS.sha256"
+	backupEpochRecord    = "NEOFS.backup_epoch"
+
+	backupFileMode = 0600
+)
+
+var (
+	errBackupChecksum      = errors.New("archive entry checksum mismatch")
+	errBackupEntry         = errors.New("unexpected archive entry")
+	errBackupMissingObject = errors.New("archive entry of the object is missing")
+	errBackupPayload       = errors.New("object payload differs from meta")
+)
+
+// Backup writes the objects with store epoch not less than since to the archive.
+//
+// Backup epoch is the latest store epoch of the stored objects. Objects stored
+// later in the same epoch are written by the next backup since it, the ones
+// written twice are restored idempotently.
+//
+// Objects removed after the since epoch are not tracked, so incremental
+// backup restored over the full one does not remove them.
+func (l *localstore) Backup(w io.Writer, since uint64) (int, uint64, error) {
+	var (
+		addrs []refs.Address
+		epoch = since
+	)
+
+	if err := l.Iterate(nil, func(meta *ObjectMeta) bool {
+		if meta.StoreEpoch >= since {
+			addrs = append(addrs, *meta.Object.Address())
+		}
+
+		if meta.StoreEpoch > epoch {
+			epoch = meta.StoreEpoch
+		}
+
+		return false
+	}); err != nil {
+		return 0, 0, errors.Wrap(err, "Localstore Backup failed on Iterate")
+	}
+
+	tw := tar.NewWriter(w)
+
+	if err := tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeXGlobalHeader,
+		Format:   tar.FormatPAX,
+		PAXRecords: map[string]string{
+			backupEpochRecord: strconv.FormatUint(epoch, 10),
+		},
+	}); err != nil {
+		return 0, 0, errors.Wrap(err, "Localstore Backup failed on archive header")
+	}
+
+	for i := range addrs {
+		if err := l.backupObject(tw, addrs[i]); err != nil {
+			return i, 0, err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return len(addrs), 0, errors.Wrap(err, "Localstore Backup failed on archive close")
+	}
+
+	return len(addrs), epoch, nil
+}
+
+// ReadBackupEpoch returns the backup epoch of the archive written by Backup.
+func ReadBackupEpoch(r io.Reader) (uint64, error) {
+	hdr, err := tar.NewReader(r).Next()
+	if err != nil {
+		return 0, errors.Wrap(err, "could not read archive header")
+	} else if hdr.Typeflag != tar.TypeXGlobalHeader {
+		return 0, errors.Wrap(errBackupEntry, "missing backup epoch")
+	}
+
+	epoch, err := strconv.ParseUint(hdr.PAXRecords[backupEpochRecord], 10, 64)
+	if err != nil {
+		return 0, errors.Wrap(errBackupEntry, "invalid backup epoch")
+	}
+
+	return epoch, nil
+}
+
+// backupObject writes the archive entries of the object.
+// Payload is streamed from the storage, so it is not kept in memory.
+func (l *localstore) backupObject(tw *tar.Writer, addr refs.Address) error {
+	k, err := addr.Hash()
+	if err != nil {
+		return errors.Wrap(err, "Localstore Backup failed on key.Marshal")
+	}
+
+	meta, err := l.Meta(addr)
+	if err != nil {
+		return err
+	}
+
+	mv, err := meta.Marshal()
+	if err != nil {
+		return errors.Wrap(err, "Localstore Backup failed on ObjectMeta.Marshal")
+	}
+
+	payload, err := l.openPayload(k, meta)
+	if err != nil {
+		return errors.Wrap(err, "Localstore Backup failed on payload read")
+	}
+
+	defer payload.Close()
+
+	name := addr.String()
+
+	if err := writeBackupEntry(tw, name+backupMetaSuffix, mv); err != nil {
+		return err
+	}
+
+	return writeBackupPayload(tw, name+backupPayloadSuffix, int64(meta.PayloadSize), payload)
+}
+
+// openPayload returns the reader of the object payload.
+//
+// Payload of the split blob is read from the blob bucket stream if
+// the bucket allows it, the other blobs are decoded in memory.
+func (l *localstore) openPayload(k []byte, meta *ObjectMeta) (io.ReadCloser, error) {
+	if s, ok := l.blobBucket.(bucket.Streamer); ok && meta.PayloadOffset > 0 {
+		r, err := s.OpenReader(k)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := r.Seek(int64(meta.PayloadOffset), io.SeekStart); err != nil {
+			_ = r.Close()
+			return nil, err
+		}
+
+		return r, nil
+	}
+
+	v, err := l.blobBucket.Get(k)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := l.decodeBlob(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return ioutil.NopCloser(bytes.NewReader(obj.Payload)), nil
+}
+
+// Restore puts the archived objects to the storage with their store epochs.
+//
+// Entry checksums and payload hashes are verified before the object is put,
+// so Restore stops on the first broken entry. Objects restored before the
+// broken entry are kept in the storage.
+func (l *localstore) Restore(r io.Reader) (int, error) {
+	var (
+		cnt  int
+		tr   = tar.NewReader(r)
+		meta *ObjectMeta
+		name string
+	)
+
+	for {
+		hdr, v, err := readBackupEntry(tr)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return cnt, err
+		}
+
+		switch {
+		case hdr.Typeflag == tar.TypeXGlobalHeader:
+			// archive records do not affect the restored objects
+		case meta == nil && strings.HasSuffix(hdr.Name, backupMetaSuffix):
+			meta = new(ObjectMeta)
+			if err := meta.Unmarshal(v); err != nil {
+				return cnt, errors.Wrapf(err, "Localstore Restore failed on %s unmarshal", hdr.Name)
+			}
+
+			name = strings.TrimSuffix(hdr.Name, backupMetaSuffix)
+		case meta != nil && hdr.Name == name+backupPayloadSuffix:
+			if meta.Object == nil {
+				return cnt, errors.Wrap(errBackupMissingObject, name)
+			}
+
+			obj := *meta.Object
+			obj.Payload = v
+
+			if err := l.restoreObject(meta, &obj); err != nil {
+				return cnt, errors.Wrapf(err, "Localstore Restore failed on %s", name)
+			}
+
+			meta = nil
+			cnt++
+		default:
+			return cnt, errors.Wrap(errBackupEntry, hdr.Name)
+		}
+	}
+
+	if meta != nil {
+		return cnt, errors.Wrap(errBackupMissingObject, name)
+	}
+
+	return cnt, nil
+}
+
+func (l *localstore) restoreObject(meta *ObjectMeta, obj *Object) error {
+	if uint64(len(obj.Payload)) != meta.PayloadSize || hash.Sum(obj.Payload) != meta.PayloadHash {
+		return errBackupPayload
+	}
+
+	ctx := context.WithValue(context.Background(), StoreEpochValue, meta.StoreEpoch)
+
+	return l.Put(ctx, obj)
+}
+
+func writeBackupEntry(tw *tar.Writer, name string, v []byte) error {
+	sum := sha256.Sum256(v)
+
+	if err := tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     backupFileMode,
+		Size:     int64(len(v)),
+		Format:   tar.FormatPAX,
+		PAXRecords: map[string]string{
+			backupChecksumRecord: hex.EncodeToString(sum[:]),
+		},
+	}); err != nil {
+		return errors.Wrapf(err, "could not write %s header", name)
+	}
+
+	if _, err := tw.Write(v); err != nil {
+		return errors.Wrapf(err, "could not write %s", name)
+	}
+
+	return nil
+}
+
+func writeBackupPayload(tw *tar.Writer, name string, size int64, r io.Reader) error {
+	if err := tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     backupFileMode,
+		Size:     size,
+		Format:   tar.FormatPAX,
+	}); err != nil {
+		return errors.Wrapf(err, "could not write %s header", name)
+	}
+
+	if _, err := io.CopyN(tw, r, size); err != nil {
+		return errors.Wrapf(err, "could not write %s", name)
+	}
+
+	return nil
+}
+
+// readBackupEntry reads the archive entry and checks its checksum.
+// Payload entries are checked by Restore against the object meta.
+func readBackupEntry(tr *tar.Reader) (*tar.Header, []byte, error) {
+	hdr, err := tr.Next()
+	if err != nil {
+		if err != io.EOF {
+			err = errors.Wrap(err, "could not read archive entry header")
+		}
+
+		return nil, nil, err
+	}
+
+	v, err := ioutil.ReadAll(tr)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "could not read %s", hdr.Name)
+	}
+
+	if hdr.Typeflag == tar.TypeXGlobalHeader || strings.HasSuffix(hdr.Name, backupPayloadSuffix) {
+		return hdr, v, nil
+	}
+
+	sum := sha256.Sum256(v)
+
+	expected, err := hex.DecodeString(hdr.PAXRecords[backupChecksumRecord])
+	if err != nil || !bytes.Equal(expected, sum[:]) {
+		return nil, nil, errors.Wrap(errBackupChecksum, hdr.Name)
+	}
+
+	return hdr, v, nil
+}
@@ -0,0 +1,128 @@
+package localstore
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalstore_Backup(t *testing.T) {
+	ls := newLocalstore(t)
+
+	objs := make([]*Object, 4)
+
+	for i := range objs {
+		objs[i] = testObject(t)
+		objs[i].SetPayload([]byte{byte(i), 1, 2, 3})
+
+		// two objects per epoch starting from 1
+		ctx := context.WithValue(context.Background(), StoreEpochValue, uint64(i/2+1))
+
+		require.NoError(t, ls.Put(ctx, objs[i]))
+	}
+
+	full := new(bytes.Buffer)
+
+	cnt, epoch, err := ls.(Backuper).Backup(full, 0)
+	require.NoError(t, err)
+	require.Equal(t, len(objs), cnt)
+	require.EqualValues(t, 2, epoch)
+
+	archived, err := ReadBackupEpoch(bytes.NewReader(full.Bytes()))
+	require.NoError(t, err)
+	require.Equal(t, epoch, archived)
+
+	// object stored in the backup epoch after the backup
+	late := testObject(t)
+	require.NoError(t, ls.Put(context.WithValue(context.Background(), StoreEpochValue, epoch), late))
+
+	incremental := new(bytes.Buffer)
+
+	// since epoch is inclusive
+	cnt, epoch, err = ls.(Backuper).Backup(incremental, epoch)
+	require.NoError(t, err)
+	require.Equal(t, 3, cnt)
+	require.EqualValues(t, 2, epoch)
+
+	t.Run("restore", func(t *testing.T) {
+		restored := newLocalstore(t)
+
+		cnt, err := restored.(Backuper).Restore(incremental)
+		require.NoError(t, err)
+		require.Equal(t, 3, cnt)
+
+		_, err = restored.Get(*late.Address())
+		require.NoError(t, err)
+
+		_, err = restored.Get(*objs[0].Address())
+		require.Error(t, err)
+
+		cnt, err = restored.(Backuper).Restore(full)
+		require.NoError(t, err)
+		require.Equal(t, len(objs), cnt)
+
+		for i := range objs {
+			obj, err := restored.Get(*objs[i].Address())
+			require.NoError(t, err)
+			require.Equal(t, objs[i], obj)
+
+			meta, err := restored.Meta(*objs[i].Address())
+			require.NoError(t, err)
+			require.Equal(t, uint64(i/2+1), meta.StoreEpoch)
+		}
+	})
+
+	t.Run("broken checksum", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		tw := tar.NewWriter(buf)
+
+		require.NoError(t, tw.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     objs[0].Address().String() + backupMetaSuffix,
+			Size:     1,
+			Format:   tar.FormatPAX,
+			PAXRecords: map[string]string{
+				backupChecksumRecord: "00",
+			},
+		}))
+
+		_, err := tw.Write([]byte{1})
+		require.NoError(t, err)
+		require.NoError(t, tw.Close())
+
+		_, err = newLocalstore(t).(Backuper).Restore(buf)
+		require.True(t, errors.Is(errors.Cause(err), errBackupChecksum))
+	})
+
+	t.Run("broken payload", func(t *testing.T) {
+		meta, err := ls.Meta(*objs[0].Address())
+		require.NoError(t, err)
+
+		mv, err := meta.Marshal()
+		require.NoError(t, err)
+
+		// payload of the same size
+		broken := []byte("data")
+
+		buf := new(bytes.Buffer)
+		tw := tar.NewWriter(buf)
+
+		name := objs[0].Address().String()
+		require.NoError(t, writeBackupEntry(tw, name+backupMetaSuffix, mv))
+		require.NoError(t, writeBackupPayload(tw, name+backupPayloadSuffix, int64(len(broken)), bytes.NewReader(broken)))
+		require.NoError(t, tw.Close())
+
+		restored := newLocalstore(t)
+
+		_, err = restored.(Backuper).Restore(buf)
+		require.True(t, errors.Is(errors.Cause(err), errBackupPayload))
+
+		ok, err := restored.Has(*objs[0].Address())
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+}
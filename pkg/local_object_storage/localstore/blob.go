@@ -0,0 +1,219 @@
+package localstore
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+)
+
+// blobFormatSplit is a marker of the blob value that keeps
+// the object header and the payload separately:
+//
+//	0x00 | uvarint(header length) | header | payload
+//
+// Marshaled object can not start with zero byte, so the values of the
+// legacy format (the whole marshaled object) are distinguished by it.
+const blobFormatSplit byte = 0x00
+
+var errInvalidBlob = errors.New("invalid blob value")
+
+// marshalBlob returns the blob value of the object
+// and the offset of the payload in it.
+func marshalBlob(obj *Object) ([]byte, uint64, error) {
+	hdr := *obj
+	hdr.Payload = nil
+
+	h, err := hdr.Marshal()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	v := make([]byte, 1+binary.MaxVarintLen64, 1+binary.MaxVarintLen64+len(h)+len(obj.Payload))
+	v[0] = blobFormatSplit
+
+	n := binary.PutUvarint(v[1:], uint64(len(h)))
+	v = append(v[:1+n], h...)
+	off := len(v)
+	v = append(v, obj.Payload...)
+
+	return v, uint64(off), nil
+}
+
+// blobValue returns the blob value of the object to store
+// and the offset of the payload in it.
+//
+// Zero offset is returned for the compressed and encrypted values.
+func (l *localstore) blobValue(obj *Object) ([]byte, uint64, error) {
+	v, off, err := marshalBlob(obj)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if cv, ok := l.compressor.compressBlob(obj, v); ok {
+		v, off = cv, 0
+	}
+
+	if l.encryptor != nil {
+		if v, err = l.encryptor.encryptBlob(v, off); err != nil {
+			return nil, 0, err
+		}
+
+		off = 0
+	}
+
+	return v, off, nil
+}
+
+// splitBlob returns the header and the payload of the blob value.
+// Nil header is returned for the values of the legacy format.
+func splitBlob(v []byte) (hdr, payload []byte, err error) {
+	if len(v) == 0 || v[0] != blobFormatSplit {
+		return nil, nil, nil
+	}
+
+	ln, n := binary.Uvarint(v[1:])
+	if n <= 0 || ln > uint64(len(v)-1-n) {
+		return nil, nil, errInvalidBlob
+	}
+
+	off := 1 + n + int(ln)
+
+	return v[1+n : off], v[off:], nil
+}
+
+// unmarshalBlob restores the object from the blob value of the split,
+// compressed or legacy format.
+func unmarshalBlob(v []byte) (*Object, error) {
+	obj := new(Object)
+
+	v, err := decompressBlob(v)
+	if err != nil {
+		return nil, err
+	}
+
+	hdr, payload, err := splitBlob(v)
+	if err != nil {
+		return nil, err
+	} else if hdr == nil {
+		return obj, obj.Unmarshal(v)
+	}
+
+	if err := obj.Unmarshal(hdr); err != nil {
+		return nil, err
+	}
+
+	if len(payload) > 0 {
+		obj.Payload = payload
+	}
+
+	return obj, nil
+}
+
+// decodeBlob restores the object from the blob value
+// of any format including the encrypted one.
+func (l *localstore) decodeBlob(v []byte) (*Object, error) {
+	v, err := l.encryptor.decryptBlob(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalBlob(v)
+}
+
+// blobPayloadOffset returns the offset of the payload in the blob value.
+// Zero offset is returned for the values of the legacy format,
+// the compressed and the encrypted ones.
+func blobPayloadOffset(v []byte) (uint64, error) {
+	hdr, payload, err := splitBlob(v)
+	if err != nil || hdr == nil {
+		return 0, err
+	}
+
+	return uint64(len(v) - len(payload)), nil
+}
+
+// migrateBlob converts the blob of the legacy format to the split one
+// and saves the payload offset in the ObjectMeta. Converted blob is
+// compressed and encrypted if it is enabled.
+//
+// It is the migration to the format version 2.
+func (l *localstore) migrateBlob(k []byte, dryRun bool) (bool, error) {
+	mv, err := l.metaBucket.Get(k)
+	if err != nil {
+		if isNotFound(err) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	meta := new(ObjectMeta)
+	if err := meta.Unmarshal(mv); err != nil {
+		return false, err
+	} else if meta.PayloadOffset > 0 {
+		return false, nil
+	}
+
+	v, err := l.blobBucket.Get(k)
+	if err != nil {
+		if isNotFound(err) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	if isCompressedBlob(v) || isEncryptedBlob(v) {
+		return false, nil
+	} else if dryRun {
+		return true, nil
+	}
+
+	if meta.PayloadOffset, err = blobPayloadOffset(v); err != nil {
+		return false, err
+	} else if meta.PayloadOffset == 0 {
+		obj, err := l.decodeBlob(v)
+		if err != nil {
+			return false, err
+		}
+
+		if v, meta.PayloadOffset, err = l.blobValue(obj); err != nil {
+			return false, err
+		}
+	}
+
+	if mv, err = meta.Marshal(); err != nil {
+		return false, err
+	}
+
+	rec := journalRecord{
+		op:         journalPut,
+		storeEpoch: meta.StoreEpoch,
+		checksum:   sha256.Sum256(v),
+	}
+
+	if err := l.beginWrite(k, rec); err != nil {
+		return false, err
+	}
+
+	if err := l.blobBucket.Set(k, v); err != nil {
+		return l.abortMigration(k, rec, err)
+	}
+
+	if err := l.metaBucket.Set(k, mv); err != nil {
+		return l.abortMigration(k, rec, err)
+	}
+
+	return true, l.commitWrite(k)
+}
+
+// abortMigration finishes the failed blob migration. The migration rolled
+// forward keeps the old ObjectMeta which is valid for the converted blob.
+func (l *localstore) abortMigration(k []byte, rec journalRecord, err error) (bool, error) {
+	if l.abortWrite(k, rec) {
+		return true, nil
+	}
+
+	return false, err
+}
@@ -0,0 +1,260 @@
+package localstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"math"
+	"testing"
+
+	"github.com/nspcc-dev/neofs-api-go/object"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket/test"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestBlobFormat(t *testing.T) {
+	obj := testObject(t)
+	obj.SetPayload([]byte("Hello, world"))
+
+	v, off, err := marshalBlob(obj)
+	require.NoError(t, err)
+	require.Equal(t, blobFormatSplit, v[0])
+	require.Equal(t, obj.Payload, v[off:])
+
+	o, err := unmarshalBlob(v)
+	require.NoError(t, err)
+	require.Equal(t, obj, o)
+
+	o2, err := blobPayloadOffset(v)
+	require.NoError(t, err)
+	require.Equal(t, off, o2)
+
+	t.Run("legacy format", func(t *testing.T) {
+		v, err := obj.Marshal()
+		require.NoError(t, err)
+
+		o, err := unmarshalBlob(v)
+		require.NoError(t, err)
+		require.Equal(t, obj, o)
+
+		off, err := blobPayloadOffset(v)
+		require.NoError(t, err)
+		require.Zero(t, off)
+	})
+
+	t.Run("broken value", func(t *testing.T) {
+		_, err := unmarshalBlob(v[:off-1])
+		require.EqualError(t, err, errInvalidBlob.Error())
+	})
+}
+
+func TestLocalstore_PRead(t *testing.T) {
+	ls := newLocalstore(t)
+
+	obj := testObject(t)
+	obj.SetPayload([]byte("Hello, world"))
+
+	require.NoError(t, ls.Put(context.Background(), obj))
+
+	data, err := ls.PRead(context.Background(), *obj.Address(), object.Range{
+		Offset: 7,
+		Length: 5,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []byte("world"), data)
+
+	_, err = ls.PRead(context.Background(), *obj.Address(), object.Range{
+		Offset: 7,
+		Length: 6,
+	})
+	require.EqualError(t, err, ErrOutOfRange.Error())
+
+	// offset + length overflows uint64
+	_, err = ls.PRead(context.Background(), *obj.Address(), object.Range{
+		Offset: 7,
+		Length: math.MaxUint64,
+	})
+	require.EqualError(t, err, ErrOutOfRange.Error())
+}
+
+func TestLocalstore_MigrateBlobs(t *testing.T) {
+	var (
+		blob = test.Bucket()
+		meta = test.Bucket()
+		p    = Params{
+			BlobBucket:     blob,
+			MetaBucket:     meta,
+			Logger:         zap.L(),
+			Collector:      newCollector(),
+			SkipMigrations: true,
+		}
+	)
+
+	obj := testObject(t)
+	obj.SetPayload([]byte("Hello, world"))
+
+	addr := *obj.Address()
+
+	k, err := addr.Hash()
+	require.NoError(t, err)
+
+	// store object in the legacy format of the version 1 storage
+	v, err := obj.Marshal()
+	require.NoError(t, err)
+	require.NoError(t, blob.Set(k, v))
+
+	v, err = metaFromObject(context.Background(), obj).Marshal()
+	require.NoError(t, err)
+	require.NoError(t, meta.Set(k, v))
+
+	ls, err := New(p)
+	require.NoError(t, err)
+
+	m, err := ls.Meta(addr)
+	require.NoError(t, err)
+	require.Zero(t, m.PayloadOffset)
+
+	data, err := ls.PRead(context.Background(), addr, object.Range{Length: 5})
+	require.NoError(t, err)
+	require.Equal(t, []byte("Hello"), data)
+
+	ver, err := ls.(Migrator).FormatVersion()
+	require.NoError(t, err)
+	require.Equal(t, uint64(baseFormatVersion), ver)
+
+	reports, err := ls.(Migrator).Migrate(true)
+	require.NoError(t, err)
+	require.Equal(t, []MigrationReport{{
+		Version:   2,
+		Name:      "split blobs",
+		Processed: 1,
+		Changed:   1,
+	}}, reports)
+
+	// storage is upgraded on start
+	p.SkipMigrations = false
+
+	ls, err = New(p)
+	require.NoError(t, err)
+
+	ver, err = ls.(Migrator).FormatVersion()
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), ver)
+
+	m, err = ls.Meta(addr)
+	require.NoError(t, err)
+	require.NotZero(t, m.PayloadOffset)
+
+	v, err = blob.Get(k)
+	require.NoError(t, err)
+	require.Equal(t, blobFormatSplit, v[0])
+
+	o, err := ls.Get(addr)
+	require.NoError(t, err)
+	require.Equal(t, obj, o)
+
+	data, err = ls.PRead(context.Background(), addr, object.Range{Length: 5})
+	require.NoError(t, err)
+	require.Equal(t, []byte("Hello"), data)
+}
+
+func TestLocalstore_Compression(t *testing.T) {
+	var (
+		blob = test.Bucket()
+		p    = Params{
+			BlobBucket: blob,
+			MetaBucket: test.Bucket(),
+			Logger:     zap.L(),
+			Collector:  newCollector(),
+			Compression: CompressionParams{
+				Enabled:              true,
+				ExcludedContentTypes: []string{"application/json", "text/*"},
+			},
+		}
+	)
+
+	ls, err := New(p)
+	require.NoError(t, err)
+
+	put := func(t *testing.T, payload []byte, contentType string) (*Object, []byte) {
+		obj := testObject(t)
+		obj.SetPayload(payload)
+
+		if contentType != "" {
+			obj.AddHeader(&object.Header{Value: &object.Header_UserHeader{
+				UserHeader: &object.UserHeader{Key: "content-type", Value: contentType},
+			}})
+		}
+
+		require.NoError(t, ls.Put(context.Background(), obj))
+
+		k, err := obj.Address().Hash()
+		require.NoError(t, err)
+
+		v, err := blob.Get(k)
+		require.NoError(t, err)
+
+		o, err := ls.Get(*obj.Address())
+		require.NoError(t, err)
+		require.Equal(t, obj, o)
+
+		data, err := ls.PRead(context.Background(), *obj.Address(), object.Range{Offset: 3, Length: 10})
+		require.NoError(t, err)
+		require.Equal(t, payload[3:13], data)
+
+		return obj, v
+	}
+
+	compressible := bytes.Repeat([]byte(`{"key":"value"}`), 100)
+
+	t.Run("compressible", func(t *testing.T) {
+		obj, v := put(t, compressible, "")
+		require.Equal(t, blobFormatCompressed, v[0])
+		require.True(t, len(v) < len(obj.Payload))
+
+		m, err := ls.Meta(*obj.Address())
+		require.NoError(t, err)
+		require.Zero(t, m.PayloadOffset)
+	})
+
+	t.Run("incompressible", func(t *testing.T) {
+		payload := make([]byte, 1500)
+		_, err := rand.Read(payload)
+		require.NoError(t, err)
+
+		_, v := put(t, payload, "")
+		require.Equal(t, blobFormatSplit, v[0])
+	})
+
+	t.Run("excluded content type", func(t *testing.T) {
+		_, v := put(t, compressible, "application/json")
+		require.Equal(t, blobFormatSplit, v[0])
+
+		_, v = put(t, compressible, "text/plain; charset=utf-8")
+		require.Equal(t, blobFormatSplit, v[0])
+
+		_, v = put(t, compressible, "application/xml")
+		require.Equal(t, blobFormatCompressed, v[0])
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		obj, _ := put(t, compressible, "")
+
+		p.Compression.Enabled = false
+
+		ls, err := New(p)
+		require.NoError(t, err)
+
+		// compressed objects are still readable
+		o, err := ls.Get(*obj.Address())
+		require.NoError(t, err)
+		require.Equal(t, obj, o)
+	})
+
+	t.Run("broken value", func(t *testing.T) {
+		_, err := unmarshalBlob([]byte{blobFormatCompressed, 1, 2, 3})
+		require.True(t, errors.Is(errors.Cause(err), errInvalidCompressedBlob))
+	})
+}
@@ -0,0 +1,147 @@
+package localstore
+
+import (
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/nspcc-dev/neofs-api-go/object"
+	"github.com/pkg/errors"
+)
+
+type (
+	// CompressionParams groups the parameters of object compression.
+	CompressionParams struct {
+		// Enabled turns on zstd compression of the stored objects.
+		// Compressed objects are read regardless of it.
+		Enabled bool
+
+		// ExcludedContentTypes is a list of Content-Type user header values
+		// of the objects that are stored uncompressed, e.g. "video/mp4".
+		// Values with "/*" suffix match the whole type, e.g. "image/*".
+		ExcludedContentTypes []string
+	}
+
+	compressor struct {
+		enc *zstd.Encoder
+
+		excluded []string
+	}
+)
+
+// blobFormatCompressed is a marker of the blob value that keeps
+// the compressed value of the split format:
+//
+//	0x01 | zstd(0x00 | uvarint(header length) | header | payload)
+//
+// Payload of the compressed blob can not be read directly,
+// so payload offset is not saved for it.
+const blobFormatCompressed byte = 0x01
+
+const (
+	contentTypeHeader = "Content-Type"
+
+	// payload that is shorter is not worth compressing
+	minCompressSize = 64
+
+	// size of the payload prefix that is compressed
+	// to check whether the payload is compressible
+	probeSize = 4 << 10
+)
+
+var errInvalidCompressedBlob = errors.New("invalid compressed blob value")
+
+// blobDecoder decompresses blob values, it is safe for concurrent use.
+var blobDecoder *zstd.Decoder
+
+func init() {
+	var err error
+
+	if blobDecoder, err = zstd.NewReader(nil); err != nil {
+		panic(err)
+	}
+}
+
+func newCompressor(p CompressionParams) (*compressor, error) {
+	if !p.Enabled {
+		return nil, nil
+	}
+
+	enc, err := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedDefault))
+	if err != nil {
+		return nil, err
+	}
+
+	return &compressor{
+		enc:      enc,
+		excluded: p.ExcludedContentTypes,
+	}, nil
+}
+
+// compressBlob returns the compressed blob value of the object.
+// False is returned if the object should be stored uncompressed.
+func (c *compressor) compressBlob(obj *Object, v []byte) ([]byte, bool) {
+	if c == nil || len(obj.Payload) < minCompressSize || c.isExcluded(obj) {
+		return nil, false
+	}
+
+	// do not waste time on the payload that is already compressed
+	probe := obj.Payload
+	if len(probe) > probeSize {
+		probe = probe[:probeSize]
+	}
+
+	if len(c.enc.EncodeAll(probe, nil)) > len(probe)*9/10 {
+		return nil, false
+	}
+
+	cv := c.enc.EncodeAll(v, []byte{blobFormatCompressed})
+	if len(cv) >= len(v) {
+		return nil, false
+	}
+
+	return cv, true
+}
+
+func (c *compressor) isExcluded(obj *Object) bool {
+	if len(c.excluded) == 0 {
+		return false
+	}
+
+	for i := range obj.Headers {
+		h, ok := obj.Headers[i].Value.(*object.Header_UserHeader)
+		if !ok || h.UserHeader == nil || !strings.EqualFold(h.UserHeader.Key, contentTypeHeader) {
+			continue
+		}
+
+		// drop parameters, e.g. "; charset=utf-8"
+		ct := strings.TrimSpace(strings.SplitN(h.UserHeader.Value, ";", 2)[0])
+
+		for _, ex := range c.excluded {
+			if strings.EqualFold(ct, ex) ||
+				strings.HasSuffix(ex, "/*") && len(ct) > len(ex)-1 && strings.EqualFold(ct[:len(ex)-1], ex[:len(ex)-1]) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func isCompressedBlob(v []byte) bool {
+	return len(v) > 0 && v[0] == blobFormatCompressed
+}
+
+// decompressBlob returns the uncompressed blob value.
+// Values that are not compressed are returned as is.
+func decompressBlob(v []byte) ([]byte, error) {
+	if !isCompressedBlob(v) {
+		return v, nil
+	}
+
+	res, err := blobDecoder.DecodeAll(v[1:], nil)
+	if err != nil {
+		return nil, errors.Wrap(errInvalidCompressedBlob, err.Error())
+	}
+
+	return res, nil
+}
@@ -19,14 +19,37 @@ func (l *localstore) Del(key refs.Address) error {
 		l.log.Warn("localstore Del failed on localstore.Get", zap.Error(err))
 	}
 
-	if err := l.blobBucket.Del(k); err != nil {
-		l.log.Warn("Localstore Del failed on BlobBucket.Del", zap.Error(err))
+	rec := journalRecord{op: journalDel}
+
+	if err := l.beginWrite(k, rec); err != nil {
+		return errors.Wrap(err, "Localstore Del failed on journal write")
 	}
 
-	if err := l.metaBucket.Del(k); err != nil {
+	if err := l.dropIndex(k); err != nil {
+		// the write rolled forward updates the metrics itself
+		if l.abortWrite(k, rec) {
+			return nil
+		}
+
+		return errors.Wrap(err, "Localstore Del failed on index update")
+	}
+
+	if err := l.blobBucket.Del(k); err != nil && !isNotFound(err) {
+		if l.abortWrite(k, rec) {
+			return nil
+		}
+
+		return errors.Wrap(err, "Localstore Del failed on BlobBucket.Del")
+	}
+
+	if err := l.metaBucket.Del(k); err != nil && !l.abortWrite(k, rec) {
 		return errors.Wrap(err, "Localstore Del failed on MetaBucket.Del")
 	}
 
+	if err := l.commitWrite(k); err != nil {
+		l.log.Warn("Localstore Del failed on journal commit", zap.Error(err))
+	}
+
 	if obj != nil {
 		l.col.UpdateContainer(
 			key.CID,
@@ -0,0 +1,464 @@
+package localstore
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"io/ioutil"
+	"strings"
+
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+type (
+	// EncryptionParams groups the parameters of object encryption.
+	EncryptionParams struct {
+		// MasterKey is a 32-byte key that wraps the data keys
+		// of the blob encryption. Blobs are stored in plaintext
+		// and encrypted blobs can not be read if it is empty.
+		MasterKey []byte
+
+		// PreviousMasterKeys are the master keys replaced by MasterKey.
+		// Data keys wrapped by them are re-wrapped by MasterKey on start,
+		// stored blobs are not rewritten.
+		PreviousMasterKeys [][]byte
+	}
+
+	encryptor struct {
+		keys   map[uint32][]byte
+		active uint32
+	}
+
+	encHeader struct {
+		keyID     uint32
+		salt      []byte
+		nonce     []byte
+		chunkSize uint64
+		size      uint64
+		off       uint64
+
+		// raw header authenticated with every chunk
+		raw []byte
+	}
+)
+
+// blobFormatEncrypted is a marker of the blob value that keeps
+// the value of the split or compressed format encrypted by AES-GCM in chunks:
+//
+//	0x02 | key ID | salt | nonce | chunk size | value size | payload offset | chunks
+//
+// Header fields are big-endian: 4-byte data key ID, 16-byte salt, 12-byte
+// nonce, 4-byte chunk size, 8-byte value size and 8-byte offset of the payload
+// in the value. Blob key is derived from the data key and the random salt by
+// HKDF-SHA256, so every blob is encrypted by its own key. Chunk nonce is the
+// random nonce XOR-ed with the chunk index, header is the additional data of
+// every chunk, so chunks can be neither reordered nor cut off. Zero payload
+// offset means that the payload can not be read directly, e.g. from the
+// compressed value.
+const blobFormatEncrypted byte = 0x02
+
+const (
+	encSaltSize  = 16
+	encNonceSize = 12
+
+	encHeaderSize = 1 + 4 + encSaltSize + encNonceSize + 4 + 8 + 8
+
+	// payload ranges are read and decrypted by chunks of this size
+	encChunkSize = 64 << 10
+
+	masterKeySize = 32
+	dataKeySize   = 32
+
+	// size of the master key ID that precedes the wrapped data key
+	masterIDSize = 8
+)
+
+var (
+	// ErrEncryptionDisabled is returned on reading the encrypted
+	// blob by the localstore without the master key.
+	ErrEncryptionDisabled = errors.New("localstore encryption is disabled")
+
+	errNilKeyBucket           = errors.New("key bucket is nil")
+	errInvalidMasterKey       = errors.New("master key must be 32 bytes long")
+	errInvalidEncryptedBlob   = errors.New("invalid encrypted blob value")
+	errInvalidWrappedKey      = errors.New("invalid wrapped data key")
+	errUnknownMasterKey       = errors.New("data key is wrapped by unknown master key")
+	errUnknownDataKey         = errors.New("blob is encrypted by unknown data key")
+	errNoEncryptedPayloadRead = errors.New("payload of the blob can not be read by chunks")
+)
+
+// LoadMasterKey decodes the hex-encoded master key of the blob
+// encryption. Value can be the path to the file with the key.
+func LoadMasterKey(s string) ([]byte, error) {
+	if data, err := ioutil.ReadFile(s); err == nil {
+		s = string(data)
+	}
+
+	key, err := hex.DecodeString(strings.TrimSpace(s))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode master key")
+	} else if len(key) != masterKeySize {
+		return nil, errInvalidMasterKey
+	}
+
+	return key, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	c, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(c)
+}
+
+func masterKeyID(key []byte) []byte {
+	h := sha256.Sum256(key)
+	return h[:masterIDSize]
+}
+
+// newEncryptor loads the data keys from the key bucket. Data keys wrapped
+// by the previous master keys are re-wrapped, the first data key is
+// generated if there are no keys yet.
+func newEncryptor(p EncryptionParams, keyBucket bucket.Bucket, log *zap.Logger) (*encryptor, error) {
+	if len(p.MasterKey) == 0 {
+		return nil, nil
+	} else if keyBucket == nil {
+		return nil, errNilKeyBucket
+	}
+
+	masters := make(map[string]cipher.AEAD, len(p.PreviousMasterKeys)+1)
+
+	for _, key := range append([][]byte{p.MasterKey}, p.PreviousMasterKeys...) {
+		if len(key) != masterKeySize {
+			return nil, errInvalidMasterKey
+		}
+
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, err
+		}
+
+		masters[string(masterKeyID(key))] = aead
+	}
+
+	var (
+		curID  = masterKeyID(p.MasterKey)
+		cur    = masters[string(curID)]
+		e      = &encryptor{keys: make(map[uint32][]byte)}
+		rewrap = make(map[uint32][]byte)
+		ierr   error
+	)
+
+	if err := keyBucket.Iterate(func(k, v []byte) bool {
+		if len(k) != 4 || len(v) < masterIDSize+cur.NonceSize() {
+			ierr = errInvalidWrappedKey
+			return false
+		}
+
+		id := binary.BigEndian.Uint32(k)
+		if id > e.active {
+			e.active = id
+		}
+
+		master, ok := masters[string(v[:masterIDSize])]
+		if !ok {
+			ierr = errors.Wrapf(errUnknownMasterKey, "data key %d", id)
+			return false
+		}
+
+		nonce := v[masterIDSize : masterIDSize+master.NonceSize()]
+
+		dk, err := master.Open(nil, nonce, v[masterIDSize+len(nonce):], k)
+		if err != nil || len(dk) != dataKeySize {
+			ierr = errors.Wrapf(errInvalidWrappedKey, "data key %d", id)
+			return false
+		}
+
+		if !bytes.Equal(v[:masterIDSize], curID) {
+			rewrap[id] = dk
+		}
+
+		e.keys[id] = dk
+
+		return true
+	}); err != nil {
+		if ierr != nil {
+			return nil, ierr
+		}
+
+		return nil, errors.Wrap(err, "could not load data keys")
+	}
+
+	if len(e.keys) == 0 {
+		dk := make([]byte, dataKeySize)
+		if _, err := rand.Read(dk); err != nil {
+			return nil, errors.Wrap(err, "could not generate data key")
+		}
+
+		e.active = 1
+		e.keys[e.active] = dk
+		rewrap[e.active] = dk
+	}
+
+	for id, dk := range rewrap {
+		k := make([]byte, 4)
+		binary.BigEndian.PutUint32(k, id)
+
+		v, err := wrapDataKey(cur, curID, k, dk)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := keyBucket.Set(k, v); err != nil {
+			return nil, errors.Wrap(err, "could not save data key")
+		}
+	}
+
+	if len(rewrap) > 0 {
+		log.Info("localstore data keys wrapped by master key",
+			zap.Int("count", len(rewrap)))
+	}
+
+	return e, nil
+}
+
+// wrapDataKey returns the data key sealed by the master key:
+//
+//	master key ID | nonce | sealed data key
+func wrapDataKey(master cipher.AEAD, masterID, id, dk []byte) ([]byte, error) {
+	v := make([]byte, masterIDSize+master.NonceSize(), masterIDSize+master.NonceSize()+len(dk)+master.Overhead())
+	copy(v, masterID)
+
+	if _, err := rand.Read(v[masterIDSize:]); err != nil {
+		return nil, errors.Wrap(err, "could not generate nonce")
+	}
+
+	return master.Seal(v, v[masterIDSize:], dk, id), nil
+}
+
+func isEncryptedBlob(v []byte) bool {
+	return len(v) > 0 && v[0] == blobFormatEncrypted
+}
+
+func parseEncHeader(v []byte) (*encHeader, error) {
+	if len(v) < encHeaderSize || v[0] != blobFormatEncrypted {
+		return nil, errInvalidEncryptedBlob
+	}
+
+	h := &encHeader{
+		keyID:     binary.BigEndian.Uint32(v[1:]),
+		salt:      v[5 : 5+encSaltSize],
+		nonce:     v[5+encSaltSize : 5+encSaltSize+encNonceSize],
+		chunkSize: uint64(binary.BigEndian.Uint32(v[33:])),
+		size:      binary.BigEndian.Uint64(v[37:]),
+		off:       binary.BigEndian.Uint64(v[45:]),
+		raw:       v[:encHeaderSize],
+	}
+
+	if h.chunkSize == 0 || h.off > h.size {
+		return nil, errInvalidEncryptedBlob
+	}
+
+	return h, nil
+}
+
+// chunkNonce returns the nonce of the i-th chunk.
+func (h *encHeader) chunkNonce(i uint64) []byte {
+	nonce := make([]byte, encNonceSize)
+	binary.BigEndian.PutUint64(nonce[encNonceSize-8:], i)
+
+	for j := range nonce {
+		nonce[j] ^= h.nonce[j]
+	}
+
+	return nonce
+}
+
+// chunkLen returns the length of the i-th plaintext chunk.
+func (h *encHeader) chunkLen(i uint64) uint64 {
+	if rest := h.size - i*h.chunkSize; rest < h.chunkSize {
+		return rest
+	}
+
+	return h.chunkSize
+}
+
+// deriveBlobKey returns the key of the blob encryption derived from
+// the data key and the salt by HKDF-SHA256 (RFC 5869). Blob key fits
+// the single block of the hash, so the expansion is a single HMAC.
+func deriveBlobKey(dk, salt []byte) []byte {
+	extract := hmac.New(sha256.New, salt)
+	extract.Write(dk)
+
+	expand := hmac.New(sha256.New, extract.Sum(nil))
+	expand.Write([]byte("neofs localstore blob key"))
+	expand.Write([]byte{1})
+
+	return expand.Sum(nil)[:dataKeySize]
+}
+
+// blobAEAD returns the cipher of the blob with the header.
+func (e *encryptor) blobAEAD(h *encHeader) (cipher.AEAD, error) {
+	dk, ok := e.keys[h.keyID]
+	if !ok {
+		return nil, errUnknownDataKey
+	}
+
+	return newAEAD(deriveBlobKey(dk, h.salt))
+}
+
+// encryptBlob returns the encrypted blob value, off is the offset
+// of the payload in the value or zero.
+func (e *encryptor) encryptBlob(v []byte, off uint64) ([]byte, error) {
+	hdr := make([]byte, encHeaderSize)
+	hdr[0] = blobFormatEncrypted
+	binary.BigEndian.PutUint32(hdr[1:], e.active)
+
+	if _, err := rand.Read(hdr[5 : 5+encSaltSize+encNonceSize]); err != nil {
+		return nil, errors.Wrap(err, "could not generate salt and nonce")
+	}
+
+	binary.BigEndian.PutUint32(hdr[33:], encChunkSize)
+	binary.BigEndian.PutUint64(hdr[37:], uint64(len(v)))
+	binary.BigEndian.PutUint64(hdr[45:], off)
+
+	h, err := parseEncHeader(hdr)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := e.blobAEAD(h)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]byte, 0, encHeaderSize+len(v)+(len(v)/encChunkSize+1)*aead.Overhead())
+	res = append(res, hdr...)
+
+	for i := uint64(0); i*encChunkSize < uint64(len(v)); i++ {
+		chunk := v[i*encChunkSize : i*encChunkSize+h.chunkLen(i)]
+		res = aead.Seal(res, h.chunkNonce(i), chunk, h.raw)
+	}
+
+	return res, nil
+}
+
+// decryptBlob returns the decrypted blob value.
+// Values that are not encrypted are returned as is.
+func (e *encryptor) decryptBlob(v []byte) ([]byte, error) {
+	if !isEncryptedBlob(v) {
+		return v, nil
+	} else if e == nil {
+		return nil, ErrEncryptionDisabled
+	}
+
+	h, err := parseEncHeader(v)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := e.openChunks(h, v[encHeaderSize:], 0)
+	if err != nil {
+		return nil, err
+	} else if uint64(len(res)) != h.size {
+		return nil, errInvalidEncryptedBlob
+	}
+
+	return res, nil
+}
+
+// openChunks decrypts the sequence of the chunks starting from the first one.
+func (e *encryptor) openChunks(h *encHeader, data []byte, first uint64) ([]byte, error) {
+	aead, err := e.blobAEAD(h)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []byte
+
+	for i := first; len(data) > 0; i++ {
+		if i*h.chunkSize >= h.size {
+			return nil, errInvalidEncryptedBlob
+		}
+
+		n := h.chunkLen(i) + uint64(aead.Overhead())
+		if uint64(len(data)) < n {
+			return nil, errInvalidEncryptedBlob
+		}
+
+		if res, err = aead.Open(res, h.chunkNonce(i), data[:n], h.raw); err != nil {
+			return nil, errors.Wrap(errInvalidEncryptedBlob, err.Error())
+		}
+
+		data = data[n:]
+	}
+
+	return res, nil
+}
+
+// readPayloadRange reads and decrypts the chunks of the encrypted blob
+// that contain the requested payload range.
+//
+// errNoEncryptedPayloadRead is returned if the blob is not encrypted
+// or its payload can not be read directly.
+func (e *encryptor) readPayloadRange(rr bucket.RangeReader, k []byte, off, ln uint64) ([]byte, error) {
+	hv, err := rr.GetRange(k, 0, encHeaderSize)
+	if err != nil {
+		if errors.Is(errors.Cause(err), bucket.ErrOutOfRange) {
+			return nil, errNoEncryptedPayloadRead
+		}
+
+		return nil, err
+	} else if !isEncryptedBlob(hv) {
+		return nil, errNoEncryptedPayloadRead
+	}
+
+	h, err := parseEncHeader(hv)
+	if err != nil {
+		return nil, err
+	} else if h.off == 0 {
+		return nil, errNoEncryptedPayloadRead
+	}
+
+	aead, err := e.blobAEAD(h)
+	if err != nil {
+		return nil, err
+	}
+
+	if payload := h.size - h.off; off > payload || ln > payload-off {
+		return nil, ErrOutOfRange
+	}
+
+	from, to := h.off+off, h.off+off+ln
+	if ln == 0 {
+		return []byte{}, nil
+	}
+
+	var (
+		first, last = from / h.chunkSize, (to - 1) / h.chunkSize
+		sealed      = h.chunkSize + uint64(aead.Overhead())
+		encFrom     = encHeaderSize + first*sealed
+		encTo       = encHeaderSize + last*sealed + h.chunkLen(last) + uint64(aead.Overhead())
+	)
+
+	data, err := rr.GetRange(k, encFrom, encTo-encFrom)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := e.openChunks(h, data, first)
+	if err != nil {
+		return nil, err
+	}
+
+	return res[from-first*h.chunkSize : to-first*h.chunkSize], nil
+}
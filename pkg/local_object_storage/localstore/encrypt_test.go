@@ -0,0 +1,211 @@
+package localstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"github.com/nspcc-dev/neofs-api-go/object"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket/test"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// rangeCounter counts the bytes read by GetRange.
+type rangeCounter struct {
+	bucket.Bucket
+
+	read uint64
+}
+
+func (r *rangeCounter) GetRange(key []byte, off, ln uint64) ([]byte, error) {
+	r.read += ln
+	return r.Bucket.(bucket.RangeReader).GetRange(key, off, ln)
+}
+
+func testMasterKey(t *testing.T) []byte {
+	key := make([]byte, masterKeySize)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+
+	return key
+}
+
+func TestLocalstore_Encryption(t *testing.T) {
+	var (
+		blob = &rangeCounter{Bucket: test.Bucket()}
+		keys = test.Bucket()
+		p    = Params{
+			BlobBucket: blob,
+			MetaBucket: test.Bucket(),
+			KeyBucket:  keys,
+			Logger:     zap.L(),
+			Collector:  newCollector(),
+			Encryption: EncryptionParams{MasterKey: testMasterKey(t)},
+		}
+	)
+
+	ls, err := New(p)
+	require.NoError(t, err)
+
+	payload := make([]byte, 3*encChunkSize+100)
+	_, err = rand.Read(payload)
+	require.NoError(t, err)
+
+	obj := testObject(t)
+	obj.SetPayload(payload)
+
+	require.NoError(t, ls.Put(context.Background(), obj))
+
+	k, err := obj.Address().Hash()
+	require.NoError(t, err)
+
+	v, err := blob.Get(k)
+	require.NoError(t, err)
+	require.Equal(t, blobFormatEncrypted, v[0])
+	require.False(t, bytes.Contains(v, payload[:64]))
+
+	m, err := ls.Meta(*obj.Address())
+	require.NoError(t, err)
+	require.Zero(t, m.PayloadOffset)
+
+	o, err := ls.Get(*obj.Address())
+	require.NoError(t, err)
+	require.Equal(t, obj, o)
+
+	t.Run("range", func(t *testing.T) {
+		rng := object.Range{Offset: encChunkSize - 10, Length: 20}
+
+		blob.read = 0
+
+		data, err := ls.PRead(context.Background(), *obj.Address(), rng)
+		require.NoError(t, err)
+		require.Equal(t, payload[rng.Offset:rng.Offset+rng.Length], data)
+
+		// header and at most two chunks are read
+		require.True(t, blob.read <= encHeaderSize+2*(encChunkSize+16))
+
+		data, err = ls.PRead(context.Background(), *obj.Address(), object.Range{
+			Offset: uint64(len(payload)) - 5,
+			Length: 5,
+		})
+		require.NoError(t, err)
+		require.Equal(t, payload[len(payload)-5:], data)
+	})
+
+	t.Run("broken chunk", func(t *testing.T) {
+		broken := append([]byte{}, v...)
+		broken[len(broken)-1] ^= 0xFF
+		require.NoError(t, blob.Set(k, broken))
+
+		_, err := ls.Get(*obj.Address())
+		require.True(t, errors.Is(errors.Cause(err), errInvalidEncryptedBlob))
+
+		require.NoError(t, blob.Set(k, v))
+	})
+
+	t.Run("master key rotation", func(t *testing.T) {
+		wrapped, err := keys.List()
+		require.NoError(t, err)
+		require.Len(t, wrapped, 1)
+
+		before, err := keys.Get(wrapped[0])
+		require.NoError(t, err)
+
+		old := p.Encryption.MasterKey
+		p.Encryption = EncryptionParams{
+			MasterKey:          testMasterKey(t),
+			PreviousMasterKeys: [][]byte{old},
+		}
+
+		ls, err := New(p)
+		require.NoError(t, err)
+
+		after, err := keys.Get(wrapped[0])
+		require.NoError(t, err)
+		require.NotEqual(t, before, after)
+
+		o, err := ls.Get(*obj.Address())
+		require.NoError(t, err)
+		require.Equal(t, obj, o)
+
+		// blobs are not rewritten
+		stored, err := blob.Get(k)
+		require.NoError(t, err)
+		require.Equal(t, v, stored)
+
+		// previous key is not needed anymore
+		p.Encryption.PreviousMasterKeys = nil
+
+		_, err = New(p)
+		require.NoError(t, err)
+
+		p.Encryption.MasterKey = old
+
+		_, err = New(p)
+		require.True(t, errors.Is(errors.Cause(err), errUnknownMasterKey))
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		p.Encryption = EncryptionParams{}
+
+		ls, err := New(p)
+		require.NoError(t, err)
+
+		_, err = ls.Get(*obj.Address())
+		require.True(t, errors.Is(errors.Cause(err), ErrEncryptionDisabled))
+	})
+}
+
+func TestLoadMasterKey(t *testing.T) {
+	key := testMasterKey(t)
+
+	res, err := LoadMasterKey(hex.EncodeToString(key))
+	require.NoError(t, err)
+	require.Equal(t, key, res)
+
+	_, err = LoadMasterKey(hex.EncodeToString(key[1:]))
+	require.EqualError(t, err, errInvalidMasterKey.Error())
+}
+
+func TestEncryptor_BlobKeys(t *testing.T) {
+	e, err := newEncryptor(EncryptionParams{MasterKey: testMasterKey(t)}, test.Bucket(), zap.L())
+	require.NoError(t, err)
+
+	value := []byte("Hello, world")
+
+	v1, err := e.encryptBlob(value, 0)
+	require.NoError(t, err)
+
+	v2, err := e.encryptBlob(value, 0)
+	require.NoError(t, err)
+
+	h1, err := parseEncHeader(v1)
+	require.NoError(t, err)
+
+	h2, err := parseEncHeader(v2)
+	require.NoError(t, err)
+
+	// the same data key derives the different blob keys
+	require.Equal(t, h1.keyID, h2.keyID)
+	require.NotEqual(t, h1.salt, h2.salt)
+	require.NotEqual(t, deriveBlobKey(e.keys[h1.keyID], h1.salt), deriveBlobKey(e.keys[h2.keyID], h2.salt))
+	require.NotEqual(t, h1.chunkNonce(0), h1.chunkNonce(1))
+
+	for _, v := range [][]byte{v1, v2} {
+		res, err := e.decryptBlob(v)
+		require.NoError(t, err)
+		require.Equal(t, value, res)
+	}
+
+	// blob key depends on the salt
+	broken := append([]byte{}, v1...)
+	broken[5] ^= 0xFF
+
+	_, err = e.decryptBlob(broken)
+	require.True(t, errors.Is(errors.Cause(err), errInvalidEncryptedBlob))
+}
@@ -0,0 +1,309 @@
+package localstore
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sort"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+type (
+	// Migrator is an interface of local object storage
+	// that upgrades the on-disk format of the stored data.
+	//
+	// Migrator methods must not be called concurrently
+	// with the other localstore operations.
+	Migrator interface {
+		// FormatVersion returns the format version of the stored data.
+		FormatVersion() (uint64, error)
+
+		// Migrate runs the migrations from the stored format version
+		// to the current one. On dry run the storage is not changed,
+		// reports contain the number of items that would be changed.
+		Migrate(dryRun bool) ([]MigrationReport, error)
+	}
+
+	// MigrationReport describes the result of the format migration.
+	MigrationReport struct {
+		// Version is a format version the migration upgrades to.
+		Version uint64
+
+		// Name is a short description of the migration.
+		Name string
+
+		// Processed is a number of the checked items.
+		Processed int
+
+		// Changed is a number of the converted items.
+		Changed int
+	}
+
+	// migration converts the stored items to the next format version.
+	migration struct {
+		name string
+
+		// migrate converts the item by storage key and returns false if
+		// the item does not need the conversion. It must not change the
+		// storage on dry run. Items can be converted twice after the
+		// interruption, so migrate must skip the converted ones.
+		migrate func(l *localstore, key []byte, dryRun bool) (bool, error)
+	}
+)
+
+// baseFormatVersion is a format version of the data
+// stored before the format was versioned.
+const baseFormatVersion = 1
+
+// migrationProgressStep is a number of the migrated items
+// after which the migration progress is saved and logged.
+const migrationProgressStep = 1000
+
+// migrations are the format migrations ordered by version:
+// migrations[i] upgrades the data of version baseFormatVersion+i.
+var migrations = []migration{
+	{
+		name:    "split blobs",
+		migrate: (*localstore).migrateBlob,
+	},
+}
+
+var (
+	// formatVersionKey is a meta bucket key of the format version.
+	// Object storage keys are 32 bytes long, so they do not collide.
+	formatVersionKey = []byte("localstore format version")
+
+	// formatProgressKey is a meta bucket key of the interrupted
+	// migration progress: uint64(version) | last migrated key.
+	formatProgressKey = []byte("localstore format progress")
+)
+
+// ErrUnknownFormat is returned by New if the storage is written
+// in the format version newer than the supported one.
+var ErrUnknownFormat = errors.New("unknown localstore format version")
+
+var errInvalidFormatItem = errors.New("invalid format item")
+
+// FormatVersion returns the current format version of the local object storage.
+func FormatVersion() uint64 {
+	return baseFormatVersion + uint64(len(migrations))
+}
+
+// isFormatKey checks if the meta bucket key belongs to the format items.
+func isFormatKey(k []byte) bool {
+	return bytes.Equal(k, formatVersionKey) || bytes.Equal(k, formatProgressKey)
+}
+
+// FormatVersion returns the format version of the stored data.
+//
+// Empty storage is of the current version, the storage without
+// the version item is of the base version.
+func (l *localstore) FormatVersion() (uint64, error) {
+	v, err := l.metaBucket.Get(formatVersionKey)
+	if err == nil {
+		if len(v) != 8 {
+			return 0, errors.Wrap(errInvalidFormatItem, "format version")
+		}
+
+		return binary.BigEndian.Uint64(v), nil
+	} else if !isNotFound(err) {
+		return 0, errors.Wrap(err, "could not read format version")
+	}
+
+	keys, err := l.objectKeys()
+	if err != nil {
+		return 0, err
+	} else if len(keys) == 0 {
+		return FormatVersion(), nil
+	}
+
+	return baseFormatVersion, nil
+}
+
+// checkFormat returns the format version of the stored data or ErrUnknownFormat
+// if it is newer than the supported one.
+func (l *localstore) checkFormat() (uint64, error) {
+	ver, err := l.FormatVersion()
+	if err != nil {
+		return 0, err
+	} else if cur := FormatVersion(); ver > cur {
+		return 0, errors.Wrapf(ErrUnknownFormat, "stored %d, supported %d", ver, cur)
+	}
+
+	return ver, nil
+}
+
+// Migrate runs the migrations from the stored format version to the
+// current one. Interrupted migration is resumed from the last saved
+// progress. Migrations after the first one are counted on dry run over
+// the data that is not converted by the previous ones.
+func (l *localstore) Migrate(dryRun bool) ([]MigrationReport, error) {
+	ver, err := l.checkFormat()
+	if err != nil {
+		return nil, err
+	}
+
+	var reports []MigrationReport
+
+	for ; ver < FormatVersion(); ver++ {
+		rep, err := l.runMigration(ver+1, dryRun)
+		if err != nil {
+			return reports, errors.Wrapf(err, "could not migrate to format version %d", ver+1)
+		}
+
+		reports = append(reports, rep)
+	}
+
+	// new and base version storages have no version item
+	if !dryRun && !l.metaBucket.Has(formatVersionKey) {
+		if err := l.setFormatVersion(ver); err != nil {
+			return reports, err
+		}
+	}
+
+	return reports, nil
+}
+
+func (l *localstore) runMigration(ver uint64, dryRun bool) (MigrationReport, error) {
+	m := migrations[ver-baseFormatVersion-1]
+
+	rep := MigrationReport{
+		Version: ver,
+		Name:    m.name,
+	}
+
+	keys, err := l.objectKeys()
+	if err != nil {
+		return rep, err
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i], keys[j]) < 0
+	})
+
+	var last []byte
+
+	if !dryRun {
+		if last, err = l.migrationProgress(ver); err != nil {
+			return rep, err
+		}
+	}
+
+	// keys are migrated in order, so the migrated ones are skipped on resume
+	from := 0
+	if last != nil {
+		from = sort.Search(len(keys), func(i int) bool {
+			return bytes.Compare(keys[i], last) > 0
+		})
+	}
+
+	l.log.Info("localstore format migration started",
+		zap.Uint64("version", ver),
+		zap.String("name", m.name),
+		zap.Int("items", len(keys)),
+		zap.Int("resumed from", from),
+		zap.Bool("dry run", dryRun))
+
+	for i := from; i < len(keys); i++ {
+		changed, err := m.migrate(l, keys[i], dryRun)
+		if err != nil {
+			if !dryRun && last != nil {
+				if err := l.setMigrationProgress(ver, last); err != nil {
+					l.log.Error("could not save format migration progress", zap.Error(err))
+				}
+			}
+
+			return rep, err
+		}
+
+		last = keys[i]
+		rep.Processed++
+
+		if changed {
+			rep.Changed++
+		}
+
+		if rep.Processed%migrationProgressStep == 0 {
+			if !dryRun {
+				if err := l.setMigrationProgress(ver, last); err != nil {
+					return rep, err
+				}
+			}
+
+			l.log.Info("localstore format migration progress",
+				zap.Uint64("version", ver),
+				zap.Int("done", from+rep.Processed),
+				zap.Int("items", len(keys)))
+		}
+	}
+
+	if !dryRun {
+		if err := l.setFormatVersion(ver); err != nil {
+			return rep, err
+		} else if err := l.metaBucket.Del(formatProgressKey); err != nil && !isNotFound(err) {
+			return rep, errors.Wrap(err, "could not remove format migration progress")
+		}
+	}
+
+	l.log.Info("localstore format migration finished",
+		zap.Uint64("version", ver),
+		zap.String("name", m.name),
+		zap.Int("processed", rep.Processed),
+		zap.Int("changed", rep.Changed),
+		zap.Bool("dry run", dryRun))
+
+	return rep, nil
+}
+
+// objectKeys returns the meta bucket keys of the stored objects.
+func (l *localstore) objectKeys() ([][]byte, error) {
+	keys, err := l.metaBucket.List()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not list meta bucket")
+	}
+
+	res := keys[:0]
+
+	for i := range keys {
+		if !isFormatKey(keys[i]) {
+			res = append(res, keys[i])
+		}
+	}
+
+	return res, nil
+}
+
+func (l *localstore) setFormatVersion(ver uint64) error {
+	v := make([]byte, 8)
+	binary.BigEndian.PutUint64(v, ver)
+
+	return errors.Wrap(l.metaBucket.Set(formatVersionKey, v), "could not write format version")
+}
+
+// migrationProgress returns the last migrated key of the interrupted
+// migration to the version. Nil is returned if there is no such migration.
+func (l *localstore) migrationProgress(ver uint64) ([]byte, error) {
+	v, err := l.metaBucket.Get(formatProgressKey)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, errors.Wrap(err, "could not read format migration progress")
+	} else if len(v) < 8 {
+		return nil, errors.Wrap(errInvalidFormatItem, "format migration progress")
+	} else if binary.BigEndian.Uint64(v) != ver {
+		return nil, nil
+	}
+
+	return v[8:], nil
+}
+
+func (l *localstore) setMigrationProgress(ver uint64, last []byte) error {
+	v := make([]byte, 8, 8+len(last))
+	binary.BigEndian.PutUint64(v, ver)
+
+	return errors.Wrap(l.metaBucket.Set(formatProgressKey, append(v, last...)),
+		"could not write format migration progress")
+}
@@ -0,0 +1,151 @@
+package localstore
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket/test"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestLocalstore_FormatVersion(t *testing.T) {
+	meta := test.Bucket()
+	p := Params{
+		BlobBucket: test.Bucket(),
+		MetaBucket: meta,
+		Logger:     zap.L(),
+		Collector:  newCollector(),
+	}
+
+	ls, err := New(p)
+	require.NoError(t, err)
+
+	ver, err := ls.(Migrator).FormatVersion()
+	require.NoError(t, err)
+	require.Equal(t, FormatVersion(), ver)
+	require.True(t, meta.Has(formatVersionKey))
+
+	obj := testObject(t)
+	require.NoError(t, ls.Put(context.Background(), obj))
+
+	cnt, err := ls.ObjectsCount()
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), cnt)
+
+	addrs, err := ListItems(ls, nil)
+	require.NoError(t, err)
+	require.Len(t, addrs, 1)
+
+	t.Run("newer version", func(t *testing.T) {
+		require.NoError(t, ls.(*localstore).setFormatVersion(FormatVersion()+1))
+
+		_, err := New(p)
+		require.True(t, errors.Is(errors.Cause(err), ErrUnknownFormat))
+	})
+}
+
+func TestLocalstore_Migrate(t *testing.T) {
+	defer func(m []migration) { migrations = m }(migrations)
+
+	var (
+		converted = make(map[string]struct{})
+		failKey   []byte
+	)
+
+	meta := test.Bucket()
+	p := Params{
+		BlobBucket:     test.Bucket(),
+		MetaBucket:     meta,
+		Logger:         zap.L(),
+		Collector:      newCollector(),
+		SkipMigrations: true,
+	}
+
+	ls, err := New(p)
+	require.NoError(t, err)
+
+	keys := make([][]byte, 3)
+
+	for i := range keys {
+		obj := testObject(t)
+		require.NoError(t, ls.Put(context.Background(), obj))
+
+		keys[i], err = obj.Address().Hash()
+		require.NoError(t, err)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i], keys[j]) < 0
+	})
+
+	migrations = []migration{{
+		name: "test",
+		migrate: func(_ *localstore, key []byte, dryRun bool) (bool, error) {
+			if bytes.Equal(key, failKey) {
+				return false, errors.New("migration failure")
+			} else if _, ok := converted[string(key)]; ok {
+				return false, nil
+			} else if !dryRun {
+				converted[string(key)] = struct{}{}
+			}
+
+			return true, nil
+		},
+	}}
+
+	m := ls.(Migrator)
+
+	requireVersion := func(exp uint64) {
+		ver, err := m.FormatVersion()
+		require.NoError(t, err)
+		require.Equal(t, exp, ver)
+	}
+
+	requireVersion(baseFormatVersion)
+
+	t.Run("dry run", func(t *testing.T) {
+		reports, err := m.Migrate(true)
+		require.NoError(t, err)
+		require.Equal(t, []MigrationReport{{
+			Version:   baseFormatVersion + 1,
+			Name:      "test",
+			Processed: len(keys),
+			Changed:   len(keys),
+		}}, reports)
+
+		require.Empty(t, converted)
+		requireVersion(baseFormatVersion)
+	})
+
+	t.Run("resume", func(t *testing.T) {
+		failKey = keys[1]
+
+		_, err := m.Migrate(false)
+		require.Error(t, err)
+		require.Len(t, converted, 1)
+		requireVersion(baseFormatVersion)
+		require.True(t, meta.Has(formatProgressKey))
+
+		failKey = nil
+
+		// interrupted migration is resumed on start
+		p.SkipMigrations = false
+
+		ls, err := New(p)
+		require.NoError(t, err)
+		require.Len(t, converted, len(keys))
+		require.False(t, meta.Has(formatProgressKey))
+
+		ver, err := ls.(Migrator).FormatVersion()
+		require.NoError(t, err)
+		require.Equal(t, FormatVersion(), ver)
+
+		reports, err := ls.(Migrator).Migrate(false)
+		require.NoError(t, err)
+		require.Empty(t, reports)
+	})
+}
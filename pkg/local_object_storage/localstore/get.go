@@ -9,7 +9,7 @@ func (l *localstore) Get(key refs.Address) (*Object, error) {
 	var (
 		err  error
 		k, v []byte
-		o    = new(Object)
+		o    *Object
 	)
 
 	k, err = key.Hash()
@@ -22,7 +22,7 @@ func (l *localstore) Get(key refs.Address) (*Object, error) {
 		return nil, errors.Wrap(err, "Localstore Get failed on blobBucket.Get")
 	}
 
-	if err = o.Unmarshal(v); err != nil {
+	if o, err = l.decodeBlob(v); err != nil {
 		return nil, errors.Wrap(err, "Localstore Get failed on Object.Unmarshal")
 	}
 
@@ -0,0 +1,386 @@
+package localstore
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"sort"
+
+	"github.com/nspcc-dev/neofs-api-go/object"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+type (
+	// IndexAttr is an enumeration of indexed object attributes.
+	IndexAttr byte
+
+	// IndexFilter is an exact match condition on the indexed object attribute.
+	IndexFilter struct {
+		Attr IndexAttr
+
+		// Key is a user header key, it is used with IndexUserHeader only.
+		Key string
+
+		Value string
+	}
+
+	// Searcher is an interface of local object storage
+	// that selects objects through the secondary indexes.
+	Searcher interface {
+		// Search calls handler for ObjectMeta of the objects
+		// that match all the filters until handler returns true.
+		//
+		// ErrIndexDisabled is returned if indexes are not maintained
+		// or are not built yet.
+		Search([]IndexFilter, MetaHandler) error
+	}
+)
+
+const (
+	_ IndexAttr = iota
+
+	// IndexCID is an IndexAttr of the container ID.
+	IndexCID
+
+	// IndexOwnerID is an IndexAttr of the object owner ID.
+	IndexOwnerID
+
+	// IndexParent is an IndexAttr of the parent object ID.
+	IndexParent
+
+	// IndexChild is an IndexAttr of the child object ID.
+	IndexChild
+
+	// IndexRoot is an IndexAttr of the objects without parent, value is empty.
+	IndexRoot
+
+	// IndexStorageGroup is an IndexAttr of the storage group objects, value is empty.
+	IndexStorageGroup
+
+	// IndexUserHeader is an IndexAttr of the user header value.
+	IndexUserHeader
+)
+
+// indexVersionKey is a key of the index bucket item that marks
+// the indexes as built. Index keys always start with non-zero IndexAttr.
+var indexVersionKey = []byte{0}
+
+const indexVersion = 2
+
+// maxIndexValueLen is a maximum length of the key and the value
+// stored in the index item as is. Longer ones are replaced with
+// their SHA-256 hash, so the index keys fit the bucket limits.
+const maxIndexValueLen = 256
+
+// ErrIndexDisabled is returned by Search if localstore
+// does not maintain the secondary indexes or they are not built.
+var ErrIndexDisabled = errors.New("localstore indexes are disabled")
+
+var errEmptyIndexFilters = errors.New("empty index filters")
+
+// indexPrefix returns the key prefix of the index items:
+//
+//	attr | uvarint(len(key)) | key | uvarint(len(value)) | value
+//
+// Key and value longer than maxIndexValueLen are written as their hash,
+// the original length keeps them apart from the short ones.
+//
+// Index item key is the prefix followed by the object storage key.
+func indexPrefix(f IndexFilter) []byte {
+	buf := make([]byte, 1+2*(binary.MaxVarintLen64+maxIndexValueLen))
+	buf[0] = byte(f.Attr)
+
+	n := 1
+	n += putIndexValue(buf[n:], f.Key)
+	n += putIndexValue(buf[n:], f.Value)
+
+	return buf[:n]
+}
+
+func putIndexValue(buf []byte, v string) int {
+	n := binary.PutUvarint(buf, uint64(len(v)))
+
+	if len(v) > maxIndexValueLen {
+		h := sha256.Sum256([]byte(v))
+		return n + copy(buf[n:], h[:])
+	}
+
+	return n + copy(buf[n:], v)
+}
+
+// indexFilters returns the indexed attributes of the object.
+func indexFilters(obj *Object) []IndexFilter {
+	res := []IndexFilter{
+		{Attr: IndexCID, Value: obj.SystemHeader.CID.String()},
+		{Attr: IndexOwnerID, Value: obj.SystemHeader.OwnerID.String()},
+	}
+
+	root := true
+
+	for i := range obj.Headers {
+		switch h := obj.Headers[i].Value.(type) {
+		case *object.Header_Link:
+			if h.Link == nil {
+				continue
+			}
+
+			switch h.Link.Type {
+			case object.Link_Parent:
+				root = false
+				res = append(res, IndexFilter{Attr: IndexParent, Value: h.Link.ID.String()})
+			case object.Link_Child:
+				res = append(res, IndexFilter{Attr: IndexChild, Value: h.Link.ID.String()})
+			}
+		case *object.Header_UserHeader:
+			if h.UserHeader == nil {
+				continue
+			}
+
+			res = append(res, IndexFilter{
+				Attr:  IndexUserHeader,
+				Key:   h.UserHeader.Key,
+				Value: h.UserHeader.Value,
+			})
+		case *object.Header_StorageGroup:
+			res = append(res, IndexFilter{Attr: IndexStorageGroup})
+		}
+	}
+
+	if root {
+		res = append(res, IndexFilter{Attr: IndexRoot})
+	}
+
+	return res
+}
+
+// addIndex saves index items of the object.
+func (l *localstore) addIndex(k []byte, obj *Object) error {
+	if l.indexBucket == nil {
+		return nil
+	}
+
+	for _, f := range indexFilters(obj) {
+		if err := l.indexBucket.Set(append(indexPrefix(f), k...), []byte{}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// dropIndex removes index items of the stored object.
+func (l *localstore) dropIndex(k []byte) error {
+	if l.indexBucket == nil {
+		return nil
+	}
+
+	mv, err := l.metaBucket.Get(k)
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	meta := new(ObjectMeta)
+	if err := meta.Unmarshal(mv); err != nil || meta.Object == nil {
+		// stale index items are skipped by Search
+		return nil
+	}
+
+	for _, f := range indexFilters(meta.Object) {
+		if err := l.indexBucket.Del(append(indexPrefix(f), k...)); err != nil && !isNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resetIndex drops the index version, so the indexes
+// are rebuilt on the next start.
+func (l *localstore) resetIndex() {
+	if err := l.indexBucket.Del(indexVersionKey); err != nil && !isNotFound(err) {
+		l.log.Warn("could not reset localstore indexes", zap.Error(err))
+	}
+}
+
+// buildIndex fills the index bucket from the meta bucket
+// if it has not been done before for the current index version.
+func (l *localstore) buildIndex() error {
+	if l.indexBuilt() {
+		return nil
+	} else if l.indexBucket.Has(indexVersionKey) {
+		// items of the previous version can not be found anymore
+		if err := l.clearIndex(); err != nil {
+			return errors.Wrap(err, "could not clear outdated indexes")
+		}
+	}
+
+	l.log.Info("building localstore indexes")
+
+	var (
+		cnt int
+		err error
+	)
+
+	if iterErr := l.metaBucket.Iterate(func(k, v []byte) bool {
+		if isFormatKey(k) {
+			return true
+		}
+
+		meta := new(ObjectMeta)
+		if meta.Unmarshal(v) != nil || meta.Object == nil {
+			l.log.Warn("skip broken meta on index build")
+			return true
+		}
+
+		if err = l.addIndex(k, meta.Object); err != nil {
+			return false
+		}
+
+		cnt++
+
+		return true
+	}); err != nil {
+		return err
+	} else if iterErr != nil {
+		return iterErr
+	}
+
+	ver := make([]byte, 8)
+	binary.BigEndian.PutUint64(ver, indexVersion)
+
+	if err := l.indexBucket.Set(indexVersionKey, ver); err != nil {
+		return err
+	}
+
+	l.log.Info("localstore indexes built",
+		zap.Int("objects", cnt))
+
+	return nil
+}
+
+// indexBuilt checks if the indexes of the current version are built.
+func (l *localstore) indexBuilt() bool {
+	ver, err := l.indexBucket.Get(indexVersionKey)
+
+	return err == nil && len(ver) == 8 && binary.BigEndian.Uint64(ver) == indexVersion
+}
+
+// clearIndex removes all the index items.
+func (l *localstore) clearIndex() error {
+	var keys [][]byte
+
+	if err := l.indexBucket.Iterate(func(k, _ []byte) bool {
+		keys = append(keys, append([]byte(nil), k...))
+		return true
+	}); err != nil {
+		return err
+	}
+
+	for i := range keys {
+		if err := l.indexBucket.Del(keys[i]); err != nil && !isNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (l *localstore) iteratePrefix(prefix []byte, h bucket.FilterHandler) error {
+	if pi, ok := l.indexBucket.(bucket.PrefixIterator); ok {
+		return pi.IteratePrefix(prefix, h)
+	}
+
+	return l.indexBucket.Iterate(func(k, v []byte) bool {
+		return !bytes.HasPrefix(k, prefix) || h(k, v)
+	})
+}
+
+// Search selects the objects through the index intersection.
+func (l *localstore) Search(fs []IndexFilter, handler MetaHandler) error {
+	switch {
+	case handler == nil:
+		return ErrEmptyMetaHandler
+	case l.indexBucket == nil, !l.indexBuilt():
+		return ErrIndexDisabled
+	case len(fs) == 0:
+		return errEmptyIndexFilters
+	}
+
+	fs = append([]IndexFilter(nil), fs...)
+
+	// container and owner usually match a lot of objects,
+	// so the narrower sets are selected first
+	sort.SliceStable(fs, func(i, j int) bool {
+		return attrWidth(fs[i].Attr) < attrWidth(fs[j].Attr)
+	})
+
+	var keys map[string]struct{}
+
+	for i := range fs {
+		prefix := indexPrefix(fs[i])
+		set := make(map[string]struct{})
+
+		if err := l.iteratePrefix(prefix, func(k, _ []byte) bool {
+			sk := string(k[len(prefix):])
+
+			if _, ok := keys[sk]; keys == nil || ok {
+				set[sk] = struct{}{}
+			}
+
+			return true
+		}); err != nil {
+			return errors.Wrap(err, "could not read index")
+		}
+
+		if keys = set; len(keys) == 0 {
+			return nil
+		}
+	}
+
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+
+	sort.Strings(sorted)
+
+	for _, k := range sorted {
+		mv, err := l.metaBucket.Get([]byte(k))
+		if err != nil {
+			if isNotFound(err) {
+				// object was removed concurrently
+				continue
+			}
+
+			return err
+		}
+
+		meta := new(ObjectMeta)
+		if err := meta.Unmarshal(mv); err != nil {
+			l.log.Error("unmarshal meta bucket item failure", zap.Error(err))
+			continue
+		}
+
+		if handler(meta) {
+			break
+		}
+	}
+
+	return nil
+}
+
+// attrWidth returns the expected relative number of objects matching the attribute.
+func attrWidth(a IndexAttr) int {
+	switch a {
+	case IndexCID, IndexOwnerID, IndexRoot:
+		return 1
+	default:
+		return 0
+	}
+}
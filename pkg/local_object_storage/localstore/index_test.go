@@ -0,0 +1,190 @@
+package localstore
+
+import (
+	"context"
+	"encoding/binary"
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/neofs-api-go/object"
+	"github.com/nspcc-dev/neofs-api-go/refs"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket/test"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newIndexedLocalstore(t *testing.T, blob, meta, index bucket.Bucket) Localstore {
+	ls, err := New(Params{
+		BlobBucket:  blob,
+		MetaBucket:  meta,
+		IndexBucket: index,
+		Logger:      zap.L(),
+		Collector:   newCollector(),
+	})
+	require.NoError(t, err)
+
+	return ls
+}
+
+func searchAddresses(t *testing.T, ls Localstore, fs ...IndexFilter) []refs.Address {
+	var res []refs.Address
+
+	require.NoError(t, ls.(Searcher).Search(fs, func(meta *ObjectMeta) bool {
+		res = append(res, *meta.Object.Address())
+		return false
+	}))
+
+	return res
+}
+
+func TestLocalstore_Search(t *testing.T) {
+	ls := newIndexedLocalstore(t, test.Bucket(), test.Bucket(), test.Bucket())
+
+	dev := testObject(t)
+
+	tester := testObject(t)
+	tester.SystemHeader.CID = dev.SystemHeader.CID
+	tester.Headers[0].Value.(*object.Header_UserHeader).UserHeader.Value = "Tester"
+
+	child := testObject(t)
+	child.SystemHeader.CID = dev.SystemHeader.CID
+	child.Headers = append(child.Headers, Header{
+		Value: &object.Header_Link{
+			Link: &object.Link{Type: object.Link_Parent, ID: dev.SystemHeader.ID},
+		},
+	})
+
+	for _, obj := range []*Object{dev, tester, child} {
+		require.NoError(t, ls.Put(context.Background(), obj))
+	}
+
+	cidFilter := IndexFilter{Attr: IndexCID, Value: dev.SystemHeader.CID.String()}
+	devFilter := IndexFilter{Attr: IndexUserHeader, Key: "Profession", Value: "Developer"}
+
+	require.Len(t, searchAddresses(t, ls, cidFilter), 3)
+	require.ElementsMatch(t,
+		[]refs.Address{*dev.Address(), *child.Address()},
+		searchAddresses(t, ls, cidFilter, devFilter))
+	require.ElementsMatch(t,
+		[]refs.Address{*dev.Address(), *tester.Address()},
+		searchAddresses(t, ls, cidFilter, IndexFilter{Attr: IndexRoot}))
+	require.Equal(t,
+		[]refs.Address{*child.Address()},
+		searchAddresses(t, ls, IndexFilter{Attr: IndexParent, Value: dev.SystemHeader.ID.String()}))
+	require.Empty(t, searchAddresses(t, ls, devFilter, IndexFilter{Attr: IndexStorageGroup}))
+
+	t.Run("stop", func(t *testing.T) {
+		cnt := 0
+
+		require.NoError(t, ls.(Searcher).Search([]IndexFilter{cidFilter}, func(*ObjectMeta) bool {
+			cnt++
+			return true
+		}))
+		require.Equal(t, 1, cnt)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, ls.Del(*dev.Address()))
+		require.Equal(t, []refs.Address{*child.Address()}, searchAddresses(t, ls, cidFilter, devFilter))
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		err := newLocalstore(t).(Searcher).Search([]IndexFilter{cidFilter}, func(*ObjectMeta) bool { return false })
+		require.Equal(t, ErrIndexDisabled, err)
+	})
+}
+
+func TestLocalstore_BuildIndex(t *testing.T) {
+	blob, meta, index := test.Bucket(), test.Bucket(), test.Bucket()
+
+	obj := testObject(t)
+	require.NoError(t, newIndexedLocalstore(t, blob, meta, nil).Put(context.Background(), obj))
+
+	// indexes are built on start
+	ls := newIndexedLocalstore(t, blob, meta, index)
+	require.True(t, index.Has(indexVersionKey))
+	require.Equal(t,
+		[]refs.Address{*obj.Address()},
+		searchAddresses(t, ls, IndexFilter{Attr: IndexOwnerID, Value: obj.SystemHeader.OwnerID.String()}))
+}
+
+func TestLocalstore_PutIndexFailure(t *testing.T) {
+	blob, meta, index := test.Bucket(), test.Bucket(), test.Bucket()
+
+	obj := testObject(t)
+	ownerFilter := IndexFilter{Attr: IndexOwnerID, Value: obj.SystemHeader.OwnerID.String()}
+
+	newIndexedLocalstore(t, blob, meta, index)
+
+	ls := newIndexedLocalstore(t, blob, meta, &crashBucket{
+		Bucket: index,
+		cp:     &crashPoint{transient: true},
+	})
+
+	// index failure does not fail the stored object
+	require.NoError(t, ls.Put(context.Background(), obj))
+	require.False(t, index.Has(indexVersionKey))
+
+	ok, err := ls.Has(*obj.Address())
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// indexes are rebuilt on restart
+	ls = newIndexedLocalstore(t, blob, meta, index)
+	require.Equal(t, []refs.Address{*obj.Address()}, searchAddresses(t, ls, ownerFilter))
+}
+
+func TestLocalstore_LongIndexValue(t *testing.T) {
+	index := test.Bucket()
+	ls := newIndexedLocalstore(t, test.Bucket(), test.Bucket(), index)
+
+	long := strings.Repeat("v", 32*1024)
+
+	obj := testObject(t)
+	obj.Headers = append(obj.Headers, Header{
+		Value: &object.Header_UserHeader{
+			UserHeader: &object.UserHeader{Key: long, Value: long},
+		},
+	})
+
+	require.NoError(t, ls.Put(context.Background(), obj))
+	require.True(t, index.Has(indexVersionKey))
+
+	require.NoError(t, index.Iterate(func(k, _ []byte) bool {
+		require.Less(t, len(k), 2*maxIndexValueLen+256)
+		return true
+	}))
+
+	require.Equal(t,
+		[]refs.Address{*obj.Address()},
+		searchAddresses(t, ls, IndexFilter{Attr: IndexUserHeader, Key: long, Value: long}))
+	require.Empty(t, searchAddresses(t, ls, IndexFilter{Attr: IndexUserHeader, Key: long, Value: long[1:]}))
+}
+
+func TestLocalstore_SearchNotBuilt(t *testing.T) {
+	blob, meta, index := test.Bucket(), test.Bucket(), test.Bucket()
+
+	obj := testObject(t)
+	ownerFilter := IndexFilter{Attr: IndexOwnerID, Value: obj.SystemHeader.OwnerID.String()}
+
+	ls := newIndexedLocalstore(t, blob, meta, index)
+	require.NoError(t, ls.Put(context.Background(), obj))
+
+	ls.(*localstore).resetIndex()
+
+	err := ls.(Searcher).Search([]IndexFilter{ownerFilter}, func(*ObjectMeta) bool { return false })
+	require.Equal(t, ErrIndexDisabled, err)
+
+	t.Run("outdated version", func(t *testing.T) {
+		ver := make([]byte, 8)
+		binary.BigEndian.PutUint64(ver, indexVersion-1)
+		require.NoError(t, index.Set(indexVersionKey, ver))
+		require.NoError(t, index.Set([]byte{byte(IndexOwnerID), 0xFF}, []byte{}))
+
+		// outdated indexes are rebuilt on start
+		ls := newIndexedLocalstore(t, blob, meta, index)
+		require.False(t, index.Has([]byte{byte(IndexOwnerID), 0xFF}))
+		require.Equal(t, []refs.Address{*obj.Address()}, searchAddresses(t, ls, ownerFilter))
+	})
+}
@@ -0,0 +1,304 @@
+package localstore
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/nspcc-dev/neofs-api-go/hash"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+type (
+	// Inspector is an interface of local object storage
+	// that checks and repairs the stored data offline.
+	//
+	// Inspector methods must not be called concurrently
+	// with the other localstore operations.
+	Inspector interface {
+		// CheckConsistency calls handler for each mismatch
+		// between the blob and meta buckets.
+		CheckConsistency(MismatchHandler) error
+
+		// RebuildMeta recreates the meta bucket items from the blobs
+		// and returns the number of restored items. Meta items
+		// without blobs are removed.
+		RebuildMeta() (int, error)
+	}
+
+	// MismatchType is an enumeration of blob and meta bucket mismatches.
+	MismatchType int
+
+	// Mismatch describes the inconsistency of the stored object.
+	Mismatch struct {
+		Type MismatchType
+
+		// Key is a storage key of the object.
+		Key []byte
+
+		// Err is a reason of the mismatch, it can be nil.
+		Err error
+	}
+
+	// MismatchHandler is a function that handles Mismatch.
+	MismatchHandler func(Mismatch)
+)
+
+const (
+	_ MismatchType = iota
+
+	// MismatchMissingBlob is a MismatchType of the meta item without blob.
+	MismatchMissingBlob
+
+	// MismatchMissingMeta is a MismatchType of the blob without meta item.
+	MismatchMissingMeta
+
+	// MismatchBrokenBlob is a MismatchType of the blob that can not be decoded.
+	MismatchBrokenBlob
+
+	// MismatchBrokenMeta is a MismatchType of the meta item that can not be decoded.
+	MismatchBrokenMeta
+
+	// MismatchHeader is a MismatchType of the object headers
+	// that differ in the blob and meta items.
+	MismatchHeader
+
+	// MismatchPayload is a MismatchType of the payload which size,
+	// hash or offset differs from the meta item.
+	MismatchPayload
+)
+
+var (
+	errPayloadSize   = errors.New("payload size differs from meta")
+	errPayloadHash   = errors.New("payload hash differs from meta")
+	errPayloadOffset = errors.New("payload offset differs from meta")
+	errKeyMismatch   = errors.New("object address does not match the key")
+)
+
+// String returns the name of the mismatch type.
+func (t MismatchType) String() string {
+	switch t {
+	case MismatchMissingBlob:
+		return "MISSING_BLOB"
+	case MismatchMissingMeta:
+		return "MISSING_META"
+	case MismatchBrokenBlob:
+		return "BROKEN_BLOB"
+	case MismatchBrokenMeta:
+		return "BROKEN_META"
+	case MismatchHeader:
+		return "HEADER_MISMATCH"
+	case MismatchPayload:
+		return "PAYLOAD_MISMATCH"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// CheckConsistency compares every meta item with its blob
+// and looks for the blobs without meta items.
+func (l *localstore) CheckConsistency(handler MismatchHandler) error {
+	if handler == nil {
+		return errors.New("mismatch handler is nil")
+	}
+
+	var err error
+
+	if iterErr := l.metaBucket.Iterate(func(k, mv []byte) bool {
+		if isFormatKey(k) {
+			return true
+		}
+
+		var m *Mismatch
+
+		if m, err = l.checkItem(k, mv); err != nil {
+			return false
+		} else if m != nil {
+			handler(*m)
+		}
+
+		return true
+	}); err != nil {
+		return err
+	} else if iterErr != nil {
+		return errors.Wrap(iterErr, "could not iterate meta bucket")
+	}
+
+	keys, err := l.blobBucket.List()
+	if err != nil {
+		return errors.Wrap(err, "could not list blob bucket")
+	}
+
+	for i := range keys {
+		if !l.metaBucket.Has(keys[i]) {
+			handler(Mismatch{Type: MismatchMissingMeta, Key: keys[i]})
+		}
+	}
+
+	return nil
+}
+
+func (l *localstore) checkItem(k, mv []byte) (*Mismatch, error) {
+	meta := new(ObjectMeta)
+	if err := meta.Unmarshal(mv); err != nil {
+		return &Mismatch{Type: MismatchBrokenMeta, Key: k, Err: err}, nil
+	} else if meta.Object == nil {
+		return &Mismatch{Type: MismatchBrokenMeta, Key: k}, nil
+	}
+
+	v, err := l.blobBucket.Get(k)
+	if err != nil {
+		if isNotFound(err) {
+			return &Mismatch{Type: MismatchMissingBlob, Key: k}, nil
+		}
+
+		return nil, errors.Wrap(err, "could not read blob")
+	}
+
+	obj, err := l.decodeBlob(v)
+	if err != nil {
+		return &Mismatch{Type: MismatchBrokenBlob, Key: k, Err: err}, nil
+	}
+
+	hdr := *obj
+	hdr.Payload = nil
+
+	bh, err := hdr.Marshal()
+	if err != nil {
+		return &Mismatch{Type: MismatchBrokenBlob, Key: k, Err: err}, nil
+	}
+
+	mh, err := meta.Object.Marshal()
+	if err != nil {
+		return &Mismatch{Type: MismatchBrokenMeta, Key: k, Err: err}, nil
+	}
+
+	if !bytes.Equal(bh, mh) {
+		return &Mismatch{Type: MismatchHeader, Key: k}, nil
+	}
+
+	switch {
+	case uint64(len(obj.Payload)) != meta.PayloadSize:
+		err = errPayloadSize
+	case hash.Sum(obj.Payload) != meta.PayloadHash:
+		err = errPayloadHash
+	case meta.PayloadOffset > 0:
+		if off, _ := blobPayloadOffset(v); off != meta.PayloadOffset {
+			err = errPayloadOffset
+		}
+	}
+
+	if err != nil {
+		return &Mismatch{Type: MismatchPayload, Key: k, Err: err}, nil
+	}
+
+	return nil, nil
+}
+
+// RebuildMeta restores the meta items of all the blobs.
+//
+// Store epochs of the existing meta items are kept, restored items get
+// zero epoch. Indexes of the restored items are updated if the index
+// bucket is set. Rebuild is not journaled, it is expected to be run on
+// the stopped node.
+func (l *localstore) RebuildMeta() (int, error) {
+	keys, err := l.blobBucket.List()
+	if err != nil {
+		return 0, errors.Wrap(err, "could not list blob bucket")
+	}
+
+	var (
+		cnt   int
+		blobs = make(map[string]struct{}, len(keys))
+	)
+
+	for i := range keys {
+		ok, err := l.rebuildItem(keys[i])
+		if err != nil {
+			return cnt, errors.Wrap(err, "could not rebuild meta item")
+		} else if ok {
+			blobs[string(keys[i])] = struct{}{}
+			cnt++
+		}
+	}
+
+	metaKeys, err := l.objectKeys()
+	if err != nil {
+		return cnt, err
+	}
+
+	for i := range metaKeys {
+		if _, ok := blobs[string(metaKeys[i])]; ok {
+			continue
+		}
+
+		if err := l.dropIndex(metaKeys[i]); err != nil {
+			return cnt, errors.Wrap(err, "could not remove index items")
+		} else if err := l.metaBucket.Del(metaKeys[i]); err != nil && !isNotFound(err) {
+			return cnt, errors.Wrap(err, "could not remove meta item")
+		}
+	}
+
+	l.log.Info("localstore meta rebuilt",
+		zap.Int("objects", cnt),
+		zap.Int("removed", len(metaKeys)-len(blobs)))
+
+	return cnt, nil
+}
+
+// rebuildItem replaces the meta item by the one restored from the blob.
+// False is returned if the blob is skipped.
+func (l *localstore) rebuildItem(k []byte) (bool, error) {
+	v, err := l.blobBucket.Get(k)
+	if err != nil {
+		if isNotFound(err) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	obj, err := l.decodeBlob(v)
+	if err != nil {
+		l.log.Warn("skip broken blob on meta rebuild",
+			zap.Error(err))
+
+		return false, nil
+	}
+
+	if ak, err := obj.Address().Hash(); err != nil || !bytes.Equal(ak, k) {
+		l.log.Warn("skip blob on meta rebuild",
+			zap.Error(errKeyMismatch))
+
+		return false, nil
+	}
+
+	meta := metaFromObject(context.Background(), obj)
+
+	if meta.PayloadOffset, err = blobPayloadOffset(v); err != nil {
+		return false, err
+	}
+
+	if old, err := l.metaBucket.Get(k); err == nil {
+		oldMeta := new(ObjectMeta)
+		if oldMeta.Unmarshal(old) == nil {
+			meta.StoreEpoch = oldMeta.StoreEpoch
+		}
+
+		// items of the old meta could differ
+		if err := l.dropIndex(k); err != nil {
+			return false, err
+		}
+	}
+
+	mv, err := meta.Marshal()
+	if err != nil {
+		return false, err
+	}
+
+	if err := l.metaBucket.Set(k, mv); err != nil {
+		return false, err
+	}
+
+	return true, l.addIndex(k, obj)
+}
@@ -0,0 +1,81 @@
+package localstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket/test"
+	"github.com/stretchr/testify/require"
+)
+
+func collectMismatches(t *testing.T, ls Localstore) map[MismatchType]int {
+	res := make(map[MismatchType]int)
+
+	require.NoError(t, ls.(Inspector).CheckConsistency(func(m Mismatch) {
+		res[m.Type]++
+	}))
+
+	return res
+}
+
+func TestLocalstore_CheckConsistency(t *testing.T) {
+	ls := newIndexedLocalstore(t, test.Bucket(), test.Bucket(), test.Bucket())
+	store := ls.(*localstore)
+
+	objs := make([]*Object, 4)
+	keys := make([][]byte, len(objs))
+
+	for i := range objs {
+		objs[i] = testObject(t)
+		objs[i].SetPayload([]byte("Hello, world"))
+
+		require.NoError(t, ls.Put(context.Background(), objs[i]))
+
+		var err error
+		keys[i], err = objs[i].Address().Hash()
+		require.NoError(t, err)
+	}
+
+	require.Empty(t, collectMismatches(t, ls))
+
+	// blob without meta
+	require.NoError(t, store.metaBucket.Del(keys[0]))
+
+	// meta without blob
+	require.NoError(t, store.blobBucket.Del(keys[1]))
+
+	// broken meta
+	require.NoError(t, store.metaBucket.Set(keys[2], []byte{0xFF}))
+
+	// payload differs from meta
+	broken := *objs[3]
+	broken.Payload = []byte("Hello, world!")
+	v, _, err := marshalBlob(&broken)
+	require.NoError(t, err)
+	require.NoError(t, store.blobBucket.Set(keys[3], v))
+
+	require.Equal(t, map[MismatchType]int{
+		MismatchMissingMeta: 1,
+		MismatchMissingBlob: 1,
+		MismatchBrokenMeta:  1,
+		MismatchPayload:     1,
+	}, collectMismatches(t, ls))
+
+	t.Run("rebuild", func(t *testing.T) {
+		cnt, err := ls.(Inspector).RebuildMeta()
+		require.NoError(t, err)
+		require.Equal(t, 3, cnt)
+		require.Empty(t, collectMismatches(t, ls))
+
+		for _, i := range []int{0, 2} {
+			_, err := ls.Meta(*objs[i].Address())
+			require.NoError(t, err)
+		}
+
+		_, err = ls.Meta(*objs[1].Address())
+		require.Error(t, err)
+
+		require.Len(t, searchAddresses(t, ls,
+			IndexFilter{Attr: IndexUserHeader, Key: "Profession", Value: "Developer"}), 3)
+	})
+}
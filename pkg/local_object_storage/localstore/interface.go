@@ -45,11 +45,45 @@ type (
 		MetaBucket bucket.Bucket
 		Logger     *zap.Logger
 		Collector  metrics2.Collector
+
+		// JournalBucket keeps intent records of the pending writes.
+		// Writes are not journaled if it is nil.
+		JournalBucket bucket.Bucket
+
+		// IndexBucket keeps the secondary indexes used by Search.
+		// Indexes are not maintained if it is nil.
+		IndexBucket bucket.Bucket
+
+		// QuarantineBucket keeps the blobs of the corrupted objects
+		// removed from the storage. Objects can not be quarantined if it is nil.
+		QuarantineBucket bucket.Bucket
+
+		// KeyBucket keeps the data keys of the blob encryption
+		// wrapped by the master key. It is required if encryption is enabled.
+		KeyBucket bucket.Bucket
+
+		// SkipMigrations disables the format migrations on start,
+		// e.g. for the read-only access. Storage of the newer
+		// format version is not opened anyway.
+		SkipMigrations bool
+
+		Compression CompressionParams
+		Encryption  EncryptionParams
 	}
 
 	localstore struct {
-		metaBucket bucket.Bucket
-		blobBucket bucket.Bucket
+		metaBucket    bucket.Bucket
+		blobBucket    bucket.Bucket
+		journalBucket bucket.Bucket
+		indexBucket   bucket.Bucket
+
+		quarantineBucket bucket.Bucket
+
+		// nil if compression is disabled
+		compressor *compressor
+
+		// nil if encryption is disabled
+		encryptor *encryptor
 
 		log *zap.Logger
 		col metrics2.Collector
@@ -69,6 +103,14 @@ var errNilLogger = errors.New("logger is nil")
 var errNilCollector = errors.New("metrics collector is nil")
 
 // New is a local object storage constructor.
+//
+// New returns ErrUnknownFormat if the storage is written in the newer format
+// version and runs the format migrations unless they are skipped.
+// If encryption is enabled, New loads the data keys and re-wraps the ones
+// wrapped by the previous master keys. If journal bucket is set, New rolls
+// all pending writes found in the journal forward or back. If blob migration
+// is enabled, New converts the blobs of the legacy format. If index bucket
+// is set and empty, New builds the indexes of all stored objects.
 func New(p Params) (Localstore, error) {
 	switch {
 	case p.MetaBucket == nil:
@@ -81,19 +123,57 @@ func New(p Params) (Localstore, error) {
 		return nil, errNilCollector
 	}
 
-	return &localstore{
-		metaBucket: p.MetaBucket,
-		blobBucket: p.BlobBucket,
-		log:        p.Logger,
-		col:        p.Collector,
-	}, nil
+	l := &localstore{
+		metaBucket:    p.MetaBucket,
+		blobBucket:    p.BlobBucket,
+		journalBucket: p.JournalBucket,
+		indexBucket:   p.IndexBucket,
+
+		quarantineBucket: p.QuarantineBucket,
+
+		log: p.Logger,
+		col: p.Collector,
+	}
+
+	var err error
+	if l.compressor, err = newCompressor(p.Compression); err != nil {
+		return nil, errors.Wrap(err, "could not create compressor")
+	}
+
+	if l.encryptor, err = newEncryptor(p.Encryption, p.KeyBucket, l.log); err != nil {
+		return nil, errors.Wrap(err, "could not create encryptor")
+	}
+
+	if _, err := l.checkFormat(); err != nil {
+		return nil, err
+	}
+
+	if l.journalBucket != nil {
+		if err := l.recoverWrites(); err != nil {
+			return nil, errors.Wrap(err, "could not recover localstore writes")
+		}
+	}
+
+	if !p.SkipMigrations {
+		if _, err := l.Migrate(false); err != nil {
+			return nil, errors.Wrap(err, "could not migrate localstore format")
+		}
+	}
+
+	if l.indexBucket != nil {
+		if err := l.buildIndex(); err != nil {
+			return nil, errors.Wrap(err, "could not build localstore indexes")
+		}
+	}
+
+	return l, nil
 }
 
 func (l localstore) Size() int64 { return l.blobBucket.Size() }
 
 // TODO: implement less costly method of counting.
 func (l localstore) ObjectsCount() (uint64, error) {
-	items, err := l.metaBucket.List()
+	items, err := l.objectKeys()
 	if err != nil {
 		return 0, err
 	}
@@ -0,0 +1,287 @@
+package localstore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket"
+	metrics2 "github.com/nspcc-dev/neofs-node/pkg/services/metrics"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+type (
+	// journalOp is an enumeration of journaled localstore operations.
+	journalOp byte
+
+	// journalRecord is an intent record of the pending localstore write.
+	//
+	// Record is stored in journal bucket under the storage key of the object
+	// before the write starts and is removed after both blob and meta
+	// buckets are updated.
+	journalRecord struct {
+		op         journalOp
+		storeEpoch uint64
+
+		// checksum of the blob value being written,
+		// allows to detect torn blob writes
+		checksum [sha256.Size]byte
+	}
+)
+
+const (
+	_ journalOp = iota
+
+	// journalPut is a journalOp of the object storing.
+	journalPut
+
+	// journalDel is a journalOp of the object removal.
+	journalDel
+)
+
+const journalRecordSize = 1 + 8 + sha256.Size
+
+var errInvalidJournalRecord = errors.New("invalid journal record")
+
+func (r journalRecord) marshal() []byte {
+	data := make([]byte, journalRecordSize)
+
+	data[0] = byte(r.op)
+	binary.BigEndian.PutUint64(data[1:], r.storeEpoch)
+	copy(data[9:], r.checksum[:])
+
+	return data
+}
+
+func (r *journalRecord) unmarshal(data []byte) error {
+	if len(data) != journalRecordSize {
+		return errInvalidJournalRecord
+	}
+
+	switch op := journalOp(data[0]); op {
+	case journalPut, journalDel:
+		r.op = op
+	default:
+		return errInvalidJournalRecord
+	}
+
+	r.storeEpoch = binary.BigEndian.Uint64(data[1:])
+	copy(r.checksum[:], data[9:])
+
+	return nil
+}
+
+func isNotFound(err error) bool {
+	return errors.Is(errors.Cause(err), bucket.ErrNotFound)
+}
+
+// beginWrite saves intent record of the write to the journal.
+func (l *localstore) beginWrite(k []byte, rec journalRecord) error {
+	if l.journalBucket == nil {
+		return nil
+	}
+
+	return l.journalBucket.Set(k, rec.marshal())
+}
+
+// commitWrite removes intent record of the finished write from the journal.
+func (l *localstore) commitWrite(k []byte) error {
+	if l.journalBucket == nil {
+		return nil
+	}
+
+	if err := l.journalBucket.Del(k); err != nil && !isNotFound(err) {
+		return err
+	}
+
+	return nil
+}
+
+// abortWrite tries to bring the interrupted write to a consistent state
+// and reports whether the write was rolled forward.
+//
+// If it fails, the intent record remains in the journal and
+// the write is finished on the next start.
+func (l *localstore) abortWrite(k []byte, rec journalRecord) bool {
+	if l.journalBucket == nil {
+		return false
+	}
+
+	forward, err := l.rollWrite(k, rec)
+	if err != nil {
+		l.log.Warn("could not roll interrupted write, leave it to recovery",
+			zap.Error(err))
+		return false
+	}
+
+	if err := l.commitWrite(k); err != nil {
+		l.log.Warn("could not remove journal record of interrupted write",
+			zap.Error(err))
+	}
+
+	return forward
+}
+
+// recoverWrites scans the journal and rolls each pending write forward or back.
+func (l *localstore) recoverWrites() error {
+	keys, err := l.journalBucket.List()
+	if err != nil {
+		return errors.Wrap(err, "could not list journal records")
+	}
+
+	for i := range keys {
+		v, err := l.journalBucket.Get(keys[i])
+		if err != nil {
+			if isNotFound(err) {
+				continue
+			}
+
+			return errors.Wrap(err, "could not read journal record")
+		}
+
+		rec := journalRecord{}
+
+		// intent record that was not fully written
+		// guarantees that the write itself was not started
+		if err := rec.unmarshal(v); err != nil {
+			l.log.Warn("drop broken journal record", zap.Error(err))
+		} else if _, err := l.rollWrite(keys[i], rec); err != nil {
+			return errors.Wrap(err, "could not roll pending write")
+		}
+
+		if err := l.commitWrite(keys[i]); err != nil {
+			return errors.Wrap(err, "could not remove journal record")
+		}
+	}
+
+	if len(keys) > 0 {
+		l.log.Info("pending localstore writes recovered",
+			zap.Int("count", len(keys)))
+	}
+
+	return nil
+}
+
+// rollWrite rolls the pending write and reports whether it was rolled forward.
+func (l *localstore) rollWrite(k []byte, rec journalRecord) (bool, error) {
+	switch rec.op {
+	case journalPut:
+		return l.rollPut(k, rec)
+	case journalDel:
+		return true, l.rollDel(k)
+	default:
+		return false, errInvalidJournalRecord
+	}
+}
+
+// rollPut rolls the pending Put forward if the blob was completely written
+// and back otherwise.
+func (l *localstore) rollPut(k []byte, rec journalRecord) (bool, error) {
+	v, err := l.blobBucket.Get(k)
+	if err != nil && !isNotFound(err) {
+		return false, err
+	}
+
+	var obj *Object
+
+	if err == nil && sha256.Sum256(v) == rec.checksum {
+		// the complete blob must not be removed for the lack of the key
+		if obj, err = l.decodeBlob(v); errors.Is(err, ErrEncryptionDisabled) {
+			return false, err
+		}
+	}
+
+	if obj == nil || err != nil {
+		return false, l.delItem(k)
+	}
+
+	if mv, err := l.metaBucket.Get(k); err == nil {
+		if err := new(ObjectMeta).Unmarshal(mv); err == nil {
+			return true, l.addIndex(k, obj)
+		}
+	} else if !isNotFound(err) {
+		return false, err
+	}
+
+	ctx := context.WithValue(context.Background(), StoreEpochValue, rec.storeEpoch)
+
+	meta := metaFromObject(ctx, obj)
+
+	if meta.PayloadOffset, err = blobPayloadOffset(v); err != nil {
+		return false, err
+	}
+
+	if v, err = meta.Marshal(); err != nil {
+		return false, err
+	}
+
+	if err := l.metaBucket.Set(k, v); err != nil {
+		return false, err
+	}
+
+	l.col.UpdateContainer(
+		obj.SystemHeader.CID,
+		obj.SystemHeader.PayloadLength,
+		metrics2.AddSpace)
+
+	return true, l.addIndex(k, obj)
+}
+
+// rollDel always rolls the pending Del forward.
+func (l *localstore) rollDel(k []byte) error {
+	obj, err := l.readBlob(k)
+	if err != nil {
+		return err
+	}
+
+	if err := l.delItem(k); err != nil {
+		return err
+	}
+
+	if obj != nil {
+		l.col.UpdateContainer(
+			obj.SystemHeader.CID,
+			obj.SystemHeader.PayloadLength,
+			metrics2.RemSpace)
+	}
+
+	return nil
+}
+
+// readBlob returns the object from blob bucket.
+// Nil object is returned if blob is missing or broken.
+func (l *localstore) readBlob(k []byte) (*Object, error) {
+	v, err := l.blobBucket.Get(k)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	obj, err := l.decodeBlob(v)
+	if err != nil {
+		return nil, nil
+	}
+
+	return obj, nil
+}
+
+// delItem removes blob, meta and index items of the object.
+func (l *localstore) delItem(k []byte) error {
+	if err := l.dropIndex(k); err != nil {
+		return err
+	}
+
+	if err := l.metaBucket.Del(k); err != nil && !isNotFound(err) {
+		return err
+	}
+
+	if err := l.blobBucket.Del(k); err != nil && !isNotFound(err) {
+		return err
+	}
+
+	return nil
+}
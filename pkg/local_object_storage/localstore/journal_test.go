@@ -0,0 +1,262 @@
+package localstore
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"testing"
+
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket/test"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type (
+	// crashPoint counts modifying operations of the crashBucket group
+	// and simulates process crash on the specified one.
+	crashPoint struct {
+		ops, at int
+		crashed bool
+
+		// transient makes the operation fail once, the next ones succeed
+		transient bool
+	}
+
+	crashBucket struct {
+		bucket.Bucket
+		cp *crashPoint
+	}
+
+	crashBuckets struct {
+		blob, meta, journal bucket.Bucket
+	}
+)
+
+var errCrash = errors.New("crash")
+
+func (c *crashPoint) step() bool {
+	if c.crashed {
+		return !c.transient
+	}
+
+	c.ops++
+	c.crashed = c.ops > c.at
+
+	return c.crashed
+}
+
+func (b *crashBucket) Set(key, value []byte) error {
+	if b.cp.step() {
+		// simulate torn write
+		_ = b.Bucket.Set(key, value[:len(value)/2])
+		return errCrash
+	}
+
+	return b.Bucket.Set(key, value)
+}
+
+func (b *crashBucket) Del(key []byte) error {
+	if b.cp.step() {
+		return errCrash
+	}
+
+	return b.Bucket.Del(key)
+}
+
+func newCrashBuckets() *crashBuckets {
+	return &crashBuckets{
+		blob:    test.Bucket(),
+		meta:    test.Bucket(),
+		journal: test.Bucket(),
+	}
+}
+
+func (b *crashBuckets) localstore(t *testing.T, cp *crashPoint) Localstore {
+	p := Params{
+		BlobBucket:    b.blob,
+		MetaBucket:    b.meta,
+		JournalBucket: b.journal,
+		Logger:        zap.L(),
+		Collector:     newCollector(),
+	}
+
+	if cp != nil {
+		// write format version on the first start without crashes
+		b.localstore(t, nil)
+
+		p.BlobBucket = &crashBucket{Bucket: b.blob, cp: cp}
+		p.MetaBucket = &crashBucket{Bucket: b.meta, cp: cp}
+		p.JournalBucket = &crashBucket{Bucket: b.journal, cp: cp}
+	}
+
+	ls, err := New(p)
+	require.NoError(t, err)
+
+	return ls
+}
+
+// requireConsistent checks that the object is either completely stored or completely missing
+// and returns true in the first case.
+func (b *crashBuckets) requireConsistent(t *testing.T, ls Localstore, obj *Object) bool {
+	addr := *obj.Address()
+
+	k, err := addr.Hash()
+	require.NoError(t, err)
+
+	keys, err := b.journal.List()
+	require.NoError(t, err)
+	require.Empty(t, keys)
+
+	if !b.blob.Has(k) {
+		require.False(t, b.meta.Has(k))
+		return false
+	}
+
+	ok, err := ls.Has(addr)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	o, err := ls.Get(addr)
+	require.NoError(t, err)
+	require.Equal(t, obj, o)
+
+	meta, err := ls.Meta(addr)
+	require.NoError(t, err)
+	require.Equal(t, uint64(len(obj.Payload)), meta.PayloadSize)
+
+	return true
+}
+
+func TestJournalRecord(t *testing.T) {
+	rec := journalRecord{
+		op:         journalPut,
+		storeEpoch: 10,
+		checksum:   sha256.Sum256([]byte("Hello, world")),
+	}
+
+	res := journalRecord{}
+	require.NoError(t, res.unmarshal(rec.marshal()))
+	require.Equal(t, rec, res)
+
+	require.EqualError(t, res.unmarshal(rec.marshal()[1:]), errInvalidJournalRecord.Error())
+	require.EqualError(t, res.unmarshal(make([]byte, journalRecordSize)), errInvalidJournalRecord.Error())
+}
+
+func TestLocalstore_PutCrash(t *testing.T) {
+	obj := testObject(t)
+	obj.SetPayload([]byte("Hello, world"))
+
+	for at := 0; ; at++ {
+		var (
+			b  = newCrashBuckets()
+			cp = &crashPoint{at: at}
+		)
+
+		err := b.localstore(t, cp).Put(context.Background(), obj)
+
+		// restart localstore over the same buckets
+		stored := b.requireConsistent(t, b.localstore(t, nil), obj)
+
+		if err == nil {
+			require.True(t, stored)
+		}
+
+		if !cp.crashed {
+			require.NoError(t, err)
+			break
+		}
+	}
+}
+
+func TestLocalstore_PutFailure(t *testing.T) {
+	obj := testObject(t)
+	obj.SetPayload([]byte("Hello, world"))
+
+	for at := 0; ; at++ {
+		var (
+			b  = newCrashBuckets()
+			cp = &crashPoint{at: at, transient: true}
+			ls = b.localstore(t, cp)
+		)
+
+		err := ls.Put(context.Background(), obj)
+
+		// failed Put must not leave the object stored and vice versa
+		ok, hErr := ls.Has(*obj.Address())
+		require.NoError(t, hErr)
+		require.Equal(t, err == nil, ok)
+
+		if ok {
+			o, err := ls.Get(*obj.Address())
+			require.NoError(t, err)
+			require.Equal(t, obj, o)
+		}
+
+		if !cp.crashed {
+			require.NoError(t, err)
+			break
+		}
+	}
+}
+
+func TestLocalstore_DelCrash(t *testing.T) {
+	obj := testObject(t)
+	obj.SetPayload([]byte("Hello, world"))
+
+	for at := 0; ; at++ {
+		var (
+			b  = newCrashBuckets()
+			cp = &crashPoint{at: at}
+		)
+
+		require.NoError(t, b.localstore(t, nil).Put(context.Background(), obj))
+
+		err := b.localstore(t, cp).Del(*obj.Address())
+
+		// restart localstore over the same buckets
+		stored := b.requireConsistent(t, b.localstore(t, nil), obj)
+
+		if err == nil {
+			require.False(t, stored)
+		}
+
+		if !cp.crashed {
+			require.NoError(t, err)
+			break
+		}
+	}
+}
+
+func TestLocalstore_DelFailure(t *testing.T) {
+	obj := testObject(t)
+	obj.SetPayload([]byte("Hello, world"))
+
+	for at := 0; ; at++ {
+		var (
+			b  = newCrashBuckets()
+			cp = &crashPoint{at: at, transient: true}
+		)
+
+		require.NoError(t, b.localstore(t, nil).Put(context.Background(), obj))
+
+		ls := b.localstore(t, cp)
+		err := ls.Del(*obj.Address())
+
+		// failed Del must leave the object stored and vice versa
+		ok, hErr := ls.Has(*obj.Address())
+		require.NoError(t, hErr)
+		require.Equal(t, err != nil, ok)
+
+		if ok {
+			o, err := ls.Get(*obj.Address())
+			require.NoError(t, err)
+			require.Equal(t, obj, o)
+		}
+
+		if !cp.crashed {
+			require.NoError(t, err)
+			break
+		}
+	}
+}
@@ -16,7 +16,11 @@ func (l *localstore) Iterate(filter FilterPipeline, handler MetaHandler) error {
 		})
 	}
 
-	return l.metaBucket.Iterate(func(_, v []byte) bool {
+	return l.metaBucket.Iterate(func(k, v []byte) bool {
+		if isFormatKey(k) {
+			return true
+		}
+
 		meta := new(ObjectMeta)
 		if err := meta.Unmarshal(v); err != nil {
 			l.log.Error("unmarshal meta bucket item failure", zap.Error(err))
@@ -2,17 +2,20 @@ package localstore
 
 import (
 	"context"
+	"crypto/sha256"
 
 	"github.com/nspcc-dev/neofs-api-go/refs"
 	metrics2 "github.com/nspcc-dev/neofs-node/pkg/services/metrics"
 	"github.com/pkg/errors"
+	"go.uber.org/zap"
 )
 
 func (l *localstore) Put(ctx context.Context, obj *Object) error {
 	var (
-		oa   refs.Address
-		k, v []byte
-		err  error
+		oa       refs.Address
+		k, v, mv []byte
+		off      uint64
+		err      error
 	)
 
 	oa = *obj.Address()
@@ -22,22 +25,55 @@ func (l *localstore) Put(ctx context.Context, obj *Object) error {
 		return errors.Wrap(err, "Localstore Put failed on StorageKey.marshal")
 	}
 
-	if v, err = obj.Marshal(); err != nil {
+	if v, off, err = l.blobValue(obj); err != nil {
 		return errors.Wrap(err, "Localstore Put failed on blobValue")
 	}
 
+	meta := metaFromObject(ctx, obj)
+	meta.PayloadOffset = off
+
+	if mv, err = meta.Marshal(); err != nil {
+		return errors.Wrap(err, "Localstore Put failed on metaValue")
+	}
+
+	rec := journalRecord{
+		op:         journalPut,
+		storeEpoch: meta.StoreEpoch,
+		checksum:   sha256.Sum256(v),
+	}
+
+	if err = l.beginWrite(k, rec); err != nil {
+		return errors.Wrap(err, "Localstore Put failed on journal write")
+	}
+
 	if err = l.blobBucket.Set(k, v); err != nil {
+		// the write rolled forward stores the object completely
+		// and updates the metrics, so it is not a failure
+		if l.abortWrite(k, rec) {
+			return nil
+		}
+
 		return errors.Wrap(err, "Localstore Put failed on BlobBucket.Set")
 	}
 
-	if v, err = metaFromObject(ctx, obj).Marshal(); err != nil {
-		return errors.Wrap(err, "Localstore Put failed on metaValue")
-	}
+	if err = l.metaBucket.Set(k, mv); err != nil {
+		if l.abortWrite(k, rec) {
+			return nil
+		}
 
-	if err = l.metaBucket.Set(k, v); err != nil {
 		return errors.Wrap(err, "Localstore Put failed on MetaBucket.Set")
 	}
 
+	if err = l.addIndex(k, obj); err != nil {
+		// the object is already stored, indexes are best-effort
+		l.log.Warn("Localstore Put failed on index update", zap.Error(err))
+		l.resetIndex()
+	}
+
+	if err = l.commitWrite(k); err != nil {
+		l.log.Warn("Localstore Put failed on journal commit", zap.Error(err))
+	}
+
 	l.col.UpdateContainer(
 		obj.SystemHeader.CID,
 		obj.SystemHeader.PayloadLength,
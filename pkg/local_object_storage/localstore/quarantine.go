@@ -0,0 +1,60 @@
+package localstore
+
+import (
+	"github.com/pkg/errors"
+)
+
+// Quarantiner is an interface of local object storage
+// that isolates the corrupted objects.
+type Quarantiner interface {
+	// Quarantine removes the object from the storage and keeps
+	// its blob in the quarantine for the investigation.
+	Quarantine(Address) error
+
+	// Quarantined checks whether the object is in the quarantine.
+	Quarantined(Address) (bool, error)
+}
+
+// ErrQuarantineDisabled is returned by Quarantiner methods if
+// localstore does not have the quarantine bucket.
+var ErrQuarantineDisabled = errors.New("localstore quarantine is disabled")
+
+// Quarantine copies the raw blob of the object to the quarantine
+// bucket and removes the object from the storage.
+//
+// Blob is copied as is, so it can be examined even if it can not be decoded.
+func (l *localstore) Quarantine(key Address) error {
+	if l.quarantineBucket == nil {
+		return ErrQuarantineDisabled
+	}
+
+	k, err := key.Hash()
+	if err != nil {
+		return errors.Wrap(err, "Localstore Quarantine failed on key.Marshal")
+	}
+
+	v, err := l.blobBucket.Get(k)
+	if err == nil {
+		if err := l.quarantineBucket.Set(k, v); err != nil {
+			return errors.Wrap(err, "Localstore Quarantine failed on QuarantineBucket.Set")
+		}
+	} else if !isNotFound(err) {
+		return errors.Wrap(err, "Localstore Quarantine failed on BlobBucket.Get")
+	}
+
+	return l.Del(key)
+}
+
+// Quarantined checks whether the object blob is in the quarantine bucket.
+func (l *localstore) Quarantined(key Address) (bool, error) {
+	if l.quarantineBucket == nil {
+		return false, nil
+	}
+
+	k, err := key.Hash()
+	if err != nil {
+		return false, errors.Wrap(err, "Localstore Quarantined failed on key.Marshal")
+	}
+
+	return l.quarantineBucket.Has(k), nil
+}
@@ -0,0 +1,59 @@
+package localstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket/test"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestLocalstore_Quarantine(t *testing.T) {
+	quarantine := test.Bucket()
+
+	ls, err := New(Params{
+		BlobBucket:       test.Bucket(),
+		MetaBucket:       test.Bucket(),
+		QuarantineBucket: quarantine,
+		Logger:           zap.L(),
+		Collector:        newCollector(),
+	})
+	require.NoError(t, err)
+
+	obj := testObject(t)
+	obj.SetPayload([]byte("Hello, world"))
+
+	require.NoError(t, ls.Put(context.Background(), obj))
+
+	addr := *obj.Address()
+	k, err := addr.Hash()
+	require.NoError(t, err)
+
+	blob, err := ls.(*localstore).blobBucket.Get(k)
+	require.NoError(t, err)
+
+	q := ls.(Quarantiner)
+
+	ok, err := q.Quarantined(addr)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	require.NoError(t, q.Quarantine(addr))
+
+	ok, err = ls.Has(addr)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	ok, err = q.Quarantined(addr)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	v, err := quarantine.Get(k)
+	require.NoError(t, err)
+	require.Equal(t, blob, v)
+
+	t.Run("disabled", func(t *testing.T) {
+		require.Equal(t, ErrQuarantineDisabled, newLocalstore(t).(Quarantiner).Quarantine(addr))
+	})
+}
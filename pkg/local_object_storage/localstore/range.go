@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/nspcc-dev/neofs-api-go/object"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket"
 	"github.com/pkg/errors"
 )
 
@@ -11,7 +12,7 @@ func (l *localstore) PRead(ctx context.Context, key Address, rng object.Range) (
 	var (
 		err  error
 		k, v []byte
-		obj  Object
+		obj  *Object
 	)
 
 	k, err = key.Hash()
@@ -19,18 +20,53 @@ func (l *localstore) PRead(ctx context.Context, key Address, rng object.Range) (
 		return nil, errors.Wrap(err, "Localstore Get failed on key.Marshal")
 	}
 
+	meta, err := l.Meta(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "Localstore Get failed on Meta")
+	}
+
+	if outOfRange(rng, meta.PayloadSize) {
+		return nil, ErrOutOfRange
+	}
+
+	// seek straight to the requested payload bytes if the blob
+	// keeps the payload separately and the bucket allows it
+	if rr, ok := l.blobBucket.(bucket.RangeReader); ok && meta.PayloadOffset > 0 {
+		v, err = rr.GetRange(k, meta.PayloadOffset+rng.Offset, rng.Length)
+		if err != nil {
+			return nil, errors.Wrap(err, "Localstore Get failed on blobBucket.GetRange")
+		}
+
+		return v, nil
+	}
+
+	// decrypt only the chunks with the requested payload bytes
+	if rr, ok := l.blobBucket.(bucket.RangeReader); ok && l.encryptor != nil {
+		v, err = l.encryptor.readPayloadRange(rr, k, rng.Offset, rng.Length)
+		if err == nil {
+			return v, nil
+		} else if !errors.Is(err, errNoEncryptedPayloadRead) {
+			return nil, errors.Wrap(err, "Localstore Get failed on encrypted blob range read")
+		}
+	}
+
 	v, err = l.blobBucket.Get(k)
 	if err != nil {
 		return nil, errors.Wrap(err, "Localstore Get failed on blobBucket.Get")
 	}
 
-	if err := obj.Unmarshal(v); err != nil {
+	if obj, err = l.decodeBlob(v); err != nil {
 		return nil, errors.Wrap(err, "Localstore Get failed on object.Unmarshal")
 	}
 
-	if rng.Offset+rng.Length > uint64(len(obj.Payload)) {
+	if outOfRange(rng, uint64(len(obj.Payload))) {
 		return nil, ErrOutOfRange
 	}
 
 	return obj.Payload[rng.Offset : rng.Offset+rng.Length], nil
 }
+
+// outOfRange checks if the range exceeds the payload of the size.
+func outOfRange(rng object.Range, size uint64) bool {
+	return rng.Offset > size || rng.Length > size-rng.Offset
+}
@@ -0,0 +1,38 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket/test"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/localstore"
+	"github.com/nspcc-dev/neofs-node/pkg/services/metrics"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// Localstore constructs Localstore over the in-memory buckets for the tests.
+//
+// Blob and meta buckets, logger and metrics collector are set
+// if they are missing in p, the other buckets are used as is.
+func Localstore(t testing.TB, p localstore.Params) localstore.Localstore {
+	if p.BlobBucket == nil {
+		p.BlobBucket = test.Bucket()
+	}
+
+	if p.MetaBucket == nil {
+		p.MetaBucket = test.Bucket()
+	}
+
+	if p.Logger == nil {
+		p.Logger = zap.L()
+	}
+
+	if p.Collector == nil {
+		p.Collector = metrics.NopCollector{}
+	}
+
+	ls, err := localstore.New(p)
+	require.NoError(t, err)
+
+	return ls
+}
@@ -12,6 +12,8 @@ type PeerInfo struct {
 	key []byte // peer public key
 
 	opts [][]byte // binary peer options
+
+	state int64 // peer state, zero if not set
 }
 
 // AddPeerArgs groups the arguments
@@ -76,6 +78,17 @@ func (a *PeerInfo) SetOptions(v [][]byte) {
 	a.opts = v
 }
 
+// State returns the peer state set by update state
+// method, zero is returned if it is not set.
+func (a PeerInfo) State() int64 {
+	return a.state
+}
+
+// SetState sets the peer state.
+func (a *PeerInfo) SetState(v int64) {
+	a.state = v
+}
+
 // SetInfo sets the peer information.
 func (a *AddPeerArgs) SetInfo(v PeerInfo) {
 	a.info = v
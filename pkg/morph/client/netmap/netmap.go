@@ -19,6 +19,10 @@ type GetNetMapValues struct {
 
 const nodeInfoFixedPrmNumber = 3
 
+// peer state is an optional item that
+// follows the fixed node info items
+const nodeInfoStatePrmNumber = nodeInfoFixedPrmNumber + 1
+
 // Peers return the list of peers from
 // network map in a binary format.
 func (g GetNetMapValues) Peers() []PeerInfo {
@@ -62,12 +66,20 @@ func peerInfoFromStackItem(prm smartcontract.Parameter) (*PeerInfo, error) {
 	prms, err := client.ArrayFromStackParameter(prm)
 	if err != nil {
 		return nil, errors.Wrapf(err, "could not get stack item array (PeerInfo)")
-	} else if ln := len(prms); ln != nodeInfoFixedPrmNumber {
-		return nil, errors.Errorf("unexpected stack item count (PeerInfo): expected %d, has %d", 3, ln)
+	} else if ln := len(prms); ln != nodeInfoFixedPrmNumber && ln != nodeInfoStatePrmNumber {
+		return nil, errors.Errorf("unexpected stack item count (PeerInfo): expected %d or %d, has %d",
+			nodeInfoFixedPrmNumber, nodeInfoStatePrmNumber, ln)
 	}
 
 	res := new(PeerInfo)
 
+	// State
+	if len(prms) == nodeInfoStatePrmNumber {
+		if res.state, err = client.IntFromStackParameter(prms[nodeInfoFixedPrmNumber]); err != nil {
+			return nil, errors.Wrap(err, "could not get integer from stack item (State)")
+		}
+	}
+
 	// Address
 	res.address, err = client.BytesFromStackParameter(prms[0])
 	if err != nil {
@@ -27,7 +27,7 @@ func (u *UpdateStateArgs) SetState(v int64) {
 // of NeoFS Netmap contract.
 func (c *Client) UpdateState(args UpdateStateArgs) error {
 	return errors.Wrapf(c.client.Invoke(
-		c.addPeerMethod,
+		c.updateStateMethod,
 		args.key,
 		args.state,
 	), "could not invoke method (%s)", c.updateStateMethod)
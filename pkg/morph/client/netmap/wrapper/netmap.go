@@ -51,6 +51,12 @@ func (w *Wrapper) GetNetMap() (*NetMap, error) {
 
 		info.SetOptions(opts)
 
+		if NodeState(peerList[i].State()) == StateFull {
+			st := info.Status()
+			st.SetFull()
+			info.SetStatus(st)
+		}
+
 		if err := nm.AddNode(info); err != nil {
 			return nil, errors.Wrapf(err, "could not add node #%d to network map", i)
 		}
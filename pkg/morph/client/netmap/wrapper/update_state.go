@@ -13,6 +13,14 @@ const (
 
 	// StateOffline is an offline node state value.
 	StateOffline
+
+	// StateFull is a state value of the online node
+	// that does not accept new objects.
+	StateFull
+
+	// StateOnline is a state value of the online node
+	// that accepts new objects.
+	StateOnline
 )
 
 // UpdatePeerState changes peer status through Netmap contract
@@ -0,0 +1,185 @@
+package bootstrap
+
+import (
+	"context"
+	"sync"
+
+	"github.com/nspcc-dev/neofs-node/pkg/core/netmap/node"
+	"github.com/nspcc-dev/neofs-node/pkg/morph/client/netmap/wrapper"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+type (
+	// CapacityMeter is an interface of the entity
+	// that reports the available share of the node storage.
+	CapacityMeter interface {
+		// RelativeAvailableCap returns the available share
+		// of the storage capacity in [0, 1] range.
+		RelativeAvailableCap() float64
+	}
+
+	// StateUpdater is an interface of the entity
+	// that changes the node state in NeoFS network map.
+	//
+	// It is implemented by
+	// github.com/nspcc-dev/neofs-node/pkg/morph/client/netmap/wrapper.Wrapper.
+	StateUpdater interface {
+		UpdatePeerState(key []byte, state wrapper.NodeState) error
+	}
+
+	// LocalStatus is an interface of the entity that keeps
+	// the status of the local node until the next network map.
+	//
+	// It is implemented by
+	// github.com/nspcc-dev/neofs-node/pkg/services/object_manager/placement.Component.
+	LocalStatus interface {
+		SetLocalStatus(node.Status) error
+	}
+
+	// CapacityWatcherParams groups the parameters
+	// of the capacity watcher constructor.
+	CapacityWatcherParams struct {
+		Meter  CapacityMeter
+		Client StateUpdater
+		Local  LocalStatus
+
+		// Key is a binary public key of the storage node.
+		Key []byte
+
+		Logger *zap.Logger
+
+		// HighWatermark is a used share of the storage
+		// at which the node becomes full.
+		HighWatermark float64
+
+		// LowWatermark is a used share of the storage
+		// at which the full node accepts new objects again.
+		LowWatermark float64
+	}
+
+	// CapacityWatcher marks the storage node full in NeoFS network map
+	// when the storage usage reaches the high watermark and online
+	// when it drops to the low watermark.
+	//
+	// Working CapacityWatcher must be created via constructor NewCapacityWatcher.
+	CapacityWatcher struct {
+		meter  CapacityMeter
+		client StateUpdater
+		local  LocalStatus
+		key    []byte
+		log    *zap.Logger
+
+		high, low float64
+
+		mtx    sync.Mutex
+		status node.Status
+
+		// status is reported at least once after the start
+		synced bool
+	}
+)
+
+var (
+	errNilCapacityMeter = errors.New("capacity meter is nil")
+	errNilStateUpdater  = errors.New("state updater is nil")
+	errNilLocalStatus   = errors.New("local status keeper is nil")
+	errEmptyNodeKey     = errors.New("node public key is empty")
+	errNilLogger        = errors.New("logger is nil")
+	errInvalidWatermark = errors.New("watermarks must satisfy 0 < low <= high <= 1")
+)
+
+// NewCapacityWatcher creates, initializes and returns the CapacityWatcher instance.
+func NewCapacityWatcher(p CapacityWatcherParams) (*CapacityWatcher, error) {
+	switch {
+	case p.Meter == nil:
+		return nil, errNilCapacityMeter
+	case p.Client == nil:
+		return nil, errNilStateUpdater
+	case p.Local == nil:
+		return nil, errNilLocalStatus
+	case len(p.Key) == 0:
+		return nil, errEmptyNodeKey
+	case p.Logger == nil:
+		return nil, errNilLogger
+	case p.LowWatermark <= 0, p.LowWatermark > p.HighWatermark, p.HighWatermark > 1:
+		return nil, errInvalidWatermark
+	}
+
+	return &CapacityWatcher{
+		meter:  p.Meter,
+		client: p.Client,
+		local:  p.Local,
+		key:    p.Key,
+		log:    p.Logger,
+		high:   p.HighWatermark,
+		low:    p.LowWatermark,
+	}, nil
+}
+
+// Check compares the storage usage with the watermarks and
+// updates the node state through the contract client if
+// the watermark has been crossed.
+//
+// Status in the network map is unknown after the start, so the
+// first check reports the state unconditionally: the node is full
+// if the usage reaches the high watermark. Updated status is set
+// to the local node, so it is applied before the next network map.
+//
+// If contract client returns error, the node status
+// is left unchanged and the update is retried on the next check.
+func (w *CapacityWatcher) Check(context.Context) {
+	used := 1 - w.meter.RelativeAvailableCap()
+
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	var (
+		state  wrapper.NodeState
+		status = w.status
+	)
+
+	switch full := w.status.Full(); {
+	case !w.synced && used >= w.high:
+		state = wrapper.StateFull
+		status.SetFull()
+	case !w.synced:
+		state = wrapper.StateOnline
+		status.ResetFull()
+	case !full && used >= w.high:
+		state = wrapper.StateFull
+		status.SetFull()
+	case full && used <= w.low:
+		state = wrapper.StateOnline
+		status.ResetFull()
+	default:
+		return
+	}
+
+	if err := w.client.UpdatePeerState(w.key, state); err != nil {
+		w.log.Error("could not update node state",
+			zap.Float64("used", used),
+			zap.Error(err))
+
+		return
+	}
+
+	w.status, w.synced = status, true
+
+	if err := w.local.SetLocalStatus(status); err != nil {
+		w.log.Warn("could not update local node status",
+			zap.Error(err))
+	}
+
+	w.log.Info("node state updated",
+		zap.Float64("used", used),
+		zap.Bool("full", status.Full()))
+}
+
+// Status returns the node status set by the last successful update.
+func (w *CapacityWatcher) Status() node.Status {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	return w.status
+}
@@ -0,0 +1,149 @@
+package bootstrap
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nspcc-dev/neofs-node/pkg/core/netmap/node"
+	"github.com/nspcc-dev/neofs-node/pkg/morph/client/netmap/wrapper"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type (
+	testMeter struct {
+		avail float64
+	}
+
+	testUpdater struct {
+		err    error
+		states []wrapper.NodeState
+	}
+
+	testLocalStatus struct {
+		status node.Status
+	}
+)
+
+func (m *testMeter) RelativeAvailableCap() float64 {
+	return m.avail
+}
+
+func (u *testUpdater) UpdatePeerState(_ []byte, state wrapper.NodeState) error {
+	if u.err != nil {
+		return u.err
+	}
+
+	u.states = append(u.states, state)
+
+	return nil
+}
+
+func (l *testLocalStatus) SetLocalStatus(status node.Status) error {
+	l.status = status
+	return nil
+}
+
+func TestNewCapacityWatcher(t *testing.T) {
+	p := CapacityWatcherParams{
+		Meter:         new(testMeter),
+		Client:        new(testUpdater),
+		Local:         new(testLocalStatus),
+		Key:           []byte{1},
+		Logger:        zap.L(),
+		HighWatermark: 0.9,
+		LowWatermark:  0.95,
+	}
+
+	_, err := NewCapacityWatcher(p)
+	require.EqualError(t, err, errInvalidWatermark.Error())
+
+	p.HighWatermark, p.LowWatermark = 0.95, 0.9
+
+	_, err = NewCapacityWatcher(p)
+	require.NoError(t, err)
+}
+
+func TestCapacityWatcher_Check(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		meter   = &testMeter{avail: 0.5}
+		updater = new(testUpdater)
+		local   = new(testLocalStatus)
+	)
+
+	w, err := NewCapacityWatcher(CapacityWatcherParams{
+		Meter:         meter,
+		Client:        updater,
+		Local:         local,
+		Key:           []byte{1},
+		Logger:        zap.L(),
+		HighWatermark: 0.95,
+		LowWatermark:  0.9,
+	})
+	require.NoError(t, err)
+
+	// the first check reports the state unconditionally
+	w.Check(ctx)
+	require.False(t, w.Status().Full())
+	require.Equal(t, []wrapper.NodeState{wrapper.StateOnline}, updater.states)
+
+	w.Check(ctx)
+	require.Len(t, updater.states, 1)
+
+	// failed update is retried on the next check
+	meter.avail = 0.04
+	updater.err = errors.New("contract failure")
+
+	w.Check(ctx)
+	require.False(t, w.Status().Full())
+	require.False(t, local.status.Full())
+
+	updater.err = nil
+
+	w.Check(ctx)
+	require.True(t, w.Status().Full())
+	require.True(t, local.status.Full())
+	require.Equal(t, []wrapper.NodeState{wrapper.StateOnline, wrapper.StateFull}, updater.states)
+
+	// between watermarks the status is kept
+	meter.avail = 0.07
+
+	w.Check(ctx)
+	require.True(t, w.Status().Full())
+	require.Len(t, updater.states, 2)
+
+	meter.avail = 0.2
+
+	w.Check(ctx)
+	require.False(t, w.Status().Full())
+	require.False(t, local.status.Full())
+	require.Equal(t, []wrapper.NodeState{wrapper.StateOnline, wrapper.StateFull, wrapper.StateOnline}, updater.states)
+}
+
+func TestCapacityWatcher_CheckAfterRestart(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		meter   = &testMeter{avail: 0.01}
+		updater = new(testUpdater)
+		local   = new(testLocalStatus)
+	)
+
+	w, err := NewCapacityWatcher(CapacityWatcherParams{
+		Meter:         meter,
+		Client:        updater,
+		Local:         local,
+		Key:           []byte{1},
+		Logger:        zap.L(),
+		HighWatermark: 0.95,
+		LowWatermark:  0.9,
+	})
+	require.NoError(t, err)
+
+	// full status of the node stored before the start is unknown
+	w.Check(ctx)
+	require.True(t, w.Status().Full())
+	require.True(t, local.status.Full())
+	require.Equal(t, []wrapper.NodeState{wrapper.StateFull}, updater.states)
+}
@@ -0,0 +1,180 @@
+package object
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"github.com/nspcc-dev/neofs-api-go/object"
+	"github.com/nspcc-dev/neofs-api-go/refs"
+	"github.com/nspcc-dev/neofs-api-go/service"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/multipart"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/transport"
+	"github.com/pkg/errors"
+)
+
+type (
+	// objectACLChecker is an interface of the extended ACL checker
+	// of the request on the object headers.
+	objectACLChecker interface {
+		checkObject(context.Context, serviceRequest, *Object) error
+	}
+
+	// copyingObjectStorer handles the Put requests that copy the object
+	// from the other container.
+	//
+	// Source object is received from the source container nodes and
+	// assembled from the children, then it is stored through the
+	// transformer into the container of the request object.
+	copyingObjectStorer struct {
+		objRecv   objectReceiver
+		objStorer objectStorer
+
+		// checks Get permission on the source container,
+		// nil if basic ACL is not checked
+		aclPreProc requestPreProcessor
+
+		// checks Get and Put permissions on the object headers
+		aclChecker objectACLChecker
+	}
+)
+
+// CopySourceHeader is a Put request extended header key of the source
+// object address. Request object of the copy request is a template of
+// the copied object: it sets the container, the owner and the optional
+// object ID, its headers are added to the user headers of the source.
+// Payload of the request is ignored.
+const CopySourceHeader = "COPY_FROM"
+
+var (
+	errInvalidCopySource  = errors.New("invalid copy source address")
+	errCopySourceNotFound = errors.New("copy source object not found")
+	errMultipartCopy      = errors.New("copy within multipart upload")
+)
+
+func (s *copyingObjectStorer) putObject(ctx context.Context, info transport.PutInfo) (*Address, error) {
+	src, ok, err := copySource(info.ExtendedHeaders())
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return s.objStorer.putObject(ctx, info)
+	}
+
+	if req, err := multipart.ParseRequest(info.ExtendedHeaders()); err != nil {
+		return nil, err
+	} else if req.Action != multipart.ActionNone {
+		return nil, errMultipartCopy
+	}
+
+	getInfo := newRawGetInfo()
+	getInfo.setTimeout(info.GetTimeout())
+	getInfo.setTTL(service.SingleForwardingTTL)
+	getInfo.setAddress(src)
+	getInfo.setSessionToken(info.GetSessionToken())
+	getInfo.setBearerToken(info.GetBearerToken())
+	getInfo.setExtendedHeaders(info.ExtendedHeaders())
+
+	getReq := copySourceRequest(getInfo)
+
+	if s.aclPreProc != nil {
+		if err := s.aclPreProc.preProcess(ctx, getReq); err != nil {
+			return nil, err
+		}
+	}
+
+	obj, err := s.objRecv.getObject(ctx, getInfo)
+	if err != nil {
+		switch errors.Cause(err) {
+		case errIncompleteOperation, childrenNotFound:
+			err = errCopySourceNotFound
+		}
+
+		return nil, err
+	} else if err := s.aclChecker.checkObject(ctx, getReq, obj.Object); err != nil {
+		return nil, err
+	}
+
+	head := copyHead(info.GetHead(), obj.Object)
+
+	if req, ok := info.(serviceRequest); ok {
+		if err := s.aclChecker.checkObject(ctx, req, head); err != nil {
+			return nil, err
+		}
+	}
+
+	payload := io.Reader(bytes.NewReader(obj.Payload))
+	if obj.payload != nil {
+		payload = io.MultiReader(payload, obj.payload)
+	}
+
+	putInfo := newRawPutInfo()
+	putInfo.setHead(head)
+	putInfo.setPayload(payload)
+	putInfo.setTimeout(info.GetTimeout())
+	putInfo.setTTL(info.GetTTL())
+	putInfo.setCopiesNumber(info.CopiesNumber())
+	putInfo.setSessionToken(info.GetSessionToken())
+	putInfo.setBearerToken(info.GetBearerToken())
+	putInfo.setExtendedHeaders(info.ExtendedHeaders())
+
+	return s.objStorer.putObject(ctx, putInfo)
+}
+
+// copySource returns the source address of the copy request.
+func copySource(hs []service.ExtendedHeader) (Address, bool, error) {
+	for i := range hs {
+		if hs[i] == nil || hs[i].Key() != CopySourceHeader {
+			continue
+		}
+
+		addr, err := refs.ParseAddress(hs[i].Value())
+		if err != nil {
+			return Address{}, false, errors.Wrap(errInvalidCopySource, err.Error())
+		}
+
+		return addr, true, nil
+	}
+
+	return Address{}, false, nil
+}
+
+// copySourceRequest returns the Get request of the source object
+// on behalf of the copy request sender.
+func copySourceRequest(info transport.GetInfo) serviceRequest {
+	req := prepareGetRequest(info)
+
+	req.SetTTL(info.GetTTL())
+	req.SetToken(toTokenMessage(info.GetSessionToken()))
+	req.SetBearer(toBearerMessage(info.GetBearerToken()))
+	req.SetHeaders(toExtendedHeaderMessages(info.ExtendedHeaders()))
+
+	return req
+}
+
+// copyHead returns the header of the copied object.
+//
+// Copied object has the user headers of the source object
+// followed by the headers of the template, other headers
+// are set by the transformer.
+func copyHead(template, src *Object) *Object {
+	res := &Object{
+		SystemHeader: object.SystemHeader{
+			Version:       template.SystemHeader.Version,
+			ID:            template.SystemHeader.ID,
+			CID:           template.SystemHeader.CID,
+			OwnerID:       template.SystemHeader.OwnerID,
+			PayloadLength: src.SystemHeader.PayloadLength,
+		},
+		Headers: make([]object.Header, 0, len(src.Headers)+len(template.Headers)),
+	}
+
+	for i := range src.Headers {
+		if _, ok := src.Headers[i].Value.(*object.Header_UserHeader); ok {
+			res.Headers = append(res.Headers, src.Headers[i])
+		}
+	}
+
+	res.Headers = append(res.Headers, template.Headers...)
+
+	return res
+}
@@ -0,0 +1,216 @@
+package object
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"testing"
+
+	"github.com/nspcc-dev/neofs-api-go/object"
+	"github.com/nspcc-dev/neofs-api-go/service"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/multipart"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/transport"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// testCopyACLChecker denies the requests of the listed types.
+type testCopyACLChecker map[object.RequestType]bool
+
+func (s testCopyACLChecker) checkObject(_ context.Context, req serviceRequest, _ *Object) error {
+	if s[req.Type()] {
+		return errAccessDenied
+	}
+
+	return nil
+}
+
+func testUserHeader(key, value string) object.Header {
+	return object.Header{Value: &object.Header_UserHeader{UserHeader: &object.UserHeader{
+		Key:   key,
+		Value: value,
+	}}}
+}
+
+func Test_copySource(t *testing.T) {
+	addr := testObjectAddress(t)
+
+	_, ok, err := copySource(testMultipartHeaders("KEY", "VALUE"))
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	res, ok, err := copySource(testMultipartHeaders(CopySourceHeader, addr.String()))
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, addr, res)
+
+	_, _, err = copySource(testMultipartHeaders(CopySourceHeader, "address"))
+	require.True(t, errors.Is(errors.Cause(err), errInvalidCopySource))
+}
+
+func Test_copyHead(t *testing.T) {
+	var (
+		srcAddr = testObjectAddress(t)
+		dstAddr = testObjectAddress(t)
+	)
+
+	src := &Object{
+		SystemHeader: object.SystemHeader{
+			ID:            srcAddr.ObjectID,
+			CID:           srcAddr.CID,
+			OwnerID:       OwnerID{1},
+			PayloadLength: 10,
+		},
+		Headers: []object.Header{
+			testUserHeader("Name", "source"),
+			{Value: &object.Header_PayloadChecksum{PayloadChecksum: testData(t, 32)}},
+			{Value: &object.Header_Integrity{Integrity: new(object.IntegrityHeader)}},
+		},
+	}
+
+	template := &Object{
+		SystemHeader: object.SystemHeader{
+			Version: 1,
+			CID:     dstAddr.CID,
+			OwnerID: OwnerID{2},
+		},
+		Headers: []object.Header{
+			testUserHeader("Copy", "true"),
+		},
+	}
+
+	require.Equal(t, &Object{
+		SystemHeader: object.SystemHeader{
+			Version:       1,
+			CID:           dstAddr.CID,
+			OwnerID:       OwnerID{2},
+			PayloadLength: 10,
+		},
+		Headers: []object.Header{
+			testUserHeader("Name", "source"),
+			testUserHeader("Copy", "true"),
+		},
+	}, copyHead(template, src))
+}
+
+func Test_copyingObjectStorer(t *testing.T) {
+	var (
+		ctx     = context.TODO()
+		srcAddr = testObjectAddress(t)
+		dstAddr = testObjectAddress(t)
+		payload = testData(t, 10)
+	)
+
+	src := &objectData{
+		Object: &Object{
+			SystemHeader: object.SystemHeader{
+				ID:            srcAddr.ObjectID,
+				CID:           srcAddr.CID,
+				PayloadLength: uint64(len(payload)),
+			},
+			Headers: []object.Header{testUserHeader("Name", "source")},
+			Payload: payload[:4],
+		},
+		payload: bytes.NewReader(payload[4:]),
+	}
+
+	putInfo := func(kv ...string) *rawPutInfo {
+		req := newRawPutInfo()
+		req.setHead(&Object{SystemHeader: object.SystemHeader{CID: dstAddr.CID}})
+		req.setExtendedHeaders(testMultipartHeaders(kv...))
+
+		return req
+	}
+
+	t.Run("regular object", func(t *testing.T) {
+		req := putInfo()
+
+		s := &copyingObjectStorer{
+			objStorer: &testHandlerEntity{
+				f: func(items ...interface{}) {
+					require.Equal(t, req, items[0])
+				},
+				res: &dstAddr,
+			},
+		}
+
+		res, err := s.putObject(ctx, req)
+		require.NoError(t, err)
+		require.Equal(t, dstAddr, *res)
+	})
+
+	t.Run("copy within multipart upload", func(t *testing.T) {
+		s := new(copyingObjectStorer)
+
+		_, err := s.putObject(ctx, putInfo(
+			CopySourceHeader, srcAddr.String(),
+			multipart.InitiateHeader, "",
+		))
+		require.EqualError(t, err, errMultipartCopy.Error())
+	})
+
+	t.Run("source access denied", func(t *testing.T) {
+		s := &copyingObjectStorer{
+			aclPreProc: &testHandlerEntity{
+				f: func(items ...interface{}) {
+					req := items[0].(serviceRequest)
+					require.Equal(t, object.RequestGet, req.Type())
+					require.Equal(t, srcAddr.CID, req.CID())
+				},
+				err: errAccessDenied,
+			},
+		}
+
+		_, err := s.putObject(ctx, putInfo(CopySourceHeader, srcAddr.String()))
+		require.EqualError(t, err, errAccessDenied.Error())
+
+		s = &copyingObjectStorer{
+			objRecv:    &testHandlerEntity{res: src},
+			aclChecker: testCopyACLChecker{object.RequestGet: true},
+		}
+
+		_, err = s.putObject(ctx, putInfo(CopySourceHeader, srcAddr.String()))
+		require.EqualError(t, err, errAccessDenied.Error())
+	})
+
+	t.Run("source not found", func(t *testing.T) {
+		s := &copyingObjectStorer{
+			objRecv: &testHandlerEntity{err: errIncompleteOperation},
+		}
+
+		_, err := s.putObject(ctx, putInfo(CopySourceHeader, srcAddr.String()))
+		require.EqualError(t, err, errCopySourceNotFound.Error())
+	})
+
+	t.Run("correct result", func(t *testing.T) {
+		var stored transport.PutInfo
+
+		s := &copyingObjectStorer{
+			objRecv: &testHandlerEntity{
+				f: func(items ...interface{}) {
+					info := items[0].([]transport.GetInfo)[0]
+					require.Equal(t, srcAddr, info.GetAddress())
+					require.Equal(t, service.SingleForwardingTTL, info.GetTTL())
+				},
+				res: src,
+			},
+			objStorer: &testHandlerEntity{
+				f: func(items ...interface{}) {
+					stored = items[0].(transport.PutInfo)
+				},
+				res: &dstAddr,
+			},
+			aclChecker: testCopyACLChecker{},
+		}
+
+		res, err := s.putObject(ctx, putInfo(CopySourceHeader, srcAddr.String()))
+		require.NoError(t, err)
+		require.Equal(t, dstAddr, *res)
+
+		require.Equal(t, copyHead(&Object{SystemHeader: object.SystemHeader{CID: dstAddr.CID}}, src.Object), stored.GetHead())
+
+		data, err := ioutil.ReadAll(stored.Payload())
+		require.NoError(t, err)
+		require.Equal(t, payload, data)
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/nspcc-dev/neofs-api-go/object"
 	"github.com/nspcc-dev/neofs-api-go/service"
 	"github.com/nspcc-dev/neofs-api-go/session"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/retention"
 	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/transformer"
 	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/transport"
 	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/transport/storagegroup"
@@ -28,6 +29,11 @@ type (
 		// Set of potential deletePreparer errors that won't be converted into errDeletePrepare
 		mErr map[error]struct{}
 
+		// checks the retention of the removed object,
+		// nil if retention is not checked
+		lockChecker retention.Checker
+		epochRecv   EpochReceiver
+
 		log *zap.Logger
 	}
 
@@ -123,6 +129,19 @@ func (s *coreObjRemover) delete(ctx context.Context, dInfo deleteInfo) error {
 		}
 	}
 
+	if s.lockChecker != nil {
+		locked, err := s.lockChecker.Locked(ctx, dInfo.GetAddress(), s.epochRecv.Epoch())
+		if err != nil {
+			s.log.Error("object retention check failure",
+				zap.String("error", err.Error()),
+			)
+
+			return errRetentionCheck
+		} else if locked {
+			return errObjectLocked
+		}
+	}
+
 	deleteList, err := s.delPrep.prepare(ctx, dInfo)
 	if err != nil {
 		if _, ok := s.mErr[errors.Cause(err)]; !ok {
@@ -31,6 +31,8 @@ type (
 		// Mocked error of any interface.
 		err error
 	}
+
+	testErrLockChecker struct{}
 )
 
 var (
@@ -44,6 +46,10 @@ var (
 	_ responsePreparer     = (*testDeleteEntity)(nil)
 )
 
+func (testErrLockChecker) Locked(context.Context, Address, uint64) (bool, error) {
+	return false, errors.New("test error for lock checker")
+}
+
 func (s *testDeleteEntity) verify(context.Context, *session.Token, *Object) error {
 	return nil
 }
@@ -210,6 +216,26 @@ func Test_coreObjRemover_delete(t *testing.T) {
 		require.EqualError(t, s.delete(ctx, req), errDeletePrepare.Error())
 	})
 
+	t.Run("locked object", func(t *testing.T) {
+		s := &coreObjRemover{
+			delPrep: &testDeleteEntity{
+				f: func(...interface{}) {
+					t.Fatal("locked object removal prepared")
+				},
+			},
+			tokenStore:  &testDeleteEntity{res: pToken},
+			lockChecker: testLockChecker{addr.ObjectID: true},
+			epochRecv:   &testDeleteEntity{res: uint64(1)},
+			log:         zap.L(),
+		}
+
+		require.EqualError(t, s.delete(ctx, req), errObjectLocked.Error())
+
+		s.lockChecker = testErrLockChecker{}
+
+		require.EqualError(t, s.delete(ctx, req), errRetentionCheck.Error())
+	})
+
 	t.Run("straight remover error", func(t *testing.T) {
 		dInfo := newRawDeleteInfo()
 		dInfo.setAddress(addr)
@@ -0,0 +1,107 @@
+package object
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/multiformats/go-multiaddr"
+	"github.com/nspcc-dev/neofs-api-go/refs"
+	"github.com/nspcc-dev/neofs-api-go/service"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/erasure"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/transport"
+	"github.com/pkg/errors"
+)
+
+type (
+	erasureObjectsReceiver interface {
+		getFromParts(context.Context, Address, []ID, bool) (*objectData, error)
+	}
+
+	coreErasureReceiver struct {
+		executor transport.SelectiveContainerExecutor
+	}
+)
+
+var errPartsNotFound = errors.New("could not find erasure coded parts")
+
+// isErasureParts checks if the children are the erasure coded parts of the parent.
+func isErasureParts(parent ID, children []ID) bool {
+	return len(children) > 0 && erasure.IsPartOf(children[0], parent)
+}
+
+// getFromParts returns the parent object restored from the parts.
+//
+// Parent headers are taken from any part, the payload is
+// decoded from the first received Data parts.
+func (s *coreErasureReceiver) getFromParts(ctx context.Context, addr Address, parts []ID, head bool) (*objectData, error) {
+	var (
+		objs []*Object
+		data int
+	)
+
+	params := transport.GetParams{
+		SelectiveParams: transport.SelectiveParams{
+			CID:             addr.CID,
+			TTL:             service.NonForwardingTTL,
+			IDList:          parts,
+			Raw:             true,
+			Token:           tokenFromContext(ctx),
+			Bearer:          bearerFromContext(ctx),
+			ExtendedHeaders: extendedHeadersFromContext(ctx),
+			Breaker: func(refs.Address) (cFlag transport.ProgressControlFlag) {
+				if data > 0 && len(objs) >= data {
+					cFlag = transport.BreakProgress
+				}
+				return
+			},
+		},
+		Handler: func(_ multiaddr.Multiaddr, obj *Object) {
+			p, ok, err := erasure.ParsePart(obj)
+			if err != nil || !ok || p.Parent != addr.ObjectID {
+				return
+			}
+
+			for i := range objs {
+				if objs[i].SystemHeader.ID == obj.SystemHeader.ID {
+					return
+				}
+			}
+
+			objs = append(objs, obj)
+
+			if head {
+				data = 1
+			} else {
+				data = int(p.Scheme.Data)
+			}
+		},
+	}
+
+	var err error
+
+	if head {
+		err = s.executor.Head(ctx, &transport.HeadParams{
+			GetParams:   params,
+			FullHeaders: true,
+		})
+	} else {
+		err = s.executor.Get(ctx, &params)
+	}
+
+	if err != nil {
+		return nil, err
+	} else if len(objs) == 0 {
+		return nil, errPartsNotFound
+	}
+
+	res := new(objectData)
+
+	if head {
+		res.Object, err = erasure.ParentHeader(objs[0])
+	} else if res.Object, err = erasure.Restore(objs); err == nil {
+		res.payload = bytes.NewReader(res.Object.Payload)
+		res.Object.Payload = nil
+	}
+
+	return res, err
+}
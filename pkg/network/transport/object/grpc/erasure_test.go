@@ -0,0 +1,175 @@
+package object
+
+import (
+	"context"
+	"io/ioutil"
+	"testing"
+
+	"github.com/multiformats/go-multiaddr"
+	"github.com/nspcc-dev/neofs-api-go/object"
+	"github.com/nspcc-dev/neofs-api-go/refs"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/erasure"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/transport"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	// Entity for mocking interfaces.
+	// Implementation of any interface intercepts arguments via f (if not nil).
+	// If err is not nil, it returns as it is. Otherwise, stored objects are passed to the handlers.
+	testErasureEntity struct {
+		// Set of interfaces which entity must implement, but some methods from those does not call.
+		transport.SelectiveContainerExecutor
+
+		// Argument interceptor. Used for ascertain of correct parameter passage between components.
+		f func(...interface{})
+		// Mocked stored objects.
+		objs map[ID]*Object
+		// Mocked error of any interface.
+		err error
+	}
+)
+
+var _ transport.SelectiveContainerExecutor = (*testErasureEntity)(nil)
+
+func (s *testErasureEntity) Get(_ context.Context, p *transport.GetParams) error {
+	if s.f != nil {
+		s.f(p)
+	}
+	if s.err != nil {
+		return s.err
+	}
+
+	for _, id := range p.IDList {
+		if p.Breaker != nil && p.Breaker(refs.Address{CID: p.CID, ObjectID: id}) == transport.BreakProgress {
+			break
+		} else if obj, ok := s.objs[id]; ok {
+			p.Handler(nil, obj)
+		}
+	}
+
+	return nil
+}
+
+func (s *testErasureEntity) Head(ctx context.Context, p *transport.HeadParams) error {
+	return s.Get(ctx, &p.GetParams)
+}
+
+func testErasureParts(t *testing.T, payload []byte) (*Object, []*Object) {
+	addr := testObjectAddress(t)
+
+	parts, err := erasure.Split(&Object{
+		SystemHeader: object.SystemHeader{
+			ID:  addr.ObjectID,
+			CID: addr.CID,
+		},
+	}, payload, erasure.Scheme{Data: 2, Parity: 1}, func(*Object) error { return nil })
+	require.NoError(t, err)
+
+	for i := range parts {
+		parts[i].AddHeader(&object.Header{Value: &object.Header_Integrity{Integrity: new(object.IntegrityHeader)}})
+	}
+
+	parent, err := erasure.Link(parts)
+	require.NoError(t, err)
+
+	return parent, parts
+}
+
+func Test_isErasureParts(t *testing.T) {
+	parent, parts := testErasureParts(t, []byte{1, 2, 3})
+
+	other := testObjectAddress(t).ObjectID
+
+	require.False(t, isErasureParts(parent.SystemHeader.ID, nil))
+	require.False(t, isErasureParts(parent.SystemHeader.ID, []ID{other}))
+	require.False(t, isErasureParts(other, []ID{parts[0].SystemHeader.ID}))
+	require.True(t, isErasureParts(parent.SystemHeader.ID, []ID{parts[0].SystemHeader.ID}))
+}
+
+func Test_coreErasureReceiver_getFromParts(t *testing.T) {
+	ctx := context.TODO()
+	payload := testData(t, 11)
+
+	parent, parts := testErasureParts(t, payload)
+	children := parent.Links(object.Link_Child)
+	addr := *parent.Address()
+
+	t.Run("executor error", func(t *testing.T) {
+		eErr := errors.New("test error for executor")
+
+		s := &coreErasureReceiver{
+			executor: &testErasureEntity{err: eErr},
+		}
+
+		_, err := s.getFromParts(ctx, addr, children, false)
+		require.EqualError(t, err, eErr.Error())
+	})
+
+	t.Run("parts not found", func(t *testing.T) {
+		s := &coreErasureReceiver{
+			executor: new(testErasureEntity),
+		}
+
+		_, err := s.getFromParts(ctx, addr, children, true)
+		require.EqualError(t, err, errPartsNotFound.Error())
+	})
+
+	t.Run("head", func(t *testing.T) {
+		s := &coreErasureReceiver{
+			executor: &testErasureEntity{
+				f: func(items ...interface{}) {
+					p := items[0].(*transport.GetParams)
+					require.True(t, p.Raw)
+					require.Equal(t, addr.CID, p.CID)
+					require.Equal(t, children, p.IDList)
+				},
+				objs: map[ID]*Object{
+					parts[2].SystemHeader.ID: parts[2],
+				},
+			},
+		}
+
+		res, err := s.getFromParts(ctx, addr, children, true)
+		require.NoError(t, err)
+		require.Equal(t, addr.ObjectID, res.SystemHeader.ID)
+		require.Equal(t, uint64(len(payload)), res.SystemHeader.PayloadLength)
+	})
+
+	t.Run("get", func(t *testing.T) {
+		var handled int
+
+		s := &coreErasureReceiver{
+			executor: &testErasureEntity{
+				objs: map[ID]*Object{
+					parts[0].SystemHeader.ID: parts[0],
+					parts[2].SystemHeader.ID: parts[2],
+				},
+			},
+		}
+
+		res, err := s.getFromParts(ctx, addr, children, false)
+		require.NoError(t, err)
+		require.Equal(t, addr.ObjectID, res.SystemHeader.ID)
+
+		data, err := ioutil.ReadAll(res.payload)
+		require.NoError(t, err)
+		require.Equal(t, payload, data)
+
+		// reception stops after Data parts
+		s.executor.(*testErasureEntity).objs[parts[1].SystemHeader.ID] = parts[1]
+		s.executor.(*testErasureEntity).f = func(items ...interface{}) {
+			p := items[0].(*transport.GetParams)
+			handler := p.Handler
+			p.Handler = func(node multiaddr.Multiaddr, obj *Object) {
+				handled++
+				handler(node, obj)
+			}
+		}
+
+		_, err = s.getFromParts(ctx, addr, children, false)
+		require.NoError(t, err)
+		require.Equal(t, 2, handled)
+	})
+}
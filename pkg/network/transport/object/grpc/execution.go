@@ -11,6 +11,7 @@ import (
 	"github.com/nspcc-dev/neofs-api-go/service"
 	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket"
 	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/localstore"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/expiration"
 	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/placement"
 	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/replication/storage"
 	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/transport"
@@ -47,6 +48,25 @@ type (
 		handleItem(interface{})
 	}
 
+	// nodeItemHandler is a responseItemHandler that distinguishes
+	// the results of the container nodes.
+	nodeItemHandler interface {
+		responseItemHandler
+		handleNodeItem(multiaddr.Multiaddr, interface{})
+	}
+
+	// nodeFailureHandler is a responseItemHandler that is notified
+	// about the container nodes that failed the operation.
+	nodeFailureHandler interface {
+		handleNodeFailure(multiaddr.Multiaddr)
+	}
+
+	// nodeBoundItemHandler passes the results of the particular node to nodeItemHandler.
+	nodeBoundItemHandler struct {
+		nodeItemHandler
+		node multiaddr.Multiaddr
+	}
+
 	operationParams struct {
 		computableParams
 		metaInfo    transport.MetaInfo
@@ -56,11 +76,13 @@ type (
 	coreOperationFinalizer struct {
 		curPlacementBuilder  placementBuilder
 		prevPlacementBuilder placementBuilder
-		interceptorPreparer  interceptorPreparer
-		workerPool           WorkerPool
-		traverseExec         transport.ContainerTraverseExecutor
-		resLogger            resultLogger
-		log                  *zap.Logger
+		// used instead of curPlacementBuilder for Put requests if set
+		storagePlacementBuilder placementBuilder
+		interceptorPreparer     interceptorPreparer
+		workerPool              WorkerPool
+		traverseExec            transport.ContainerTraverseExecutor
+		resLogger               resultLogger
+		log                     *zap.Logger
 	}
 
 	localFullObjectReceiver interface {
@@ -100,6 +122,9 @@ type (
 		queryImp  localQueryImposer
 		rngReader localRangeReader
 		rngHasher localRangeHasher
+
+		// used to find the node position in the search cursor
+		addressStore storage.AddressStore
 	}
 
 	coreHandler struct {
@@ -244,10 +269,17 @@ func (s *coreOperationExecutor) executeOperation(ctx context.Context, req transp
 }
 
 func (s *coreOperationFinalizer) completeExecution(ctx context.Context, p operationParams) error {
+	curPlacementBuilder := s.curPlacementBuilder
+
+	// new objects are not sent to the full nodes
+	if p.reqType == object.RequestPut && s.storagePlacementBuilder != nil {
+		curPlacementBuilder = s.storagePlacementBuilder
+	}
+
 	traverser := newContainerTraverser(&traverseParams{
 		tryPrevNM:            p.tryPreviousNetMap,
 		addr:                 p.addr,
-		curPlacementBuilder:  s.curPlacementBuilder,
+		curPlacementBuilder:  curPlacementBuilder,
 		prevPlacementBuilder: s.prevPlacementBuilder,
 		maxRecycleCount:      p.maxRecycleCount,
 		stopCount:            p.stopCount,
@@ -314,10 +346,18 @@ func (s *coreInterceptorPreparer) prepareInterceptor(p interceptorItems) (func(c
 		return nil, err
 	}
 
+	itemHandler := p.itemHandler
+	if h, ok := itemHandler.(nodeItemHandler); ok {
+		itemHandler = &nodeBoundItemHandler{
+			nodeItemHandler: h,
+			node:            selfAddr,
+		}
+	}
+
 	return func(ctx context.Context, node multiaddr.Multiaddr) (res bool) {
 		if node.Equal(selfAddr) {
 			p.handler.HandleResult(ctx, selfAddr, nil,
-				s.localExec.executeOperation(ctx, p.metaInfo, p.itemHandler))
+				s.localExec.executeOperation(ctx, p.metaInfo, itemHandler))
 			return !p.selfForward
 		}
 
@@ -330,13 +370,26 @@ func (s *coreHandler) HandleResult(ctx context.Context, n multiaddr.Multiaddr, r
 
 	s.traverser.add(n, ok)
 
-	if ok && r != nil {
-		s.itemHandler.handleItem(r)
+	switch {
+	case !ok:
+		if h, isFailureHandler := s.itemHandler.(nodeFailureHandler); isFailureHandler {
+			h.handleNodeFailure(n)
+		}
+	case r != nil:
+		if h, isNodeHandler := s.itemHandler.(nodeItemHandler); isNodeHandler {
+			h.handleNodeItem(n, r)
+		} else {
+			s.itemHandler.handleItem(r)
+		}
 	}
 
 	s.resLogger.logErr(s.reqType, n, e)
 }
 
+func (s *nodeBoundItemHandler) handleItem(v interface{}) {
+	s.handleNodeItem(s.node, v)
+}
+
 func (s *coreResultLogger) logErr(t object.RequestType, n multiaddr.Multiaddr, e error) {
 	if e == nil {
 		return
@@ -377,7 +430,12 @@ func (s *localOperationExecutor) executeOperation(ctx context.Context, req trans
 	case object.RequestSearch:
 		r := req.(transport.SearchInfo)
 
-		addrList, err := s.queryImp.imposeQuery(ctx, r.GetCID(), r.GetQuery(), 1) // TODO: add query version to SearchInfo
+		page, err := s.searchPage(r)
+		if err != nil {
+			return err
+		}
+
+		addrList, err := s.queryImp.imposeQuery(withSearchPage(ctx, page), r.GetCID(), r.GetQuery(), queryVersion(r))
 		if err != nil {
 			return err
 		}
@@ -408,6 +466,22 @@ func (s *localOperationExecutor) executeOperation(ctx context.Context, req trans
 	return nil
 }
 
+// searchPage returns the page of the local search results
+// requested by the search cursor.
+func (s *localOperationExecutor) searchPage(r transport.MetaInfo) (searchPage, error) {
+	cur, limit, err := parseSearchPaging(r.ExtendedHeaders())
+	if err != nil || cur == nil {
+		return searchPage{limit: limit}, err
+	}
+
+	selfAddr, err := s.addressStore.SelfAddr()
+	if err != nil {
+		return searchPage{}, err
+	}
+
+	return cur.page(selfAddr.String(), limit), nil
+}
+
 func (s *localStoreExecutor) getHashes(ctx context.Context, addr Address, ranges []Range, salt []byte) ([]Hash, error) {
 	res := make([]Hash, 0, len(ranges))
 
@@ -450,6 +524,11 @@ func (s *localStoreExecutor) headObject(_ context.Context, addr Address) (*Objec
 		}
 	}
 
+	// expired objects are not found until GC removes them
+	if expiration.Expired(m.Object, s.epochRecv.Epoch()) {
+		return nil, errIncompleteOperation
+	}
+
 	return m.Object, nil
 }
 
@@ -464,5 +543,9 @@ func (s *localStoreExecutor) getObject(_ context.Context, addr Address) (*Object
 		}
 	}
 
+	if expiration.Expired(obj, s.epochRecv.Epoch()) {
+		return nil, errIncompleteOperation
+	}
+
 	return obj, nil
 }
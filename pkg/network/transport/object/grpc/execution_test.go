@@ -4,6 +4,7 @@ import (
 	"context"
 	"io"
 	"io/ioutil"
+	"strconv"
 	"testing"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/nspcc-dev/neofs-api-go/service"
 	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket"
 	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/localstore"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/expiration"
 	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/replication/storage"
 	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/transport"
 	"github.com/pkg/errors"
@@ -537,6 +539,9 @@ func Test_localStoreExecutor(t *testing.T) {
 			obj := new(Object)
 
 			s := &localStoreExecutor{
+				epochRecv: &testExecutionEntity{
+					res: uint64(1),
+				},
 				localStore: &testExecutionEntity{
 					res: obj,
 				},
@@ -546,6 +551,21 @@ func Test_localStoreExecutor(t *testing.T) {
 			require.NoError(t, err)
 			require.Equal(t, obj, res)
 		})
+
+		t.Run("expired", func(t *testing.T) {
+			s := &localStoreExecutor{
+				epochRecv: &testExecutionEntity{
+					res: uint64(2),
+				},
+				localStore: &testExecutionEntity{
+					res: testExpiringObject(1),
+				},
+			}
+
+			res, err := s.getObject(ctx, addr)
+			require.EqualError(t, err, errIncompleteOperation.Error())
+			require.Nil(t, res)
+		})
 	})
 
 	t.Run("head", func(t *testing.T) {
@@ -572,9 +592,12 @@ func Test_localStoreExecutor(t *testing.T) {
 		})
 
 		t.Run("success", func(t *testing.T) {
-			obj := new(Object)
+			obj := testExpiringObject(1)
 
 			s := &localStoreExecutor{
+				epochRecv: &testExecutionEntity{
+					res: uint64(1),
+				},
 				localStore: &testExecutionEntity{
 					res: &Meta{Object: obj},
 				},
@@ -584,6 +607,21 @@ func Test_localStoreExecutor(t *testing.T) {
 			require.NoError(t, err)
 			require.Equal(t, obj, res)
 		})
+
+		t.Run("expired", func(t *testing.T) {
+			s := &localStoreExecutor{
+				epochRecv: &testExecutionEntity{
+					res: uint64(2),
+				},
+				localStore: &testExecutionEntity{
+					res: &Meta{Object: testExpiringObject(1)},
+				},
+			}
+
+			res, err := s.headObject(ctx, addr)
+			require.EqualError(t, err, errIncompleteOperation.Error())
+			require.Nil(t, res)
+		})
 	})
 
 	t.Run("get range", func(t *testing.T) {
@@ -1203,3 +1241,18 @@ func testAddrList(t *testing.T, count int) (res []Address) {
 	}
 	return
 }
+
+func testExpiringObject(epoch uint64) *Object {
+	return &Object{
+		Headers: []Header{
+			{
+				Value: &object.Header_UserHeader{
+					UserHeader: &object.UserHeader{
+						Key:   expiration.Header,
+						Value: strconv.FormatUint(epoch, 10),
+					},
+				},
+			},
+		},
+	}
+}
@@ -7,6 +7,9 @@ import (
 	"github.com/nspcc-dev/neofs-api-go/storagegroup"
 	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket"
 	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/localstore"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/erasure"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/expiration"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/retention"
 	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/verifier"
 	"github.com/pkg/errors"
 )
@@ -20,6 +23,7 @@ type (
 		localStore    localstore.Localstore
 		epochRecv     EpochReceiver
 		verifier      verifier.Verifier
+		lockChecker   retention.Checker
 
 		maxPayloadSize uint64
 	}
@@ -47,6 +51,10 @@ const (
 	creationEpochFN      = "CREATION_EPOCH"
 	objIntegrityFN       = "OBJECT_INTEGRITY"
 	payloadSizeFN        = "PAYLOAD_SIZE"
+	expirationEpochFN    = "EXPIRATION_EPOCH"
+	retentionLockFN      = "RETENTION_LOCK"
+	erasurePartFN        = "ERASURE_PART"
+	acceptancePolicyFN   = "ACCEPTANCE_POLICY"
 )
 
 var errObjectFilter = errors.New("incoming object has not passed filter")
@@ -57,6 +65,9 @@ var mFilters = map[string]filterConstructor{
 	creationEpochFN:      creationEpochFC,
 	objIntegrityFN:       objectIntegrityFC,
 	payloadSizeFN:        payloadSizeFC,
+	expirationEpochFN:    expirationEpochFC,
+	retentionLockFN:      retentionLockFC,
+	erasurePartFN:        erasurePartFC,
 }
 
 var mBasicFilters = map[string]filterConstructor{
@@ -69,6 +80,20 @@ func newIncomingObjectFilter(p *Params) (Filter, error) {
 		return nil, err
 	}
 
+	if p.AcceptancePolicy != nil {
+		// minimal priority keeps the built-in filters first
+		if err := filter.PutSubFilter(localstore.SubFilterParams{
+			PriorityFlag: localstore.PriorityMin,
+			FilterPipeline: localstore.NewFilter(&localstore.FilterParams{
+				Name:       acceptancePolicyFN,
+				FilterFunc: acceptancePolicyFC(p.AcceptancePolicy),
+			}),
+			OnFail: localstore.CodeFail,
+		}); err != nil {
+			return nil, errors.Wrapf(err, "could not put filter %s in pipeline", acceptancePolicyFN)
+		}
+	}
+
 	return filter, nil
 }
 
@@ -78,6 +103,30 @@ func newFilter(p *Params, name string, m map[string]filterConstructor) (Filter,
 		FilterFunc: localstore.SkippingFilterFunc,
 	})
 
+	holder, err := retention.NewHolder(p.ContainerStorage)
+	if err != nil {
+		return nil, err
+	}
+
+	var locator retention.Locator
+
+	// lock objects are placed by their own ID, so the locks
+	// of the local objects are looked for in the container
+	if p.executor != nil {
+		if locator, err = retention.NewRemoteLocator(p.executor); err != nil {
+			return nil, err
+		}
+	}
+
+	lockChecker, err := retention.NewLocalChecker(retention.LocalCheckerParams{
+		Localstore: p.LocalStore,
+		Holder:     holder,
+		Locator:    locator,
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	fp := &filterParams{
 		sgInfoRecv:    p.SGInfoReceiver,
 		tsPresChecker: &coreTSPresChecker{localStore: p.LocalStore},
@@ -86,6 +135,7 @@ func newFilter(p *Params, name string, m map[string]filterConstructor) (Filter,
 		localStore:    p.LocalStore,
 		epochRecv:     p.EpochReceiver,
 		verifier:      p.Verifier,
+		lockChecker:   lockChecker,
 
 		maxPayloadSize: p.MaxPayloadSize,
 	}
@@ -157,6 +207,12 @@ func storageGroupFC(p *filterParams) localstore.FilterFunc {
 func tombstoneOverwriteFC(p *filterParams) localstore.FilterFunc {
 	return func(ctx context.Context, meta *Meta) *localstore.FilterResult {
 		if meta.Object.IsTombstone() {
+			if locked, err := p.lockChecker.Locked(ctx, *meta.Object.Address(), p.epochRecv.Epoch()); err != nil {
+				return localstore.ResultFail()
+			} else if locked {
+				return localstore.ResultWithError(localstore.CodeFail, errObjectLocked)
+			}
+
 			return localstore.ResultPass()
 		} else if hasTombstone, err := p.tsPresChecker.hasLocalTombstone(*meta.Object.Address()); err != nil {
 			return localstore.ResultFail()
@@ -223,6 +279,46 @@ func creationEpochFC(p *filterParams) localstore.FilterFunc {
 	}
 }
 
+func expirationEpochFC(p *filterParams) localstore.FilterFunc {
+	return func(_ context.Context, meta *Meta) *localstore.FilterResult {
+		e, ok, err := expiration.Epoch(meta.Object)
+		if err != nil {
+			return localstore.ResultWithError(localstore.CodeFail, errInvalidExpirationEpoch)
+		} else if current := p.epochRecv.Epoch(); ok && e < current {
+			return localstore.ResultWithError(
+				localstore.CodeFail,
+				&detailedError{
+					error: errObjectExpired,
+					d:     objectExpirationEpochDetails(current),
+				},
+			)
+		}
+
+		return localstore.ResultPass()
+	}
+}
+
+func retentionLockFC(_ *filterParams) localstore.FilterFunc {
+	return func(_ context.Context, meta *Meta) *localstore.FilterResult {
+		if _, _, err := retention.ParseLock(meta.Object); err != nil {
+			return localstore.ResultWithError(localstore.CodeFail, errInvalidRetentionLock)
+		}
+
+		return localstore.ResultPass()
+	}
+}
+
+func erasurePartFC(_ *filterParams) localstore.FilterFunc {
+	return func(_ context.Context, meta *Meta) *localstore.FilterResult {
+		// objects without the part headers are not the parts
+		if _, _, err := erasure.ParsePart(meta.Object); err != nil {
+			return localstore.ResultWithError(localstore.CodeFail, errInvalidErasurePart)
+		}
+
+		return localstore.ResultPass()
+	}
+}
+
 func objectIntegrityFC(p *filterParams) localstore.FilterFunc {
 	return func(ctx context.Context, meta *Meta) *localstore.FilterResult {
 		if err := p.verifier.Verify(ctx, meta.Object); err != nil {
@@ -239,6 +335,23 @@ func objectIntegrityFC(p *filterParams) localstore.FilterFunc {
 	}
 }
 
+func acceptancePolicyFC(policy localstore.FilterFunc) localstore.FilterFunc {
+	return func(ctx context.Context, meta *Meta) *localstore.FilterResult {
+		res := policy(ctx, meta)
+		if res.Code() != localstore.CodeFail {
+			return res
+		}
+
+		return localstore.ResultWithError(
+			localstore.CodeFail,
+			&detailedError{
+				error: errAcceptancePolicy,
+				d:     acceptancePolicyDetails(res.Err()),
+			},
+		)
+	}
+}
+
 func basicFilter(p *Params) (Filter, error) {
 	return newFilter(p, allObjectsCheckpointFilterName, mBasicFilters)
 }
@@ -10,6 +10,8 @@ import (
 	"github.com/nspcc-dev/neofs-api-go/storagegroup"
 	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket"
 	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/localstore"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/erasure"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/retention"
 	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/verifier"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
@@ -35,6 +37,9 @@ type (
 		obj *Object
 		exp localstore.FilterCode
 	}
+
+	// testLockChecker reports the listed objects as locked.
+	testLockChecker map[ID]bool
 )
 
 var (
@@ -45,6 +50,10 @@ var (
 	_ tombstonePresenceChecker  = (*testFilterEntity)(nil)
 )
 
+func (s testLockChecker) Locked(_ context.Context, addr Address, _ uint64) (bool, error) {
+	return s[addr.ObjectID], nil
+}
+
 func (s *testFilterEntity) Meta(addr Address) (*Meta, error) {
 	if s.f != nil {
 		s.f(addr)
@@ -105,6 +114,85 @@ func Test_creationEpochFC(t *testing.T) {
 	testFilteringObjects(t, ctx, ff, valid, invalid, nil)
 }
 
+func Test_expirationEpochFC(t *testing.T) {
+	localEpoch := uint64(100)
+
+	ff := expirationEpochFC(&filterParams{epochRecv: &testFilterEntity{res: localEpoch}})
+
+	malformed := testExpiringObject(0)
+	malformed.Headers[0].Value.(*object.Header_UserHeader).UserHeader.Value = "soon"
+
+	valid := []Object{
+		{},
+		*testExpiringObject(localEpoch),
+		*testExpiringObject(localEpoch + 1),
+	}
+
+	invalid := []Object{
+		*testExpiringObject(localEpoch - 1),
+		*malformed,
+	}
+
+	testFilteringObjects(t, context.TODO(), ff, valid, invalid, nil)
+}
+
+func Test_retentionLockFC(t *testing.T) {
+	ff := retentionLockFC(new(filterParams))
+
+	lock := func(kv ...string) Object {
+		obj := Object{}
+		for i := 0; i < len(kv); i += 2 {
+			obj.Headers = append(obj.Headers, Header{Value: &object.Header_UserHeader{
+				UserHeader: &object.UserHeader{Key: kv[i], Value: kv[i+1]},
+			}})
+		}
+
+		return obj
+	}
+
+	target := testObjectAddress(t).ObjectID.String()
+
+	valid := []Object{
+		{},
+		lock(retention.Header, "10"),
+		lock(retention.Header, "10", retention.TargetHeader, target),
+	}
+
+	invalid := []Object{
+		lock(retention.Header, "later"),
+		lock(retention.Header, "10", retention.TargetHeader, "object"),
+		lock(retention.TargetHeader, target),
+	}
+
+	testFilteringObjects(t, context.TODO(), ff, valid, invalid, nil)
+}
+
+func Test_erasurePartFC(t *testing.T) {
+	ff := erasurePartFC(new(filterParams))
+
+	src := &Object{SystemHeader: object.SystemHeader{ID: testObjectAddress(t).ObjectID}}
+
+	scheme := erasure.Scheme{Data: 2, Parity: 1}
+
+	parts, err := erasure.Split(src, []byte{1, 2, 3}, scheme,
+		func(*Object) error { return nil })
+	require.NoError(t, err)
+
+	wrongID := *parts[0]
+	wrongID.SystemHeader.ID = erasure.PartID(src.SystemHeader.ID, scheme, 1)
+
+	// objects can have the IDs of the part version
+	noHeaders := Object{SystemHeader: object.SystemHeader{ID: parts[0].SystemHeader.ID}}
+
+	notPartID := *parts[0]
+	notPartID.SystemHeader.ID = src.SystemHeader.ID
+
+	valid := []Object{*src, *parts[0], *parts[2], noHeaders}
+	invalid := []Object{wrongID, notPartID}
+
+	testFilteringObjects(t, context.TODO(), ff, valid, invalid, nil)
+}
+
 func Test_objectSizeFC(t *testing.T) {
 	maxProcSize := uint64(100)
 
@@ -185,6 +273,10 @@ func Test_tombstoneOverwriteFC(t *testing.T) {
 		obj4 = Object{
 			SystemHeader: SystemHeader{ID: testObjectAddress(t).ObjectID},
 		}
+		obj5 = Object{
+			SystemHeader: SystemHeader{ID: testObjectAddress(t).ObjectID},
+			Headers:      []Header{{Value: new(object.Header_Tombstone)}},
+		}
 	)
 
 	ts := new(testFilterEntity)
@@ -200,9 +292,13 @@ func Test_tombstoneOverwriteFC(t *testing.T) {
 	}
 
 	valid := []Object{obj1, obj4}
-	invalid := []Object{obj2, obj3}
+	invalid := []Object{obj2, obj3, obj5}
 
-	ff := tombstoneOverwriteFC(&filterParams{tsPresChecker: ts})
+	ff := tombstoneOverwriteFC(&filterParams{
+		tsPresChecker: ts,
+		lockChecker:   testLockChecker{obj5.SystemHeader.ID: true},
+		epochRecv:     &testFilterEntity{res: uint64(1)},
+	})
 
 	testFilteringObjects(t, context.TODO(), ff, valid, invalid, nil)
 }
@@ -397,3 +493,38 @@ func Test_payloadSizeFC(t *testing.T) {
 
 	testFilteringObjects(t, context.TODO(), ff, valid, invalid, nil)
 }
+
+func Test_acceptancePolicyFC(t *testing.T) {
+	errRule := errors.New("rule failure")
+
+	ff := acceptancePolicyFC(func(_ context.Context, meta *Meta) *localstore.FilterResult {
+		switch meta.Object.SystemHeader.PayloadLength {
+		case 0:
+			return localstore.ResultPass()
+		case 1:
+			return localstore.ResultIgnore()
+		default:
+			return localstore.ResultWithError(localstore.CodeFail, errRule)
+		}
+	})
+
+	valid := []Object{
+		{SystemHeader: SystemHeader{PayloadLength: 0}},
+	}
+
+	invalid := []Object{
+		{SystemHeader: SystemHeader{PayloadLength: 2}},
+	}
+
+	ignored := []Object{
+		{SystemHeader: SystemHeader{PayloadLength: 1}},
+	}
+
+	testFilteringObjects(t, context.TODO(), ff, valid, invalid, ignored)
+
+	res := ff(context.TODO(), &Meta{Object: &invalid[0]})
+	require.Equal(t, &detailedError{
+		error: errAcceptancePolicy,
+		d:     acceptancePolicyDetails(errRule),
+	}, res.Err())
+}
@@ -66,6 +66,8 @@ func (s *objectService) executeRequest(ctx context.Context, req serviceRequest)
 			serviceRequest: r,
 			timeout:        s.pSrch.Timeout,
 		})
+	case *searchRequest:
+		return nil, s.streamSearch(ctx, r)
 	case *putRequest:
 		addr, err := s.objStorer.putObject(ctx, r)
 		if err != nil {
@@ -50,6 +50,7 @@ type (
 		straightObjRecv objectReceiver
 		childLister     objectChildrenLister
 		ancestralRecv   ancestralObjectsReceiver
+		erasureRecv     erasureObjectsReceiver
 
 		log *zap.Logger
 	}
@@ -244,7 +245,17 @@ func (s *coreObjectReceiver) getObject(ctx context.Context, info ...transport.Ge
 		}
 	}
 
-	res, err := s.ancestralRecv.getFromChildren(ctx, info[0].GetAddress(), children, info[0].Type() == object.RequestHead)
+	var (
+		res  *objectData
+		head = info[0].Type() == object.RequestHead
+	)
+
+	if s.erasureRecv != nil && isErasureParts(info[0].GetAddress().ObjectID, children) {
+		res, err = s.erasureRecv.getFromParts(ctx, info[0].GetAddress(), children, head)
+	} else {
+		res, err = s.ancestralRecv.getFromChildren(ctx, info[0].GetAddress(), children, head)
+	}
+
 	if err != nil {
 		s.log.Error("could not get object from children",
 			zap.String("error", err.Error()),
@@ -0,0 +1,168 @@
+package object
+
+import (
+	"context"
+
+	"github.com/multiformats/go-multiaddr"
+	"github.com/nspcc-dev/neofs-api-go/object"
+	"github.com/nspcc-dev/neofs-api-go/refs"
+	"github.com/nspcc-dev/neofs-api-go/service"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/multipart"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/transformer"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/transport"
+	"github.com/pkg/errors"
+)
+
+type (
+	// multipartObjectStorer handles the multipart upload requests
+	// of the objects with the session token.
+	//
+	// Upload record and parts are prepared by the transformer,
+	// closing child and parent object of the completed upload
+	// are signed by the session token and stored straightly.
+	multipartObjectStorer struct {
+		objStorer      objectStorer
+		straightStorer objectStorer
+
+		executor transport.SelectiveContainerExecutor
+
+		// maximum payload size of the part, larger parts
+		// would be split by the transformer
+		partLimit uint64
+	}
+)
+
+var (
+	errMultipartPayload  = errors.New("multipart upload record with payload")
+	errMultipartPartSize = errors.New("multipart upload part size overflow")
+	errUploadNotFound    = errors.New("multipart upload not found")
+)
+
+func (s *multipartObjectStorer) putObject(ctx context.Context, info transport.PutInfo) (*Address, error) {
+	req, err := multipart.ParseRequest(info.ExtendedHeaders())
+	if err != nil {
+		return nil, err
+	} else if req.Action == multipart.ActionNone {
+		return s.objStorer.putObject(ctx, info)
+	}
+
+	var (
+		obj = info.GetHead()
+		u   = multipart.Upload{ID: req.ID, Token: info.GetSessionToken().GetID()}
+	)
+
+	switch req.Action {
+	case multipart.ActionInitiate:
+		if obj.SystemHeader.PayloadLength > 0 {
+			return nil, errMultipartPayload
+		} else if u.ID, err = refs.NewObjectID(); err != nil {
+			return nil, err
+		}
+
+		obj.SystemHeader.ID = u.RecordID()
+		obj.Headers = u.Record()
+
+		if _, err := s.objStorer.putObject(ctx, info); err != nil {
+			return nil, err
+		}
+
+		return &Address{CID: obj.SystemHeader.CID, ObjectID: u.ID}, nil
+	case multipart.ActionPart:
+		if obj.SystemHeader.PayloadLength > s.partLimit {
+			return nil, errMultipartPartSize
+		}
+
+		obj.SystemHeader.ID = u.PartID(req.Part)
+		obj.Headers = u.Part(req.Part)
+
+		return s.objStorer.putObject(ctx, info)
+	default:
+		return s.complete(ctx, u, req.Part, info)
+	}
+}
+
+func (s *multipartObjectStorer) complete(ctx context.Context, u multipart.Upload, n uint64, info transport.PutInfo) (*Address, error) {
+	ids := make([]ID, 0, n+1)
+
+	ids = append(ids, u.RecordID())
+	for i := uint64(1); i <= n; i++ {
+		ids = append(ids, u.PartID(i))
+	}
+
+	heads, err := s.head(ctx, info.GetHead().SystemHeader.CID, ids)
+	if err != nil {
+		return nil, err
+	} else if _, ok := heads[u.RecordID()]; !ok {
+		return nil, errUploadNotFound
+	}
+
+	parts := make([]*Object, 0, n)
+	for i := range ids[1:] {
+		parts = append(parts, heads[ids[i+1]])
+	}
+
+	token, ok := ctx.Value(transformer.PublicSessionToken).(*service.Token)
+	if !ok {
+		return nil, errNilToken
+	}
+
+	template := info.GetHead()
+	template.AddHeader(&object.Header{Value: &object.Header_Token{Token: token}})
+
+	last, parent, err := u.Complete(template, parts, func(obj *object.Object) error {
+		return transformer.SignWithToken(ctx, obj)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, obj := range []*Object{last, parent} {
+		putInfo := newRawPutInfo()
+		putInfo.setHead(obj)
+		putInfo.setTimeout(info.GetTimeout())
+		putInfo.setTTL(info.GetTTL())
+		putInfo.setCopiesNumber(info.CopiesNumber())
+		putInfo.setSessionToken(info.GetSessionToken())
+		putInfo.setBearerToken(info.GetBearerToken())
+		putInfo.setExtendedHeaders(info.ExtendedHeaders())
+
+		if _, err := s.straightStorer.putObject(ctx, putInfo); err != nil {
+			return nil, err
+		}
+	}
+
+	return parent.Address(), nil
+}
+
+// head returns the headers of the found objects by ID.
+func (s *multipartObjectStorer) head(ctx context.Context, cid CID, ids []ID) (map[ID]*Object, error) {
+	res := make(map[ID]*Object, len(ids))
+
+	err := s.executor.Head(ctx, &transport.HeadParams{
+		GetParams: transport.GetParams{
+			SelectiveParams: transport.SelectiveParams{
+				CID:        cid,
+				ServeLocal: true,
+				TTL:        service.SingleForwardingTTL,
+				IDList:     ids,
+				Breaker: func(addr refs.Address) (f transport.ProgressControlFlag) {
+					if _, ok := res[addr.ObjectID]; ok {
+						f = transport.NextAddress
+					}
+
+					return
+				},
+				Token:  tokenFromContext(ctx),
+				Bearer: bearerFromContext(ctx),
+
+				ExtendedHeaders: extendedHeadersFromContext(ctx),
+			},
+			Handler: func(_ multiaddr.Multiaddr, obj *Object) {
+				res[obj.SystemHeader.ID] = obj
+			},
+		},
+		FullHeaders: true,
+	})
+
+	return res, err
+}
@@ -0,0 +1,210 @@
+package object
+
+import (
+	"context"
+	"crypto/sha256"
+	"strconv"
+	"testing"
+
+	"github.com/nspcc-dev/neofs-api-go/hash"
+	"github.com/nspcc-dev/neofs-api-go/object"
+	"github.com/nspcc-dev/neofs-api-go/service"
+	"github.com/nspcc-dev/neofs-api-go/session"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/multipart"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/transformer"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/transport"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type testHeadExecutor struct {
+	transport.SelectiveContainerExecutor
+
+	objs map[ID]*Object
+}
+
+func (s *testHeadExecutor) Head(_ context.Context, p *transport.HeadParams) error {
+	for _, id := range p.IDList {
+		if obj, ok := s.objs[id]; ok {
+			p.Handler(nil, obj)
+		}
+	}
+
+	return nil
+}
+
+// testMultipartHeaders returns the Put request extended headers.
+func testMultipartHeaders(kv ...string) []service.ExtendedHeader {
+	hs := make([]service.RequestExtendedHeader_KV, 0, len(kv)/2)
+
+	for i := 0; i < len(kv); i += 2 {
+		h := service.RequestExtendedHeader_KV{}
+		h.SetK(kv[i])
+		h.SetV(kv[i+1])
+
+		hs = append(hs, h)
+	}
+
+	req := new(object.PutRequest)
+	req.SetHeaders(hs)
+
+	return req.ExtendedHeaders()
+}
+
+func Test_multipartObjectStorer(t *testing.T) {
+	var (
+		addr  = testObjectAddress(t)
+		token = new(service.Token)
+	)
+
+	token.SetID(session.TokenID{1, 2, 3})
+
+	pToken, err := session.NewPrivateToken(0)
+	require.NoError(t, err)
+
+	ctx := contextWithValues(context.TODO(),
+		transformer.PrivateSessionToken, pToken,
+		transformer.PublicSessionToken, token,
+	)
+
+	putInfo := func(payload uint64, kv ...string) *rawPutInfo {
+		req := newRawPutInfo()
+		req.setSessionToken(token)
+		req.setHead(&Object{SystemHeader: object.SystemHeader{CID: addr.CID, PayloadLength: payload}})
+		req.setExtendedHeaders(testMultipartHeaders(kv...))
+
+		return req
+	}
+
+	t.Run("regular object", func(t *testing.T) {
+		req := putInfo(10)
+
+		s := &multipartObjectStorer{
+			objStorer: &testPutEntity{
+				f: func(items ...interface{}) {
+					require.Equal(t, req, items[0])
+				},
+				res: &addr,
+			},
+		}
+
+		res, err := s.putObject(ctx, req)
+		require.NoError(t, err)
+		require.Equal(t, addr, *res)
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		s := new(multipartObjectStorer)
+
+		_, err := s.putObject(ctx, putInfo(10, multipart.PartHeader, "1"))
+		require.True(t, errors.Is(errors.Cause(err), multipart.ErrInvalidRequest))
+	})
+
+	t.Run("initiate", func(t *testing.T) {
+		var stored *Object
+
+		s := &multipartObjectStorer{
+			objStorer: &testPutEntity{
+				f: func(items ...interface{}) {
+					stored = items[0].(transport.PutInfo).GetHead()
+				},
+				res: &addr,
+			},
+		}
+
+		_, err := s.putObject(ctx, putInfo(1, multipart.InitiateHeader, ""))
+		require.EqualError(t, err, errMultipartPayload.Error())
+
+		res, err := s.putObject(ctx, putInfo(0, multipart.InitiateHeader, ""))
+		require.NoError(t, err)
+		require.Equal(t, addr.CID, res.CID)
+
+		u := multipart.Upload{ID: res.ObjectID, Token: token.GetID()}
+		require.Equal(t, u.RecordID(), stored.SystemHeader.ID)
+		require.Equal(t, u.Record(), stored.Headers)
+	})
+
+	t.Run("part", func(t *testing.T) {
+		var (
+			stored *Object
+			u      = multipart.Upload{ID: addr.ObjectID, Token: token.GetID()}
+		)
+
+		s := &multipartObjectStorer{
+			objStorer: &testPutEntity{
+				f: func(items ...interface{}) {
+					stored = items[0].(transport.PutInfo).GetHead()
+				},
+				res: &addr,
+			},
+			partLimit: 10,
+		}
+
+		_, err := s.putObject(ctx, putInfo(11,
+			multipart.UploadHeader, addr.ObjectID.String(),
+			multipart.PartHeader, "2",
+		))
+		require.EqualError(t, err, errMultipartPartSize.Error())
+
+		_, err = s.putObject(ctx, putInfo(10,
+			multipart.UploadHeader, addr.ObjectID.String(),
+			multipart.PartHeader, "2",
+		))
+		require.NoError(t, err)
+		require.Equal(t, u.PartID(2), stored.SystemHeader.ID)
+		require.Equal(t, u.Part(2), stored.Headers)
+	})
+
+	t.Run("complete", func(t *testing.T) {
+		var (
+			u     = multipart.Upload{ID: addr.ObjectID, Token: token.GetID()}
+			objs  = make(map[ID]*Object)
+			sum   = sha256.Sum256(nil)
+			kv    = []string{multipart.UploadHeader, addr.ObjectID.String(), multipart.CompleteHeader, "2"}
+			saved []*Object
+		)
+
+		complete := func() (*Address, error) {
+			req := putInfo(0, kv...)
+			req.GetHead().AddHeader(&object.Header{Value: &object.Header_PayloadChecksum{PayloadChecksum: sum[:]}})
+
+			s := &multipartObjectStorer{
+				straightStorer: &testPutEntity{
+					f: func(items ...interface{}) {
+						saved = append(saved, items[0].(transport.PutInfo).GetHead())
+					},
+					res: &addr,
+				},
+				executor: &testHeadExecutor{objs: objs},
+			}
+
+			return s.putObject(ctx, req)
+		}
+
+		_, err := complete()
+		require.EqualError(t, err, errUploadNotFound.Error())
+
+		objs[u.RecordID()] = &Object{SystemHeader: object.SystemHeader{ID: u.RecordID()}}
+
+		_, err = complete()
+		require.True(t, errors.Is(errors.Cause(err), multipart.ErrMissingPart))
+
+		for i := uint64(1); i <= 2; i++ {
+			objs[u.PartID(i)] = &Object{
+				SystemHeader: object.SystemHeader{ID: u.PartID(i), PayloadLength: i},
+				Headers: append(u.Part(i), object.Header{Value: &object.Header_HomoHash{
+					HomoHash: hash.Sum([]byte(strconv.FormatUint(i, 10))),
+				}}),
+			}
+		}
+
+		res, err := complete()
+		require.NoError(t, err)
+		require.Equal(t, addr, *res)
+
+		require.Len(t, saved, 2)
+		require.Equal(t, u.PartID(3), saved[0].SystemHeader.ID)
+		require.Equal(t, u.ID, saved[1].SystemHeader.ID)
+		require.Equal(t, []ID{u.PartID(1), u.PartID(2), u.PartID(3)}, saved[1].Links(object.Link_Child))
+	})
+}
@@ -89,29 +89,7 @@ func newPreProcessor(p *Params) requestPreProcessor {
 	preProcList := make([]requestPreProcessor, 0)
 
 	if p.CheckACL {
-		preProcList = append(preProcList, &aclPreProcessor{
-			log: p.Logger,
-
-			aclInfoReceiver: p.aclInfoReceiver,
-
-			reqActionCalc: p.requestActionCalculator,
-
-			localStore: p.LocalStore,
-
-			extACLSource: p.ExtendedACLSource,
-
-			bearerVerifier: &complexBearerVerifier{
-				items: []bearerTokenVerifier{
-					&bearerActualityVerifier{
-						epochRecv: p.EpochReceiver,
-					},
-					new(bearerSignatureVerifier),
-					&bearerOwnershipVerifier{
-						cnrStorage: p.ContainerStorage,
-					},
-				},
-			},
-		})
+		preProcList = append(preProcList, newACLPreProcessor(p))
 	}
 
 	preProcList = append(preProcList,
@@ -158,3 +136,31 @@ func newPreProcessor(p *Params) requestPreProcessor {
 		key:     p.Key,
 	}
 }
+
+// newACLPreProcessor creates requestPreProcessor that checks
+// the basic and extended ACL of the request.
+func newACLPreProcessor(p *Params) requestPreProcessor {
+	return &aclPreProcessor{
+		log: p.Logger,
+
+		aclInfoReceiver: p.aclInfoReceiver,
+
+		reqActionCalc: p.requestActionCalculator,
+
+		localStore: p.LocalStore,
+
+		extACLSource: p.ExtendedACLSource,
+
+		bearerVerifier: &complexBearerVerifier{
+			items: []bearerTokenVerifier{
+				&bearerActualityVerifier{
+					epochRecv: p.EpochReceiver,
+				},
+				new(bearerSignatureVerifier),
+				&bearerOwnershipVerifier{
+					cnrStorage: p.ContainerStorage,
+				},
+			},
+		},
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/nspcc-dev/neofs-api-go/service"
 	"github.com/nspcc-dev/neofs-api-go/session"
 	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/localstore"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/erasure"
 	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/transformer"
 	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/transport"
 	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/transport/storagegroup"
@@ -262,12 +263,18 @@ func (s *transformingObjectStorer) putObject(ctx context.Context, src transport.
 		}, func(ctx context.Context, unit transformer.ProcUnit) error {
 			res = unit.Head.Address()
 
+			copies := copyNum
+			if _, ok, _ := erasure.ParsePart(unit.Head); ok {
+				// erasure coded part is stored on the single node
+				copies = 1
+			}
+
 			putInfo := newRawPutInfo()
 			putInfo.setHead(unit.Head)
 			putInfo.setPayload(unit.Payload)
 			putInfo.setTimeout(timeout)
 			putInfo.setTTL(ttl)
-			putInfo.setCopiesNumber(copyNum)
+			putInfo.setCopiesNumber(copies)
 			putInfo.setSessionToken(token)
 			putInfo.setBearerToken(bearer)
 			putInfo.setExtendedHeaders(extHdrs)
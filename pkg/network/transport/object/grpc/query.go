@@ -8,6 +8,8 @@ import (
 	"github.com/nspcc-dev/neofs-api-go/object"
 	"github.com/nspcc-dev/neofs-api-go/query"
 	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/localstore"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/expiration"
+	v2 "github.com/nspcc-dev/neofs-node/pkg/services/object_manager/query"
 	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/transport"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
@@ -19,8 +21,17 @@ type (
 	}
 
 	coreQueryImposer struct {
-		fCreator filterCreator
-		lsLister localstore.Iterator
+		fCreator  filterCreator
+		lsLister  localstore.Iterator
+		epochRecv EpochReceiver
+
+		log *zap.Logger
+	}
+
+	// queryImposerV2 imposes the queries of version 2.
+	queryImposerV2 struct {
+		lsLister  localstore.Iterator
+		epochRecv EpochReceiver
 
 		log *zap.Logger
 	}
@@ -48,8 +59,22 @@ var (
 	_ filterCreator     = (*coreFilterCreator)(nil)
 	_ localQueryImposer = (*queryVersionController)(nil)
 	_ localQueryImposer = (*coreQueryImposer)(nil)
+	_ localQueryImposer = (*queryImposerV2)(nil)
 )
 
+// queryVersion returns the query version of the search operation.
+// Queries of the internal searches and of the requests without
+// the version are of version 1.
+func queryVersion(r transport.SearchInfo) int {
+	if src, ok := r.(transport.QueryVersionSource); ok {
+		if v := src.GetQueryVersion(); v != 0 {
+			return int(v)
+		}
+	}
+
+	return 1
+}
+
 func (s *queryVersionController) imposeQuery(ctx context.Context, c CID, d []byte, v int) ([]Address, error) {
 	imp := s.m[v]
 	if imp == nil {
@@ -60,17 +85,7 @@ func (s *queryVersionController) imposeQuery(ctx context.Context, c CID, d []byt
 }
 
 func (s *coreQueryImposer) imposeQuery(ctx context.Context, cid CID, qData []byte, _ int) (res []Address, err error) {
-	defer func() {
-		switch err {
-		case nil, errSearchQueryUnmarshal:
-		default:
-			s.log.Error("local query imposing failure",
-				zap.String("error", err.Error()),
-			)
-
-			err = errLocalQueryImpose
-		}
-	}()
+	defer func() { err = hideImposeError(s.log, err) }()
 
 	var q query.Query
 
@@ -84,10 +99,97 @@ func (s *coreQueryImposer) imposeQuery(ctx context.Context, cid CID, qData []byt
 		return
 	}
 
-	err = s.lsLister.Iterate(
-		s.fCreator.createFilter(q),
+	return searchLocal(s.lsLister, s.epochRecv.Epoch(), searchPageFromContext(ctx), indexFilters(q),
+		func(obj *Object) bool { return imposeQuery(q, obj) },
+		func() Filter { return s.fCreator.createFilter(q) },
+	)
+}
+
+func (s *queryImposerV2) imposeQuery(ctx context.Context, cid CID, qData []byte, _ int) (res []Address, err error) {
+	defer func() { err = hideImposeError(s.log, err) }()
+
+	var (
+		q     v2.Query
+		match v2.Matcher
+	)
+
+	if err = q.Unmarshal(qData); err == nil {
+		match, err = q.Compile()
+	}
+
+	if err != nil {
+		s.log.Error("could not unmarshal search query",
+			zap.String("error", err.Error()),
+		)
+
+		return nil, errSearchQueryUnmarshal
+	}
+
+	// objects of the other containers are not found
+	inContainer := func(obj *Object) bool {
+		return obj.SystemHeader.CID.Equal(cid) && match(obj)
+	}
+
+	ifs := append(indexFiltersV2(q.Filter), localstore.IndexFilter{
+		Attr:  localstore.IndexCID,
+		Value: cid.String(),
+	})
+
+	return searchLocal(s.lsLister, s.epochRecv.Epoch(), searchPageFromContext(ctx), ifs, inContainer,
+		func() Filter { return newMatchFilter(inContainer) },
+	)
+}
+
+// hideImposeError logs the local query failure and replaces it
+// with errLocalQueryImpose.
+func hideImposeError(log *zap.Logger, err error) error {
+	switch err {
+	case nil, errSearchQueryUnmarshal:
+		return err
+	default:
+		log.Error("local query imposing failure",
+			zap.String("error", err.Error()),
+		)
+
+		return errLocalQueryImpose
+	}
+}
+
+// searchLocal returns the page of the addresses of the matching objects
+// that are not expired in address order. Objects are preselected by the
+// index filters if localstore supports the indexes, otherwise they are
+// iterated with the filter.
+func searchLocal(ls localstore.Iterator, epoch uint64, page searchPage, ifs []localstore.IndexFilter,
+	match func(*Object) bool, filter func() Filter) ([]Address, error) {
+	res := &pageCollector{searchPage: page}
+
+	// indexes only preselect the objects, so the whole query is checked anyway
+	if searcher, ok := ls.(localstore.Searcher); ok && len(ifs) > 0 {
+		err := searcher.Search(ifs, func(meta *Meta) (stop bool) {
+			if match(meta.Object) && !expiration.Expired(meta.Object, epoch) {
+				res.add(Address{
+					CID:      meta.Object.SystemHeader.CID,
+					ObjectID: meta.Object.SystemHeader.ID,
+				})
+			}
+			return
+		})
+		if !errors.Is(errors.Cause(err), localstore.ErrIndexDisabled) {
+			return res.list(), err
+		}
+
+		res.reset()
+	}
+
+	err := ls.Iterate(
+		filter(),
 		func(meta *Meta) (stop bool) {
-			res = append(res, Address{
+			// expired objects are not found until GC removes them
+			if expiration.Expired(meta.Object, epoch) {
+				return
+			}
+
+			res.add(Address{
 				CID:      meta.Object.SystemHeader.CID,
 				ObjectID: meta.Object.SystemHeader.ID,
 			})
@@ -95,13 +197,108 @@ func (s *coreQueryImposer) imposeQuery(ctx context.Context, cid CID, qData []byt
 		},
 	)
 
-	return res, err
+	return res.list(), err
+}
+
+// indexFilters returns the conditions of the query
+// that can be checked through the localstore indexes.
+func indexFilters(q query.Query) []localstore.IndexFilter {
+	res := make([]localstore.IndexFilter, 0, len(q.Filters))
+
+	for i := range q.Filters {
+		f := q.Filters[i]
+
+		// presence filters ignore the value
+		switch f.Name {
+		case KeyRootObject:
+			res = append(res, localstore.IndexFilter{Attr: localstore.IndexRoot})
+			continue
+		case transport.KeyStorageGroup:
+			res = append(res, localstore.IndexFilter{Attr: localstore.IndexStorageGroup})
+			continue
+		}
+
+		if f.Type != query.Filter_Exact {
+			continue
+		}
+
+		switch f.Name {
+		case KeyCID:
+			res = append(res, localstore.IndexFilter{Attr: localstore.IndexCID, Value: f.Value})
+		case KeyOwnerID:
+			res = append(res, localstore.IndexFilter{Attr: localstore.IndexOwnerID, Value: f.Value})
+		case transport.KeyParent:
+			res = append(res, localstore.IndexFilter{Attr: localstore.IndexParent, Value: f.Value})
+		case KeyChild:
+			res = append(res, localstore.IndexFilter{Attr: localstore.IndexChild, Value: f.Value})
+		case KeyID, KeyPrev, KeyNext,
+			transport.KeyTombstone, transport.KeyNoChildren, transport.KeyHasParent:
+			// not indexed, checked in memory
+		default:
+			res = append(res, localstore.IndexFilter{
+				Attr:  localstore.IndexUserHeader,
+				Key:   f.Name,
+				Value: f.Value,
+			})
+		}
+	}
+
+	return res
+}
+
+// indexFiltersV2 returns the conditions of the query of version 2
+// that can be checked through the localstore indexes.
+func indexFiltersV2(f v2.Filter) []localstore.IndexFilter {
+	switch f.Op {
+	case v2.OpAnd:
+		var res []localstore.IndexFilter
+
+		for i := range f.Filters {
+			res = append(res, indexFiltersV2(f.Filters[i])...)
+		}
+
+		return res
+	case v2.OpExists:
+		switch f.Key {
+		case v2.KeyRoot:
+			return []localstore.IndexFilter{{Attr: localstore.IndexRoot}}
+		case v2.KeyStorageGroup:
+			return []localstore.IndexFilter{{Attr: localstore.IndexStorageGroup}}
+		}
+	case v2.OpEQ:
+		switch f.Key {
+		case v2.KeyCID:
+			return []localstore.IndexFilter{{Attr: localstore.IndexCID, Value: f.Value}}
+		case v2.KeyOwnerID:
+			return []localstore.IndexFilter{{Attr: localstore.IndexOwnerID, Value: f.Value}}
+		case v2.KeyParent:
+			return []localstore.IndexFilter{{Attr: localstore.IndexParent, Value: f.Value}}
+		case v2.KeyChild:
+			return []localstore.IndexFilter{{Attr: localstore.IndexChild, Value: f.Value}}
+		case v2.KeyID, v2.KeyPayloadLength, v2.KeyCreationEpoch, v2.KeyCreationTime,
+			v2.KeyPrev, v2.KeyNext, v2.KeyRoot, v2.KeyTombstone, v2.KeyStorageGroup:
+			// not indexed, checked in memory
+		default:
+			return []localstore.IndexFilter{{
+				Attr:  localstore.IndexUserHeader,
+				Key:   f.Key,
+				Value: f.Value,
+			}}
+		}
+	}
+
+	return nil
 }
 
 func (s *coreFilterCreator) createFilter(q query.Query) Filter {
+	return newMatchFilter(func(obj *Object) bool { return imposeQuery(q, obj) })
+}
+
+// newMatchFilter returns the filter that passes the matching objects.
+func newMatchFilter(match func(*Object) bool) Filter {
 	f, err := localstore.AllPassIncludingFilter(queryFilterName, &localstore.FilterParams{
 		FilterFunc: func(_ context.Context, o *Meta) *localstore.FilterResult {
-			if !imposeQuery(q, o.Object) {
+			if !match(o.Object) {
 				return localstore.ResultFail()
 			}
 			return localstore.ResultPass()
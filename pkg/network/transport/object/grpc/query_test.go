@@ -10,6 +10,7 @@ import (
 	"github.com/nspcc-dev/neofs-api-go/refs"
 	"github.com/nspcc-dev/neofs-api-go/storagegroup"
 	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/localstore"
+	v2 "github.com/nspcc-dev/neofs-node/pkg/services/object_manager/query"
 	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/transport"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
@@ -212,9 +213,10 @@ func Test_coreQueryImposer_imposeQuery(t *testing.T) {
 
 			// create test query imposer with mocked always failing lister
 			qImposer := &coreQueryImposer{
-				fCreator: new(coreFilterCreator),
-				lsLister: &testQueryEntity{err: lsErr},
-				log:      log,
+				fCreator:  new(coreFilterCreator),
+				lsLister:  &testQueryEntity{err: lsErr},
+				epochRecv: &testExecutionEntity{res: uint64(0)},
+				log:       log,
 			}
 
 			// try to impose testQuery
@@ -243,7 +245,8 @@ func Test_coreQueryImposer_imposeQuery(t *testing.T) {
 					},
 					err: errors.New(""),
 				},
-				log: log,
+				epochRecv: &testExecutionEntity{res: uint64(0)},
+				log:       log,
 			}
 
 			_, _ = qImposer.imposeQuery(ctx, obj.SystemHeader.CID, qBytes, v)
@@ -266,10 +269,22 @@ func Test_coreQueryImposer_imposeQuery(t *testing.T) {
 				})
 			}
 
+			// expired object is not found
+			expired := testExpiringObject(1)
+			expired.SystemHeader = SystemHeader{
+				ID:  addrList[0].ObjectID,
+				CID: addrList[0].CID,
+			}
+
+			items = append(items, localstore.ListItem{
+				ObjectMeta: Meta{Object: expired},
+			})
+
 			// create imposer with mocked lister
 			qImposer := &coreQueryImposer{
-				fCreator: new(coreFilterCreator),
-				lsLister: &testQueryEntity{res: items},
+				fCreator:  new(coreFilterCreator),
+				lsLister:  &testQueryEntity{res: items},
+				epochRecv: &testExecutionEntity{res: uint64(2)},
 			}
 
 			// try to impose testQuery
@@ -826,3 +841,152 @@ func testFullObjectWithQuery(t *testing.T) (query.Query, *Object) {
 
 	return query, obj
 }
+
+type testIndexSearcher struct {
+	testQueryEntity
+
+	ifs []localstore.IndexFilter
+}
+
+func (s *testIndexSearcher) Search(ifs []localstore.IndexFilter, h localstore.MetaHandler) error {
+	s.ifs = ifs
+
+	for _, item := range s.res.([]localstore.ListItem) {
+		h(&item.ObjectMeta)
+	}
+
+	return nil
+}
+
+func Test_queryImposerV2_imposeQuery(t *testing.T) {
+	ctx := context.TODO()
+	log := zap.L()
+
+	addrList := testAddrList(t, 3)
+	cid := addrList[0].CID
+
+	addrList[1].CID = cid
+
+	// object of the other container is not found
+	addrList[2].CID[0] = cid[0] + 1
+
+	items := make([]localstore.ListItem, 0, len(addrList))
+	for i := range addrList {
+		items = append(items, localstore.ListItem{
+			ObjectMeta: Meta{
+				Object: &Object{
+					SystemHeader: SystemHeader{
+						ID:            addrList[i].ObjectID,
+						CID:           addrList[i].CID,
+						PayloadLength: uint64(i),
+					},
+				},
+			},
+		})
+	}
+
+	q := v2.Query{Filter: v2.Field(v2.OpLE, v2.KeyPayloadLength, "1")}
+
+	qData, err := q.Marshal()
+	require.NoError(t, err)
+
+	t.Run("query unmarshal failure", func(t *testing.T) {
+		s := &queryImposerV2{log: log}
+
+		for _, data := range [][]byte{
+			[]byte("{"),
+			[]byte(`{"filter":{"op":"LIKE"}}`),
+		} {
+			res, err := s.imposeQuery(ctx, cid, data, v2.Version)
+			require.EqualError(t, err, errSearchQueryUnmarshal.Error())
+			require.Nil(t, res)
+		}
+	})
+
+	t.Run("filter", func(t *testing.T) {
+		var f Filter
+
+		s := &queryImposerV2{
+			lsLister: &testQueryEntity{
+				f: func(p ...interface{}) {
+					f = p[0].(Filter)
+				},
+				res: []localstore.ListItem{},
+			},
+			epochRecv: &testExecutionEntity{res: uint64(0)},
+			log:       log,
+		}
+
+		_, err := s.imposeQuery(ctx, cid, qData, v2.Version)
+		require.NoError(t, err)
+
+		for i, exp := range []localstore.FilterCode{localstore.CodePass, localstore.CodePass, localstore.CodeFail} {
+			require.Equal(t, exp, f.Pass(ctx, &items[i].ObjectMeta).Code())
+		}
+
+		items[1].ObjectMeta.Object.SystemHeader.PayloadLength = 2
+		defer func() { items[1].ObjectMeta.Object.SystemHeader.PayloadLength = 1 }()
+
+		require.Equal(t, localstore.CodeFail, f.Pass(ctx, &items[1].ObjectMeta).Code())
+	})
+
+	t.Run("indexes", func(t *testing.T) {
+		searcher := &testIndexSearcher{
+			testQueryEntity: testQueryEntity{res: items},
+		}
+
+		s := &queryImposerV2{
+			lsLister:  searcher,
+			epochRecv: &testExecutionEntity{res: uint64(0)},
+			log:       log,
+		}
+
+		// addresses are returned in address order
+		exp := append([]Address{}, addrList[:2]...)
+		sortAddresses(exp)
+
+		res, err := s.imposeQuery(ctx, cid, qData, v2.Version)
+		require.NoError(t, err)
+		require.Equal(t, exp, res)
+		require.Equal(t, []localstore.IndexFilter{{Attr: localstore.IndexCID, Value: cid.String()}}, searcher.ifs)
+
+		// next page starts after the last address of the previous one
+		res, err = s.imposeQuery(withSearchPage(ctx, searchPage{after: &exp[0], limit: 1}), cid, qData, v2.Version)
+		require.NoError(t, err)
+		require.Equal(t, exp[1:], res)
+	})
+}
+
+func Test_indexFiltersV2(t *testing.T) {
+	f := v2.And(
+		v2.Field(v2.OpEQ, "Tag", "a"),
+		v2.Field(v2.OpEQ, v2.KeyOwnerID, "owner"),
+		v2.Field(v2.OpEQ, v2.KeyPayloadLength, "10"),
+		v2.Field(v2.OpPrefix, "Name", "a"),
+		v2.Exists(v2.KeyRoot),
+		v2.Or(v2.Field(v2.OpEQ, "Tag", "b"), v2.Field(v2.OpEQ, "Tag", "c")),
+		v2.And(v2.Field(v2.OpEQ, v2.KeyParent, "parent")),
+	)
+
+	require.Equal(t, []localstore.IndexFilter{
+		{Attr: localstore.IndexUserHeader, Key: "Tag", Value: "a"},
+		{Attr: localstore.IndexOwnerID, Value: "owner"},
+		{Attr: localstore.IndexRoot},
+		{Attr: localstore.IndexParent, Value: "parent"},
+	}, indexFiltersV2(f))
+
+	require.Empty(t, indexFiltersV2(v2.Not(v2.Field(v2.OpEQ, "Tag", "a"))))
+}
+
+func Test_queryVersion(t *testing.T) {
+	req := func(v uint32) transport.SearchInfo {
+		return &transportRequest{serviceRequest: &object.SearchRequest{QueryVersion: v}}
+	}
+
+	require.Equal(t, 1, queryVersion(req(0)))
+	require.Equal(t, 1, queryVersion(req(1)))
+	require.Equal(t, v2.Version, queryVersion(req(v2.Version)))
+
+	// internal searches carry the queries of version 1
+	require.Equal(t, 1, queryVersion(newRawSearchInfo()))
+}
@@ -63,13 +63,6 @@ func (s *epochResponsePreparer) prepareResponse(_ context.Context, req serviceRe
 }
 
 func (s *aclResponsePreparer) prepareResponse(ctx context.Context, req serviceRequest, resp serviceResponse) error {
-	aclInfo, err := s.aclInfoReceiver.getACLInfo(ctx, req)
-	if err != nil {
-		return errAccessDenied
-	} else if !aclInfo.checkBearer && !aclInfo.checkExtended {
-		return nil
-	}
-
 	var obj *Object
 
 	switch r := resp.(type) {
@@ -83,7 +76,16 @@ func (s *aclResponsePreparer) prepareResponse(ctx context.Context, req serviceRe
 		obj = r.GetObject()
 	}
 
-	if obj == nil {
+	return s.checkObject(ctx, req, obj)
+}
+
+// checkObject checks the extended ACL of the request on the object headers.
+// Nil object passes the check if the container ACL is received.
+func (s *aclResponsePreparer) checkObject(ctx context.Context, req serviceRequest, obj *Object) error {
+	aclInfo, err := s.aclInfoReceiver.getACLInfo(ctx, req)
+	if err != nil {
+		return errAccessDenied
+	} else if !aclInfo.checkBearer && !aclInfo.checkExtended || obj == nil {
 		return nil
 	}
 
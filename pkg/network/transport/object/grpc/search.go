@@ -4,10 +4,12 @@ import (
 	"context"
 	"sync"
 
+	"github.com/multiformats/go-multiaddr"
 	"github.com/nspcc-dev/neofs-api-go/object"
 	v1 "github.com/nspcc-dev/neofs-api-go/query"
 	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/transport"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/metadata"
 )
 
 // QueryFilter is a type alias of
@@ -42,10 +44,49 @@ type (
 		searchObjects(context.Context, transport.SearchInfo) ([]Address, error)
 	}
 
+	// objectSearchStreamer is an interface of the search operation
+	// that passes the results to the handler as the nodes return them.
+	objectSearchStreamer interface {
+		streamObjects(context.Context, transport.SearchInfo, responseItemHandler) error
+	}
+
 	coreObjectSearcher struct {
 		executor operationExecutor
 	}
 
+	searchRequest struct {
+		*object.SearchRequest
+		srv object.Service_SearchServer
+	}
+
+	// searchStream sends the search results to the client in batches
+	// as the container nodes return them. Addresses are deduplicated
+	// within the batch only, so memory usage does not depend on the
+	// whole result size: the same address stored by several nodes
+	// can be sent several times.
+	searchStream struct {
+		*sync.Mutex
+
+		ctx  context.Context
+		req  serviceRequest
+		srv  object.Service_SearchServer
+		prep responsePreparer
+
+		limit int
+
+		// cursor of the current page
+		cur *searchCursor
+
+		// cursor of the next page
+		next *searchCursor
+
+		// true if the cursor of the next page is updated
+		paged bool
+
+		sent int
+		err  error
+	}
+
 	// objectAddressSet is and interface of object address set.
 	objectAddressSet interface {
 		responseItemHandler
@@ -67,9 +108,13 @@ type (
 var addrPerMsg = int64(maxGetPayloadSize / new(Address).Size())
 
 var (
-	_ transport.SearchInfo = (*transportRequest)(nil)
-	_ objectSearcher       = (*coreObjectSearcher)(nil)
-	_ objectAddressSet     = (*coreObjAddrSet)(nil)
+	_ transport.SearchInfo         = (*transportRequest)(nil)
+	_ transport.QueryVersionSource = (*transportRequest)(nil)
+	_ objectSearcher               = (*coreObjectSearcher)(nil)
+	_ objectSearchStreamer         = (*coreObjectSearcher)(nil)
+	_ objectAddressSet             = (*coreObjAddrSet)(nil)
+	_ nodeItemHandler              = (*searchStream)(nil)
+	_ nodeFailureHandler           = (*searchStream)(nil)
 )
 
 func (s *transportRequest) GetCID() CID { return s.serviceRequest.(*object.SearchRequest).CID() }
@@ -78,6 +123,10 @@ func (s *transportRequest) GetQuery() []byte {
 	return s.serviceRequest.(*object.SearchRequest).GetQuery()
 }
 
+func (s *transportRequest) GetQueryVersion() uint32 {
+	return s.serviceRequest.(*object.SearchRequest).GetQueryVersion()
+}
+
 func (s *objectService) Search(req *object.SearchRequest, srv object.Service_SearchServer) (err error) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -95,36 +144,46 @@ func (s *objectService) Search(req *object.SearchRequest, srv object.Service_Sea
 		})
 	}()
 
-	var r interface{}
-
-	if r, err = s.requestHandler.handleRequest(srv.Context(), handleRequestParams{
-		request:  req,
+	_, err = s.requestHandler.handleRequest(srv.Context(), handleRequestParams{
+		request: &searchRequest{
+			SearchRequest: req,
+			srv:           srv,
+		},
 		executor: s,
-	}); err != nil {
-		return err
-	}
+	})
 
-	addrList := r.([]Address)
+	return err
+}
 
-	for {
-		cut := min(int64(len(addrList)), addrPerMsg)
+// streamSearch sends the search results to the request stream. The cursor
+// of the next page of the paged search is set to the response trailer.
+func (s *objectService) streamSearch(ctx context.Context, req *searchRequest) error {
+	sInfo := &transportRequest{
+		serviceRequest: req.SearchRequest,
+		timeout:        s.pSrch.Timeout,
+	}
 
-		resp := makeSearchResponse(addrList[:cut])
-		if err = s.respPreparer.prepareResponse(srv.Context(), req, resp); err != nil {
-			return
-		}
+	cur, limit, err := parseSearchPaging(sInfo.ExtendedHeaders())
+	if err != nil {
+		return err
+	}
 
-		if err = srv.Send(resp); err != nil {
-			return
-		}
+	stream := &searchStream{
+		Mutex: new(sync.Mutex),
+		ctx:   ctx,
+		req:   req.SearchRequest,
+		srv:   req.srv,
+		prep:  s.respPreparer,
+		limit: limit,
+		cur:   cur,
+		next:  cur.clone(),
+	}
 
-		addrList = addrList[cut:]
-		if len(addrList) == 0 {
-			break
-		}
+	if err := s.objStreamer.streamObjects(ctx, sInfo, stream); err != nil {
+		return err
 	}
 
-	return err
+	return stream.close()
 }
 
 func (s *coreObjectSearcher) searchObjects(ctx context.Context, sInfo transport.SearchInfo) ([]Address, error) {
@@ -136,6 +195,114 @@ func (s *coreObjectSearcher) searchObjects(ctx context.Context, sInfo transport.
 	return addrSet.list(), nil
 }
 
+func (s *coreObjectSearcher) streamObjects(ctx context.Context, sInfo transport.SearchInfo, h responseItemHandler) error {
+	return s.executor.executeOperation(ctx, sInfo, h)
+}
+
+func (s *searchStream) handleItem(v interface{}) {
+	s.Lock()
+	s.send(v.([]Address))
+	s.Unlock()
+}
+
+func (s *searchStream) handleNodeItem(node multiaddr.Multiaddr, v interface{}) {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.limit == 0 {
+		s.send(v.([]Address))
+		return
+	}
+
+	// the nodes that do not support paging return all the results
+	page := &pageCollector{searchPage: s.cur.page(node.String(), s.limit)}
+	for _, addr := range v.([]Address) {
+		page.add(addr)
+	}
+
+	list := page.list()
+
+	s.next.next(node.String(), list, s.limit)
+	s.paged = true
+
+	s.send(list)
+}
+
+// handleNodeFailure keeps the position of the failed node in the cursor
+// of the next page, so its results are requested again on the next page.
+func (s *searchStream) handleNodeFailure(node multiaddr.Multiaddr) {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.limit == 0 {
+		return
+	}
+
+	s.next.retry(node.String())
+	s.paged = true
+}
+
+// send sends the unique addresses of the list in the batches
+// of no more than addrPerMsg addresses.
+func (s *searchStream) send(list []Address) {
+	if s.err != nil {
+		return
+	}
+
+	var (
+		batch = make([]Address, 0, len(list))
+		seen  = make(map[Address]struct{}, len(list))
+	)
+
+	for i := range list {
+		if _, ok := seen[list[i]]; !ok {
+			seen[list[i]] = struct{}{}
+			batch = append(batch, list[i])
+		}
+	}
+
+	for len(batch) > 0 && s.err == nil {
+		cut := min(int64(len(batch)), addrPerMsg)
+
+		s.err = s.sendResponse(batch[:cut])
+
+		batch = batch[cut:]
+	}
+}
+
+func (s *searchStream) sendResponse(list []Address) error {
+	resp := makeSearchResponse(list)
+	if err := s.prep.prepareResponse(s.ctx, s.req, resp); err != nil {
+		return err
+	}
+
+	s.sent++
+
+	return s.srv.Send(resp)
+}
+
+// close finishes the stream with the empty response if nothing was found
+// and sets the cursor of the next page to the trailer if the search
+// is not complete.
+func (s *searchStream) close() error {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.err != nil {
+		return s.err
+	} else if s.sent == 0 {
+		if err := s.sendResponse(nil); err != nil {
+			return err
+		}
+	}
+
+	if s.paged && !s.next.complete() {
+		s.srv.SetTrailer(metadata.Pairs(SearchCursorTrailer, s.next.encode()))
+	}
+
+	return nil
+}
+
 func newUniqueAddressAccumulator() objectAddressSet {
 	return &coreObjAddrSet{
 		RWMutex: new(sync.RWMutex),
@@ -0,0 +1,253 @@
+package object
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"sort"
+	"strconv"
+
+	"github.com/nspcc-dev/neofs-api-go/refs"
+	"github.com/nspcc-dev/neofs-api-go/service"
+	"github.com/pkg/errors"
+)
+
+type (
+	// searchCursor is a position of the paged search.
+	//
+	// Cursor stores the last address returned by each container node.
+	// Nil position means that the node returned all the results,
+	// the nodes absent in the cursor start from the beginning. Zero
+	// address precedes all the addresses, so it is the position of
+	// the node that failed the first page.
+	searchCursor struct {
+		nodes map[string]*Address
+	}
+
+	// searchPage is a part of the local search results in address order.
+	searchPage struct {
+		// last address of the previous page, nil for the first page
+		after *Address
+
+		// true if the node returned all the results
+		done bool
+
+		// maximum number of addresses, 0 if unlimited
+		limit int
+	}
+
+	// pageCollector accumulates the addresses of the search page.
+	pageCollector struct {
+		searchPage
+
+		items []Address
+	}
+)
+
+const (
+	// SearchCursorHeader is a request extended header key of the search
+	// continuation cursor. Cursor is taken from SearchCursorTrailer
+	// of the previous Search response.
+	SearchCursorHeader = "SEARCH_CURSOR"
+
+	// SearchLimitHeader is a request extended header key of the maximum
+	// number of the addresses returned by each container node.
+	// Search results are not paged if the limit is not set.
+	SearchLimitHeader = "SEARCH_LIMIT"
+
+	// SearchCursorTrailer is a response trailer key of the search
+	// continuation cursor. Trailer is not set if the search is complete.
+	SearchCursorTrailer = "neofs-search-cursor"
+
+	searchPageValue = "SEARCH_PAGE"
+)
+
+var errInvalidSearchPage = errors.New("invalid search page")
+
+// parseSearchPaging returns the cursor and the limit of the search
+// from the request extended headers. Nil cursor is returned for
+// the first page.
+func parseSearchPaging(hs []service.ExtendedHeader) (cur *searchCursor, limit int, err error) {
+	for i := range hs {
+		if hs[i] == nil {
+			continue
+		}
+
+		switch hs[i].Key() {
+		case SearchCursorHeader:
+			if cur, err = decodeSearchCursor(hs[i].Value()); err != nil {
+				return nil, 0, err
+			}
+		case SearchLimitHeader:
+			if limit, err = strconv.Atoi(hs[i].Value()); err != nil || limit < 0 {
+				return nil, 0, errors.Wrapf(errInvalidSearchPage, "invalid limit %s", hs[i].Value())
+			}
+		}
+	}
+
+	return cur, limit, nil
+}
+
+func newSearchCursor() *searchCursor {
+	return &searchCursor{nodes: make(map[string]*Address)}
+}
+
+func decodeSearchCursor(s string) (*searchCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errors.Wrap(errInvalidSearchPage, err.Error())
+	}
+
+	var nodes map[string]string
+
+	if err := json.Unmarshal(data, &nodes); err != nil {
+		return nil, errors.Wrap(errInvalidSearchPage, err.Error())
+	}
+
+	res := newSearchCursor()
+
+	for node, pos := range nodes {
+		if pos == "" {
+			res.nodes[node] = nil
+			continue
+		}
+
+		addr, err := refs.ParseAddress(pos)
+		if err != nil {
+			return nil, errors.Wrapf(errInvalidSearchPage, "invalid position of node %s", node)
+		}
+
+		res.nodes[node] = &addr
+	}
+
+	return res, nil
+}
+
+// encode returns the opaque string representation of the cursor.
+func (c *searchCursor) encode() string {
+	nodes := make(map[string]string, len(c.nodes))
+
+	for node, pos := range c.nodes {
+		if pos != nil {
+			nodes[node] = pos.String()
+		} else {
+			nodes[node] = ""
+		}
+	}
+
+	// map of strings is always encoded
+	data, _ := json.Marshal(nodes)
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// page returns the next page of the node.
+func (c *searchCursor) page(node string, limit int) searchPage {
+	res := searchPage{limit: limit}
+
+	if c != nil {
+		if pos, ok := c.nodes[node]; ok {
+			res.after, res.done = pos, pos == nil
+		}
+	}
+
+	return res
+}
+
+// next moves the node position after the returned page.
+func (c *searchCursor) next(node string, page []Address, limit int) {
+	if limit > 0 && len(page) >= limit {
+		last := page[len(page)-1]
+		c.nodes[node] = &last
+	} else {
+		c.nodes[node] = nil
+	}
+}
+
+// retry keeps the node position, so the page of the failed node is
+// requested again. The node that has no position starts from the
+// beginning on the next page.
+func (c *searchCursor) retry(node string) {
+	if _, ok := c.nodes[node]; !ok {
+		c.nodes[node] = new(Address)
+	}
+}
+
+// complete returns true if all the nodes returned all the results.
+func (c *searchCursor) complete() bool {
+	for _, pos := range c.nodes {
+		if pos != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (c *searchCursor) clone() *searchCursor {
+	res := newSearchCursor()
+
+	if c != nil {
+		for node, pos := range c.nodes {
+			res.nodes[node] = pos
+		}
+	}
+
+	return res
+}
+
+func withSearchPage(ctx context.Context, p searchPage) context.Context {
+	return context.WithValue(ctx, searchPageValue, p)
+}
+
+// searchPageFromContext returns the search page from the context,
+// the whole result is a single page if the context has no page.
+func searchPageFromContext(ctx context.Context) searchPage {
+	p, _ := ctx.Value(searchPageValue).(searchPage)
+	return p
+}
+
+func addressLess(a, b Address) bool {
+	if c := bytes.Compare(a.CID[:], b.CID[:]); c != 0 {
+		return c < 0
+	}
+
+	return bytes.Compare(a.ObjectID[:], b.ObjectID[:]) < 0
+}
+
+func sortAddresses(list []Address) {
+	sort.Slice(list, func(i, j int) bool { return addressLess(list[i], list[j]) })
+}
+
+func (c *pageCollector) add(addr Address) {
+	if c.done || c.after != nil && !addressLess(*c.after, addr) {
+		return
+	}
+
+	c.items = append(c.items, addr)
+
+	// keep no more than twice the limit in memory
+	if c.limit > 0 && len(c.items) >= 2*c.limit {
+		c.trim()
+	}
+}
+
+func (c *pageCollector) trim() {
+	sortAddresses(c.items)
+
+	if c.limit > 0 && len(c.items) > c.limit {
+		c.items = c.items[:c.limit]
+	}
+}
+
+func (c *pageCollector) reset() {
+	c.items = nil
+}
+
+// list returns the sorted addresses of the page.
+func (c *pageCollector) list() []Address {
+	c.trim()
+
+	return c.items
+}
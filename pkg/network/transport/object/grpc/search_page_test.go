@@ -0,0 +1,138 @@
+package object
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nspcc-dev/neofs-api-go/object"
+	"github.com/nspcc-dev/neofs-api-go/service"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// testSearchHeaders returns the extended headers with non-empty search cursor and limit.
+func testSearchHeaders(cursor, limit string) []service.RequestExtendedHeader_KV {
+	var res []service.RequestExtendedHeader_KV
+
+	for k, v := range map[string]string{
+		SearchCursorHeader: cursor,
+		SearchLimitHeader:  limit,
+	} {
+		if v == "" {
+			continue
+		}
+
+		h := service.RequestExtendedHeader_KV{}
+		h.SetK(k)
+		h.SetV(v)
+
+		res = append(res, h)
+	}
+
+	return res
+}
+
+func Test_searchCursor(t *testing.T) {
+	var (
+		n1, n2, n3 = testNode(t, 1).String(), testNode(t, 2).String(), testNode(t, 3).String()
+		addrList   = testAddrList(t, 3)
+	)
+
+	sortAddresses(addrList)
+
+	cur := newSearchCursor()
+	cur.next(n1, addrList[:2], 2)
+	cur.next(n2, addrList[:1], 2)
+
+	require.False(t, cur.complete())
+
+	res, err := decodeSearchCursor(cur.encode())
+	require.NoError(t, err)
+	require.Equal(t, cur, res)
+
+	require.Equal(t, searchPage{after: &addrList[1], limit: 2}, res.page(n1, 2))
+	require.Equal(t, searchPage{done: true, limit: 2}, res.page(n2, 2))
+	require.Equal(t, searchPage{limit: 2}, res.page(n3, 2))
+
+	res.next(n1, addrList[2:], 2)
+	require.True(t, res.complete())
+
+	// failed node keeps the search incomplete
+	res.retry(n2)
+	res.retry(n3)
+	require.False(t, res.complete())
+	require.Equal(t, searchPage{done: true, limit: 2}, res.page(n2, 2))
+	require.Equal(t, searchPage{after: new(Address), limit: 2}, res.page(n3, 2))
+
+	res, err = decodeSearchCursor(res.encode())
+	require.NoError(t, err)
+	require.Equal(t, searchPage{after: new(Address), limit: 2}, res.page(n3, 2))
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		for _, s := range []string{
+			"!",
+			"WyJhIl0",      // ["a"]
+			"eyJhIjoiYiJ9", // {"a":"b"}
+		} {
+			_, err := decodeSearchCursor(s)
+			require.True(t, errors.Is(errors.Cause(err), errInvalidSearchPage), s)
+		}
+	})
+}
+
+func Test_parseSearchPaging(t *testing.T) {
+	headers := func(cursor, limit string) []service.ExtendedHeader {
+		req := new(object.SearchRequest)
+		req.SetHeaders(testSearchHeaders(cursor, limit))
+
+		return req.ExtendedHeaders()
+	}
+
+	res, limit, err := parseSearchPaging(headers("", ""))
+	require.NoError(t, err)
+	require.Nil(t, res)
+	require.Zero(t, limit)
+
+	for _, hs := range [][]service.ExtendedHeader{
+		headers("", "-1"),
+		headers("", "ten"),
+		headers("!", "1"),
+	} {
+		_, _, err := parseSearchPaging(hs)
+		require.True(t, errors.Is(errors.Cause(err), errInvalidSearchPage))
+	}
+
+	cur := newSearchCursor()
+	cur.next(testNode(t, 1).String(), testAddrList(t, 1), 1)
+
+	res, limit, err = parseSearchPaging(headers(cur.encode(), "1"))
+	require.NoError(t, err)
+	require.Equal(t, cur, res)
+	require.Equal(t, 1, limit)
+}
+
+func Test_pageCollector(t *testing.T) {
+	addrList := testAddrList(t, 10)
+
+	sorted := append([]Address{}, addrList...)
+	sortAddresses(sorted)
+
+	collect := func(p searchPage) []Address {
+		c := &pageCollector{searchPage: p}
+		for i := range addrList {
+			c.add(addrList[i])
+		}
+
+		return c.list()
+	}
+
+	require.Equal(t, sorted, collect(searchPage{}))
+	require.Equal(t, sorted[:3], collect(searchPage{limit: 3}))
+	require.Equal(t, sorted[4:7], collect(searchPage{after: &sorted[3], limit: 3}))
+	require.Equal(t, sorted[8:], collect(searchPage{after: &sorted[7], limit: 3}))
+	require.Empty(t, collect(searchPage{done: true}))
+
+	ctx := withSearchPage(context.TODO(), searchPage{limit: 3})
+	require.Equal(t, searchPage{limit: 3}, searchPageFromContext(ctx))
+	require.Equal(t, searchPage{}, searchPageFromContext(context.TODO()))
+}
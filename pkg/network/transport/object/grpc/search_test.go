@@ -9,6 +9,7 @@ import (
 	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/transport"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
 )
 
 type (
@@ -50,6 +51,12 @@ func (s *testSearchEntity) Send(r *object.SearchResponse) error {
 	return s.err
 }
 
+func (s *testSearchEntity) SetTrailer(md metadata.MD) {
+	if s.f != nil {
+		s.f(md)
+	}
+}
+
 func (s *testSearchEntity) Context() context.Context { return context.TODO() }
 
 func (s *testSearchEntity) executeOperation(_ context.Context, p transport.MetaInfo, h responseItemHandler) error {
@@ -137,89 +144,225 @@ func TestObjectService_Search(t *testing.T) {
 		Query:       testData(t, 10),
 	}
 
+	rhErr := errors.New("test error for request handler")
+	s := &objectService{
+		statusCalculator: newStatusCalculator(),
+	}
+
+	srv := new(testSearchEntity)
+
+	s.requestHandler = &testSearchEntity{
+		f: func(items ...interface{}) {
+			p := items[0].(handleRequestParams)
+			require.Equal(t, &searchRequest{SearchRequest: req, srv: srv}, p.request)
+			require.Equal(t, s, p.executor)
+		},
+		err: rhErr,
+	}
+
+	require.EqualError(t, s.Search(req, srv), rhErr.Error())
+}
+
+func Test_objectService_streamSearch(t *testing.T) {
+	ctx := context.TODO()
+
+	req := &object.SearchRequest{
+		ContainerID: testObjectAddress(t).CID,
+		Query:       testData(t, 10),
+	}
+
 	addrList := testAddrList(t, int(addrPerMsg)+5)
 
-	t.Run("request handler failure", func(t *testing.T) {
-		rhErr := errors.New("test error for request handler")
-		s := &objectService{
-			statusCalculator: newStatusCalculator(),
+	// newService returns the service with the executor that passes the lists to the handler
+	newService := func(lists ...[]Address) *objectService {
+		return &objectService{
+			objStreamer: &coreObjectSearcher{
+				executor: &testSearchEntity{
+					f: func(items ...interface{}) {
+						for i := range lists {
+							items[1].(responseItemHandler).handleItem(lists[i])
+						}
+					},
+				},
+			},
+			respPreparer: new(testSearchEntity),
 		}
+	}
 
-		s.requestHandler = &testSearchEntity{
+	t.Run("server error", func(t *testing.T) {
+		srvErr := errors.New("test error for search server")
+
+		s := newService(addrList)
+
+		srv := &testSearchEntity{
 			f: func(items ...interface{}) {
-				p := items[0].(handleRequestParams)
-				require.Equal(t, req, p.request)
-				require.Equal(t, s, p.executor)
+				require.Equal(t, makeSearchResponse(addrList[:addrPerMsg]), items[0])
 			},
-			err: rhErr,
+			err: srvErr, // force server to return srvErr
 		}
 
-		require.EqualError(t, s.Search(req, new(testSearchEntity)), rhErr.Error())
+		require.EqualError(t, s.streamSearch(ctx, &searchRequest{SearchRequest: req, srv: srv}), srvErr.Error())
 	})
 
-	t.Run("server error", func(t *testing.T) {
-		srvErr := errors.New("test error for search server")
+	t.Run("correct result", func(t *testing.T) {
+		// addresses are deduplicated within the batch
+		s := newService(append(addrList[:3:3], addrList[0]), addrList)
 
-		resp := &object.SearchResponse{Addresses: addrList[:addrPerMsg]}
+		var res []*object.SearchResponse
 
-		s := &objectService{
-			requestHandler: &testSearchEntity{
-				res: addrList,
-			},
-			respPreparer: &testSearchEntity{
-				f: func(items ...interface{}) {
-					require.Equal(t, req, items[0])
-					require.Equal(t, makeSearchResponse(addrList[:addrPerMsg]), items[1])
-				},
-				res: resp,
+		srv := &testSearchEntity{
+			f: func(items ...interface{}) {
+				res = append(res, items[0].(*object.SearchResponse))
 			},
-
-			statusCalculator: newStatusCalculator(),
 		}
 
+		require.NoError(t, s.streamSearch(ctx, &searchRequest{SearchRequest: req, srv: srv}))
+		require.Equal(t, []*object.SearchResponse{
+			makeSearchResponse(addrList[:3]),
+			makeSearchResponse(addrList[:addrPerMsg]),
+			makeSearchResponse(addrList[addrPerMsg:]),
+		}, res)
+	})
+
+	t.Run("empty result", func(t *testing.T) {
+		s := newService()
+
+		var res []*object.SearchResponse
+
 		srv := &testSearchEntity{
 			f: func(items ...interface{}) {
-				require.Equal(t, resp, items[0])
+				res = append(res, items[0].(*object.SearchResponse))
 			},
-			err: srvErr, // force server to return srvErr
 		}
 
-		require.EqualError(t, s.Search(req, srv), srvErr.Error())
+		require.NoError(t, s.streamSearch(ctx, &searchRequest{SearchRequest: req, srv: srv}))
+		require.Equal(t, []*object.SearchResponse{makeSearchResponse(nil)}, res)
 	})
 
-	t.Run("correct result", func(t *testing.T) {
-		handler := &testSearchEntity{res: make([]Address, 0)}
+	t.Run("invalid limit", func(t *testing.T) {
+		req := &object.SearchRequest{ContainerID: req.ContainerID}
+		req.SetHeaders(testSearchHeaders("", "-1"))
+
+		err := newService().streamSearch(ctx, &searchRequest{SearchRequest: req, srv: new(testSearchEntity)})
+		require.True(t, errors.Is(errors.Cause(err), errInvalidSearchPage))
+	})
+
+	t.Run("pages", func(t *testing.T) {
+		var (
+			n1, n2 = testNode(t, 1), testNode(t, 2)
+			list   = append([]Address{}, addrList[:3]...)
+			cursor string
+		)
 
-		off := 0
+		sortAddresses(list)
 
-		var resp *object.SearchResponse
+		// search returns the next page of the nodes and the cursor of the next page
+		search := func(t *testing.T, exp []Address) {
+			req := &object.SearchRequest{ContainerID: req.ContainerID}
+			req.SetHeaders(testSearchHeaders(cursor, "2"))
 
-		s := &objectService{
-			requestHandler: handler,
-			respPreparer: &testSearchEntity{
+			first := cursor == ""
+
+			s := newService()
+			s.objStreamer = &coreObjectSearcher{
+				executor: &testSearchEntity{
+					f: func(items ...interface{}) {
+						h := items[1].(nodeItemHandler)
+
+						// first node does not support paging
+						h.handleNodeItem(n1, []Address{list[2], list[0], list[1]})
+
+						// second node returned all the results on the first page
+						if first {
+							h.handleNodeItem(n2, list[:1])
+						}
+					},
+				},
+			}
+
+			var res []Address
+
+			cursor = ""
+
+			srv := &testSearchEntity{
 				f: func(items ...interface{}) {
-					require.Equal(t, req, items[0])
-					resp = items[1].(*object.SearchResponse)
-					sz := len(resp.GetAddresses())
-					require.Equal(t, makeSearchResponse(addrList[off:off+sz]), items[1])
-					off += sz
+					switch v := items[0].(type) {
+					case *object.SearchResponse:
+						res = append(res, v.Addresses...)
+					case metadata.MD:
+						cursor = v.Get(SearchCursorTrailer)[0]
+					}
 				},
-			},
+			}
 
-			statusCalculator: newStatusCalculator(),
+			require.NoError(t, s.streamSearch(ctx, &searchRequest{SearchRequest: req, srv: srv}))
+			require.Equal(t, exp, res)
 		}
 
-		srv := &testSearchEntity{
-			f: func(items ...interface{}) {
-				require.Equal(t, resp, items[0])
-			},
-		}
+		search(t, list[:2])
+		require.NotEmpty(t, cursor)
+
+		search(t, list[2:])
+		require.Empty(t, cursor)
+	})
+
+	t.Run("failed node", func(t *testing.T) {
+		var (
+			n1, n2 = testNode(t, 1), testNode(t, 2)
+			list   = append([]Address{}, addrList[:2]...)
+			cursor string
+		)
 
-		require.NoError(t, s.Search(req, srv))
+		sortAddresses(list)
+
+		// search returns the next page of the nodes and the cursor of the next page
+		search := func(t *testing.T, exp []Address) {
+			req := &object.SearchRequest{ContainerID: req.ContainerID}
+			req.SetHeaders(testSearchHeaders(cursor, "2"))
+
+			first := cursor == ""
+
+			s := newService()
+			s.objStreamer = &coreObjectSearcher{
+				executor: &testSearchEntity{
+					f: func(items ...interface{}) {
+						h := items[1].(nodeItemHandler)
+
+						if first {
+							h.handleNodeItem(n1, list[:1])
+							h.(nodeFailureHandler).handleNodeFailure(n2)
+						} else {
+							h.handleNodeItem(n2, list[1:])
+						}
+					},
+				},
+			}
+
+			var res []Address
+
+			cursor = ""
+
+			srv := &testSearchEntity{
+				f: func(items ...interface{}) {
+					switch v := items[0].(type) {
+					case *object.SearchResponse:
+						res = append(res, v.Addresses...)
+					case metadata.MD:
+						cursor = v.Get(SearchCursorTrailer)[0]
+					}
+				},
+			}
+
+			require.NoError(t, s.streamSearch(ctx, &searchRequest{SearchRequest: req, srv: srv}))
+			require.Equal(t, exp, res)
+		}
 
-		handler.res = addrList
+		// second node failed the first page, so the search is not complete
+		search(t, list[:1])
+		require.NotEmpty(t, cursor)
 
-		require.NoError(t, s.Search(req, srv))
+		search(t, list[1:])
+		require.Empty(t, cursor)
 	})
 }
 
@@ -22,7 +22,9 @@ import (
 	libgrpc "github.com/nspcc-dev/neofs-node/pkg/network/transport/grpc"
 	_range "github.com/nspcc-dev/neofs-node/pkg/network/transport/object/grpc/range"
 	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/placement"
+	v2 "github.com/nspcc-dev/neofs-node/pkg/services/object_manager/query"
 	storage2 "github.com/nspcc-dev/neofs-node/pkg/services/object_manager/replication/storage"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/retention"
 	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/transformer"
 	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/transport"
 	storagegroup2 "github.com/nspcc-dev/neofs-node/pkg/services/object_manager/transport/storagegroup"
@@ -108,6 +110,7 @@ type (
 	Placer interface {
 		IsContainerNode(ctx context.Context, addr multiaddr.Multiaddr, cid CID, previousNetMap bool) (bool, error)
 		GetNodes(ctx context.Context, addr Address, usePreviousNetMap bool, excl ...multiaddr.Multiaddr) ([]multiaddr.Multiaddr, error)
+		GetStorageNodes(ctx context.Context, addr Address, excl ...multiaddr.Multiaddr) ([]multiaddr.Multiaddr, error)
 	}
 
 	// WorkerPool is an interface of go-routing pool.
@@ -170,12 +173,19 @@ type (
 
 		headRecv objectReceiver
 
+		// executor finds the remote lock objects in the filters
+		executor transport.SelectiveContainerExecutor
+
 		Verifier verifier.Verifier
 
 		Transformer transformer.Transformer
 
 		MaxPayloadSize uint64
 
+		// AcceptancePolicy checks the incoming objects
+		// after the built-in filters if set.
+		AcceptancePolicy localstore.FilterFunc
+
 		// ACL pre-processor params
 		ContainerStorage storage.Storage
 		NetmapClient     *NetmapClient
@@ -216,6 +226,7 @@ type (
 		requestHandler requestHandler
 
 		objSearcher objectSearcher
+		objStreamer objectSearchStreamer
 		objRecv     objectReceiver
 		objStorer   objectStorer
 		objRemover  objectRemover
@@ -378,6 +389,14 @@ func New(p *Params) (Service, error) {
 		targetFinder: p.targetFinder,
 	}
 
+	aclRespPreparer := &aclResponsePreparer{
+		aclInfoReceiver: p.aclInfoReceiver,
+
+		reqActCalc: p.requestActionCalculator,
+
+		eaclSrc: p.ExtendedACLSource,
+	}
+
 	srv := &objectService{
 		ls:         p.LocalStore,
 		log:        p.Logger,
@@ -398,13 +417,7 @@ func New(p *Params) (Service, error) {
 		respPreparer: &complexResponsePreparer{
 			items: []responsePreparer{
 				epochRespPreparer,
-				&aclResponsePreparer{
-					aclInfoReceiver: p.aclInfoReceiver,
-
-					reqActCalc: p.requestActionCalculator,
-
-					eaclSrc: p.ExtendedACLSource,
-				},
+				aclRespPreparer,
 			},
 		},
 
@@ -459,9 +472,16 @@ func New(p *Params) (Service, error) {
 	}
 
 	qvc.m[1] = &coreQueryImposer{
-		fCreator: new(coreFilterCreator),
-		lsLister: p.LocalStore,
-		log:      p.Logger,
+		fCreator:  new(coreFilterCreator),
+		lsLister:  p.LocalStore,
+		epochRecv: p.EpochReceiver,
+		log:       p.Logger,
+	}
+
+	qvc.m[v2.Version] = &queryImposerV2{
+		lsLister:  p.LocalStore,
+		epochRecv: p.EpochReceiver,
+		log:       p.Logger,
 	}
 
 	localExec := &localOperationExecutor{
@@ -471,6 +491,8 @@ func New(p *Params) (Service, error) {
 		queryImp:  qvc,
 		rngReader: local,
 		rngHasher: local,
+
+		addressStore: p.AddressStore,
 	}
 
 	opExec := &coreOperationExecutor{
@@ -486,6 +508,11 @@ func New(p *Params) (Service, error) {
 				placementBuilder: p.Placer,
 				log:              p.Logger,
 			},
+			storagePlacementBuilder: &corePlacementUtil{
+				fullExcluded:     true,
+				placementBuilder: p.Placer,
+				log:              p.Logger,
+			},
 			interceptorPreparer: &coreInterceptorPreparer{
 				localExec:    localExec,
 				addressStore: p.AddressStore,
@@ -501,10 +528,13 @@ func New(p *Params) (Service, error) {
 		loc: localExec,
 	}
 
-	srv.objSearcher = &coreObjectSearcher{
+	searcher := &coreObjectSearcher{
 		executor: opExec,
 	}
 
+	srv.objSearcher = searcher
+	srv.objStreamer = searcher
+
 	childLister := &coreChildrenLister{
 		queryFn:     coreChildrenQueryFunc,
 		objSearcher: srv.objSearcher,
@@ -556,6 +586,9 @@ func New(p *Params) (Service, error) {
 			},
 			pRangeRecv: rngRecv,
 		},
+		erasureRecv: &coreErasureReceiver{
+			executor: srv.executor,
+		},
 		log: p.Logger,
 	}
 	childrenRecv.coreObjRecv = coreObjRecv
@@ -563,10 +596,11 @@ func New(p *Params) (Service, error) {
 	srv.payloadRngRecv = rngRecv
 
 	if !p.Assembly {
-		coreObjRecv.ancestralRecv, coreObjRecv.childLister = nil, nil
+		coreObjRecv.ancestralRecv, coreObjRecv.childLister, coreObjRecv.erasureRecv = nil, nil, nil
 	}
 
 	p.headRecv = srv.objRecv
+	p.executor = srv.executor
 
 	filter, err := newIncomingObjectFilter(p)
 	if err != nil {
@@ -592,6 +626,21 @@ func New(p *Params) (Service, error) {
 		},
 	}
 
+	copyStorer := &copyingObjectStorer{
+		objRecv: srv.objRecv,
+		objStorer: &multipartObjectStorer{
+			objStorer:      transformerObjStorer,
+			straightStorer: straightStorer,
+			executor:       srv.executor,
+			partLimit:      p.MaxPayloadSize,
+		},
+		aclChecker: aclRespPreparer,
+	}
+
+	if p.CheckACL {
+		copyStorer.aclPreProc = newACLPreProcessor(p)
+	}
+
 	srv.objStorer = &filteringObjectStorer{
 		filter: bf,
 		objStorer: &bifurcatingObjectStorer{
@@ -604,11 +653,24 @@ func New(p *Params) (Service, error) {
 			},
 			tokenStorer: &tokenObjectStorer{
 				tokenStore: p.TokenStore,
-				objStorer:  transformerObjStorer,
+				objStorer:  copyStorer,
 			},
 		},
 	}
 
+	holder, err := retention.NewHolder(p.ContainerStorage)
+	if err != nil {
+		return nil, err
+	}
+
+	lockChecker, err := retention.NewRemoteChecker(retention.RemoteCheckerParams{
+		SelectiveContainerExecutor: srv.executor,
+		Holder:                     holder,
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	srv.objRemover = &coreObjRemover{
 		delPrep: &coreDelPreparer{
 			childLister: childLister,
@@ -617,9 +679,11 @@ func New(p *Params) (Service, error) {
 			tombCreator: new(coreTombCreator),
 			objStorer:   transformerObjStorer,
 		},
-		tokenStore: p.TokenStore,
-		mErr:       map[error]struct{}{},
-		log:        p.Logger,
+		tokenStore:  p.TokenStore,
+		mErr:        map[error]struct{}{},
+		lockChecker: lockChecker,
+		epochRecv:   p.EpochReceiver,
+		log:         p.Logger,
 	}
 
 	srv.rngRecv = &coreRangeReceiver{
@@ -8,6 +8,10 @@ import (
 	"github.com/nspcc-dev/neofs-api-go/object"
 	"github.com/nspcc-dev/neofs-api-go/session"
 	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/localstore"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/erasure"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/expiration"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/multipart"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/retention"
 	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/transformer"
 	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/transport/storagegroup"
 	"github.com/pkg/errors"
@@ -116,6 +120,18 @@ const msgObjectCreationEpoch = "invalid creation epoch of object"
 
 var errObjectFromTheFuture = errors.New("object from the future")
 
+const msgInvalidExpirationEpoch = "invalid expiration epoch of object"
+
+var errInvalidExpirationEpoch = errors.New("invalid expiration epoch")
+
+const msgObjectExpired = "object is already expired"
+
+var errObjectExpired = errors.New("object is already expired")
+
+const msgAcceptancePolicy = "object rejected by acceptance policy"
+
+var errAcceptancePolicy = errors.New("object rejected by acceptance policy")
+
 const msgObjectPayloadSize = "max object payload size overflow"
 
 var errObjectPayloadSize = errors.New("max object payload size overflow")
@@ -174,12 +190,48 @@ const msgDeletePrepare = "delete information preparation failure"
 
 var errDeletePrepare = errors.New("delete information preparation failure")
 
+const msgObjectLocked = "object is locked until the end of retention period"
+
+var errObjectLocked = errors.New("object is locked")
+
+const msgRetentionCheck = "could not check object retention"
+
+var errRetentionCheck = errors.New("could not check object retention")
+
+const msgInvalidRetentionLock = "invalid retention lock of object"
+
+var errInvalidRetentionLock = errors.New("invalid retention lock")
+
+const msgInvalidErasurePart = "invalid erasure coded part of object"
+
+var errInvalidErasurePart = errors.New("invalid erasure coded part")
+
 const msgQueryVersion = "unsupported query version"
 
 const msgSearchQueryUnmarshal = "query unmarshal failure"
 
 const msgLocalQueryImpose = "local query imposing failure"
 
+const msgInvalidSearchPage = "invalid search cursor or limit"
+
+const msgInvalidMultipartRequest = "invalid multipart upload headers"
+
+const msgMultipartPayload = "multipart upload initiation with payload"
+
+const msgMultipartPartSize = "max multipart upload part size overflow"
+
+const msgMissingMultipartChecksum = "missing payload checksum of multipart upload"
+
+const msgMissingPart = "missing part of multipart upload"
+
+const msgUploadNotFound = "multipart upload not found"
+
+const msgInvalidCopySource = "invalid copy source address"
+
+const msgCopySourceNotFound = "copy source object not found"
+
+const msgMultipartCopy = "copy within multipart upload is not supported"
+
 var mStatusCommon = map[error]*statusInfo{
 	// RPC implementation recovered panic
 	errServerPanic: {
@@ -313,6 +365,51 @@ var mStatusCustom = map[requestError]*statusInfo{
 		m: msgObjectCreationEpoch,
 		d: nil, // TODO: NSPCC-1048
 	},
+	{
+		t: object.RequestPut,
+		e: errInvalidExpirationEpoch,
+	}: {
+		c: codes.InvalidArgument,
+		m: msgInvalidExpirationEpoch,
+		d: expirationEpochHeaderDetails(),
+	},
+	{
+		t: object.RequestPut,
+		e: errObjectExpired,
+	}: {
+		c: codes.FailedPrecondition,
+		m: msgObjectExpired,
+	},
+	{
+		t: object.RequestPut,
+		e: errAcceptancePolicy,
+	}: {
+		c: codes.FailedPrecondition,
+		m: msgAcceptancePolicy,
+	},
+	{
+		t: object.RequestPut,
+		e: errInvalidRetentionLock,
+	}: {
+		c: codes.InvalidArgument,
+		m: msgInvalidRetentionLock,
+		d: retentionLockHeaderDetails(),
+	},
+	{
+		t: object.RequestPut,
+		e: errInvalidErasurePart,
+	}: {
+		c: codes.InvalidArgument,
+		m: msgInvalidErasurePart,
+		d: erasurePartHeaderDetails(),
+	},
+	{
+		t: object.RequestPut,
+		e: errObjectLocked,
+	}: {
+		c: codes.FailedPrecondition,
+		m: msgObjectLocked,
+	},
 	{
 		t: object.RequestPut,
 		e: errObjectPayloadSize,
@@ -380,6 +477,79 @@ var mStatusCustom = map[requestError]*statusInfo{
 		c: codes.NotFound,
 		m: msgIncompleteSGInfo,
 	},
+	{
+		t: object.RequestPut,
+		e: multipart.ErrInvalidRequest,
+	}: {
+		c: codes.InvalidArgument,
+		m: msgInvalidMultipartRequest,
+		d: multipartRequestDetails(),
+	},
+	{
+		t: object.RequestPut,
+		e: errMultipartPayload,
+	}: {
+		c: codes.InvalidArgument,
+		m: msgMultipartPayload,
+	},
+	{
+		t: object.RequestPut,
+		e: errMultipartPartSize,
+	}: {
+		c: codes.InvalidArgument,
+		m: msgMultipartPartSize,
+	},
+	{
+		t: object.RequestPut,
+		e: multipart.ErrMissingChecksum,
+	}: {
+		c: codes.InvalidArgument,
+		m: msgMissingMultipartChecksum,
+		d: multipartChecksumDetails(),
+	},
+	{
+		t: object.RequestPut,
+		e: multipart.ErrMissingPart,
+	}: {
+		c: codes.FailedPrecondition,
+		m: msgMissingPart,
+	},
+	{
+		t: object.RequestPut,
+		e: errUploadNotFound,
+	}: {
+		c: codes.NotFound,
+		m: msgUploadNotFound,
+	},
+	{
+		t: object.RequestPut,
+		e: errInvalidCopySource,
+	}: {
+		c: codes.InvalidArgument,
+		m: msgInvalidCopySource,
+		d: copySourceDetails(),
+	},
+	{
+		t: object.RequestPut,
+		e: errCopySourceNotFound,
+	}: {
+		c: codes.NotFound,
+		m: msgCopySourceNotFound,
+	},
+	{
+		t: object.RequestPut,
+		e: errNonAssembly,
+	}: {
+		c: codes.Unimplemented,
+		m: msgNonAssembly,
+	},
+	{
+		t: object.RequestPut,
+		e: errMultipartCopy,
+	}: {
+		c: codes.InvalidArgument,
+		m: msgMultipartCopy,
+	},
 	{
 		t: object.RequestPut,
 		e: errTransformer,
@@ -509,6 +679,20 @@ var mStatusCustom = map[requestError]*statusInfo{
 		c: codes.Internal,
 		m: msgDeletePrepare,
 	},
+	{
+		t: object.RequestDelete,
+		e: errObjectLocked,
+	}: {
+		c: codes.FailedPrecondition,
+		m: msgObjectLocked,
+	},
+	{
+		t: object.RequestDelete,
+		e: errRetentionCheck,
+	}: {
+		c: codes.Unavailable,
+		m: msgRetentionCheck,
+	},
 	{
 		t: object.RequestSearch,
 		e: errUnsupportedQueryVersion,
@@ -530,6 +714,14 @@ var mStatusCustom = map[requestError]*statusInfo{
 		c: codes.Internal,
 		m: msgLocalQueryImpose,
 	},
+	{
+		t: object.RequestSearch,
+		e: errInvalidSearchPage,
+	}: {
+		c: codes.InvalidArgument,
+		m: msgInvalidSearchPage,
+		d: searchPageDetails(),
+	},
 	{
 		t: object.RequestRangeHash,
 		e: errPayloadRangeNotFound,
@@ -641,6 +833,19 @@ func requestAuthDetails() []proto.Message {
 	}
 }
 
+func searchPageDetails() []proto.Message {
+	return []proto.Message{
+		&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{
+					Field:       "Headers",
+					Description: "search cursor should be taken from the previous response, limit should be a non-negative integer",
+				},
+			},
+		},
+	}
+}
+
 func invalidTTLDetails() []proto.Message {
 	return []proto.Message{
 		&errdetails.BadRequest{
@@ -825,6 +1030,80 @@ func payloadChecksumHeaderDetails() []proto.Message {
 	}
 }
 
+func expirationEpochHeaderDetails() []proto.Message {
+	return []proto.Message{
+		&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{
+					Field:       "R.Object.Headers",
+					Description: fmt.Sprintf("%s user header should contain decimal epoch number", expiration.Header),
+				},
+			},
+		},
+	}
+}
+
+func retentionLockHeaderDetails() []proto.Message {
+	return []proto.Message{
+		&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{
+					Field: "R.Object.Headers",
+					Description: fmt.Sprintf("%s user header should contain decimal epoch number, %s user headers should contain object IDs",
+						retention.Header, retention.TargetHeader),
+				},
+			},
+		},
+	}
+}
+
+func erasurePartHeaderDetails() []proto.Message {
+	return []proto.Message{
+		&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{
+					Field: "R.Object.Headers",
+					Description: fmt.Sprintf("part objects should carry %s, %s and %s user headers and the parent link matching the part ID",
+						erasure.SchemeHeader, erasure.IndexHeader, erasure.SizeHeader),
+				},
+			},
+		},
+	}
+}
+
+func objectExpirationEpochDetails(e uint64) []proto.Message {
+	return []proto.Message{
+		&errdetails.PreconditionFailure{
+			Violations: []*errdetails.PreconditionFailure_Violation{
+				{
+					Type:        "object requirements",
+					Subject:     "expiration epoch",
+					Description: fmt.Sprintf("should not be less than %d", e),
+				},
+			},
+		},
+	}
+}
+
+func acceptancePolicyDetails(e error) []proto.Message {
+	desc := "should satisfy the acceptance policy"
+	if e != nil {
+		desc = e.Error()
+	}
+
+	return []proto.Message{
+		&errdetails.PreconditionFailure{
+			Violations: []*errdetails.PreconditionFailure_Violation{
+				{
+					Type:        "object requirements",
+					Subject:     "acceptance policy",
+					Description: desc,
+				},
+			},
+		},
+	}
+}
+
 func objectHeadersVerificationDetails(e error) []proto.Message {
 	return []proto.Message{
 		&errdetails.BadRequest{
@@ -862,6 +1141,45 @@ func sgLinkingDetails() []proto.Message {
 	}
 }
 
+func multipartRequestDetails() []proto.Message {
+	return []proto.Message{
+		&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{
+					Field:       "Headers",
+					Description: "should contain either initiate header or upload ID with part number or number of parts",
+				},
+			},
+		},
+	}
+}
+
+func copySourceDetails() []proto.Message {
+	return []proto.Message{
+		&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{
+					Field:       "Headers",
+					Description: "copy source should be an object address in CID/ObjectID format",
+				},
+			},
+		},
+	}
+}
+
+func multipartChecksumDetails() []proto.Message {
+	return []proto.Message{
+		&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{
+					Field:       "R.Object.Headers",
+					Description: "should contain PayloadChecksum of the whole payload",
+				},
+			},
+		},
+	}
+}
+
 func sgSizeDetails(exp, act uint64) []proto.Message {
 	return []proto.Message{
 		&errdetails.BadRequest{
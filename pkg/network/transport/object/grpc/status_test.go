@@ -8,6 +8,7 @@ import (
 	"github.com/nspcc-dev/neofs-api-go/object"
 	"github.com/nspcc-dev/neofs-api-go/session"
 	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/localstore"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/multipart"
 	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/transformer"
 	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/transport/storagegroup"
 	testlogger "github.com/nspcc-dev/neofs-node/pkg/util/logger/test"
@@ -765,6 +766,113 @@ func TestStatusPut(t *testing.T) {
 
 		testStatusPut(t, h, srv, info, ds)
 	})
+
+	t.Run("invalid multipart request", func(t *testing.T) {
+		ds := make([]interface{}, 0)
+
+		for _, d := range multipartRequestDetails() {
+			ds = append(ds, d)
+		}
+
+		srv := &testPutEntity{
+			res: object.MakePutRequestHeader(new(Object)),
+		}
+
+		h := &testPutEntity{
+			err: multipart.ErrInvalidRequest,
+		}
+
+		info := statusInfo{
+			c: codes.InvalidArgument,
+			m: msgInvalidMultipartRequest,
+		}
+
+		testStatusPut(t, h, srv, info, ds)
+	})
+
+	t.Run("missing part of multipart upload", func(t *testing.T) {
+		ds := make([]interface{}, 0)
+
+		srv := &testPutEntity{
+			res: object.MakePutRequestHeader(new(Object)),
+		}
+
+		h := &testPutEntity{
+			err: multipart.ErrMissingPart,
+		}
+
+		info := statusInfo{
+			c: codes.FailedPrecondition,
+			m: msgMissingPart,
+		}
+
+		testStatusPut(t, h, srv, info, ds)
+	})
+
+	t.Run("invalid retention lock", func(t *testing.T) {
+		ds := make([]interface{}, 0)
+
+		for _, d := range retentionLockHeaderDetails() {
+			ds = append(ds, d)
+		}
+
+		srv := &testPutEntity{
+			res: object.MakePutRequestHeader(new(Object)),
+		}
+
+		h := &testPutEntity{
+			err: errInvalidRetentionLock,
+		}
+
+		info := statusInfo{
+			c: codes.InvalidArgument,
+			m: msgInvalidRetentionLock,
+		}
+
+		testStatusPut(t, h, srv, info, ds)
+	})
+
+	t.Run("invalid erasure coded part", func(t *testing.T) {
+		ds := make([]interface{}, 0)
+
+		for _, d := range erasurePartHeaderDetails() {
+			ds = append(ds, d)
+		}
+
+		srv := &testPutEntity{
+			res: object.MakePutRequestHeader(new(Object)),
+		}
+
+		h := &testPutEntity{
+			err: errInvalidErasurePart,
+		}
+
+		info := statusInfo{
+			c: codes.InvalidArgument,
+			m: msgInvalidErasurePart,
+		}
+
+		testStatusPut(t, h, srv, info, ds)
+	})
+
+	t.Run("tombstone of locked object", func(t *testing.T) {
+		ds := make([]interface{}, 0)
+
+		srv := &testPutEntity{
+			res: object.MakePutRequestHeader(new(Object)),
+		}
+
+		h := &testPutEntity{
+			err: errObjectLocked,
+		}
+
+		info := statusInfo{
+			c: codes.FailedPrecondition,
+			m: msgObjectLocked,
+		}
+
+		testStatusPut(t, h, srv, info, ds)
+	})
 }
 
 func testStatusGet(t *testing.T, h requestHandler, srv object.Service_GetServer, info statusInfo, d []interface{}) {
@@ -1088,6 +1196,36 @@ func TestStatusDelete(t *testing.T) {
 
 		testStatusDelete(t, h, info, ds)
 	})
+
+	t.Run("locked object", func(t *testing.T) {
+		ds := make([]interface{}, 0)
+
+		h := &testHeadEntity{
+			err: errObjectLocked,
+		}
+
+		info := statusInfo{
+			c: codes.FailedPrecondition,
+			m: msgObjectLocked,
+		}
+
+		testStatusDelete(t, h, info, ds)
+	})
+
+	t.Run("retention check failure", func(t *testing.T) {
+		ds := make([]interface{}, 0)
+
+		h := &testHeadEntity{
+			err: errRetentionCheck,
+		}
+
+		info := statusInfo{
+			c: codes.Unavailable,
+			m: msgRetentionCheck,
+		}
+
+		testStatusDelete(t, h, info, ds)
+	})
 }
 
 func testStatusSearch(t *testing.T, h requestHandler, srv object.Service_SearchServer, info statusInfo, d []interface{}) {
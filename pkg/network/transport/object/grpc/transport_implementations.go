@@ -562,7 +562,7 @@ func prepareSearchRequest(req transport.SearchInfo) serviceRequest {
 	return &object.SearchRequest{
 		ContainerID:  req.GetCID(),
 		Query:        req.GetQuery(),
-		QueryVersion: 1,
+		QueryVersion: uint32(queryVersion(req)),
 	}
 }
 
@@ -182,5 +182,9 @@ func newContainerTraverser(p *traverseParams) containerTraverser {
 }
 
 func (s *corePlacementUtil) buildPlacement(ctx context.Context, addr Address, excl ...multiaddr.Multiaddr) ([]multiaddr.Multiaddr, error) {
+	if s.fullExcluded {
+		return s.placementBuilder.GetStorageNodes(ctx, addr, excl...)
+	}
+
 	return s.placementBuilder.GetNodes(ctx, addr, s.prevNetMap, excl...)
 }
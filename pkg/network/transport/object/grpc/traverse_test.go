@@ -45,6 +45,16 @@ func (s *testTraverseEntity) GetNodes(ctx context.Context, a Address, p bool, e
 	return s.res.([]multiaddr.Multiaddr), nil
 }
 
+func (s *testTraverseEntity) GetStorageNodes(ctx context.Context, a Address, e ...multiaddr.Multiaddr) ([]multiaddr.Multiaddr, error) {
+	if s.f != nil {
+		s.f(a, e)
+	}
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.res.([]multiaddr.Multiaddr), nil
+}
+
 func (s *testTraverseEntity) buildPlacement(_ context.Context, addr Address, excl ...multiaddr.Multiaddr) ([]multiaddr.Multiaddr, error) {
 	if s.f != nil {
 		s.f(addr, excl)
@@ -77,6 +87,25 @@ func Test_coreCnrAffChecker_buildPlacement(t *testing.T) {
 		s.buildPlacement(ctx, addr, nodes...)
 	})
 
+	t.Run("full nodes excluded", func(t *testing.T) {
+		s := &corePlacementUtil{
+			fullExcluded: true,
+			placementBuilder: &testTraverseEntity{
+				f: func(items ...interface{}) {
+					require.Len(t, items, 2)
+					require.Equal(t, addr, items[0].(Address))
+					require.Equal(t, nodes, items[1].([]multiaddr.Multiaddr))
+				},
+				res: nodes,
+			},
+			log: zap.L(),
+		}
+
+		res, err := s.buildPlacement(ctx, addr, nodes...)
+		require.NoError(t, err)
+		require.Equal(t, nodes, res)
+	})
+
 	t.Run("correct result", func(t *testing.T) {
 		t.Run("placer error", func(t *testing.T) {
 			s := &corePlacementUtil{
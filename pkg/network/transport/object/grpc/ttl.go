@@ -49,6 +49,9 @@ type (
 		// Previous network map flag.
 		prevNetMap bool
 
+		// Full nodes exclusion flag, used for the new objects.
+		fullExcluded bool
+
 		// Local node net address store.
 		localAddrStore storage.AddressStore
 
@@ -0,0 +1,30 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/nspcc-dev/neofs-api-go/refs"
+	meta2 "github.com/nspcc-dev/neofs-node/pkg/local_object_storage/meta"
+)
+
+// NopCollector is a Collector that does nothing.
+//
+// It is used by the tools and tests that do not export metrics.
+type NopCollector struct{}
+
+var _ Collector = NopCollector{}
+
+// Start does nothing.
+func (NopCollector) Start(context.Context) {}
+
+// UpdateSpaceUsage does nothing.
+func (NopCollector) UpdateSpaceUsage() {}
+
+// SetCounter does nothing.
+func (NopCollector) SetCounter(ObjectCounter) {}
+
+// SetIterator does nothing.
+func (NopCollector) SetIterator(meta2.Iterator) {}
+
+// UpdateContainer does nothing.
+func (NopCollector) UpdateContainer(refs.CID, uint64, SpaceOp) {}
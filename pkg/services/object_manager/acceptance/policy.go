@@ -0,0 +1,151 @@
+package acceptance
+
+import (
+	"context"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/localstore"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+type (
+	// Params groups the parameters of the Policy constructor.
+	Params struct {
+		// Path is a path to the policy file, empty
+		// path means the policy without rules.
+		Path string
+
+		Logger *zap.Logger
+	}
+
+	// Policy is an acceptance policy of the incoming objects
+	// compiled from the policy file.
+	//
+	// The file contains the list of rules in `rules` section in any
+	// format supported by viper, see Rule for the rule fields.
+	Policy struct {
+		path string
+		log  *zap.Logger
+
+		mtx      sync.RWMutex
+		pipeline localstore.FilterPipeline
+		modTime  time.Time
+	}
+)
+
+const rulesSection = "rules"
+
+var errNilLogger = errors.New("logger is nil")
+
+// New loads the acceptance policy from the file.
+func New(p Params) (*Policy, error) {
+	if p.Logger == nil {
+		return nil, errNilLogger
+	}
+
+	res := &Policy{
+		path: p.Path,
+		log:  p.Logger,
+	}
+
+	if err := res.Reload(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// ReadRules reads the rules from the policy file.
+func ReadRules(path string) ([]Rule, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+
+	if err := v.ReadInConfig(); err != nil {
+		return nil, errors.Wrap(err, "could not read policy file")
+	}
+
+	var rules []Rule
+
+	if err := v.UnmarshalKey(rulesSection, &rules); err != nil {
+		return nil, errors.Wrap(err, "could not decode policy rules")
+	}
+
+	return rules, nil
+}
+
+// Pass checks the object by the policy rules.
+// It is a localstore.FilterFunc.
+func (p *Policy) Pass(ctx context.Context, meta *localstore.ObjectMeta) *localstore.FilterResult {
+	p.mtx.RLock()
+	pipeline := p.pipeline
+	p.mtx.RUnlock()
+
+	return pipeline.Pass(ctx, meta)
+}
+
+// Reload reads and compiles the policy file. On failure
+// the previously loaded policy stays in effect.
+func (p *Policy) Reload() error {
+	var (
+		rules   []Rule
+		modTime time.Time
+	)
+
+	if p.path != "" {
+		info, err := os.Stat(p.path)
+		if err != nil {
+			return errors.Wrap(err, "could not stat policy file")
+		}
+
+		modTime = info.ModTime()
+
+		if rules, err = ReadRules(p.path); err != nil {
+			return err
+		}
+	}
+
+	pipeline, err := Compile(rules)
+	if err != nil {
+		return errors.Wrap(err, "could not compile policy")
+	}
+
+	p.mtx.Lock()
+	p.pipeline, p.modTime = pipeline, modTime
+	p.mtx.Unlock()
+
+	p.log.Info("acceptance policy loaded",
+		zap.String("path", p.path),
+		zap.Int("rules", len(rules)))
+
+	return nil
+}
+
+// Watch reloads the policy if the policy file was modified.
+// Reload errors are logged.
+func (p *Policy) Watch(context.Context) {
+	if p.path == "" {
+		return
+	}
+
+	info, err := os.Stat(p.path)
+	if err != nil {
+		p.log.Error("could not stat policy file", zap.Error(err))
+		return
+	}
+
+	p.mtx.RLock()
+	modified := !info.ModTime().Equal(p.modTime)
+	p.mtx.RUnlock()
+
+	if !modified {
+		return
+	}
+
+	if err := p.Reload(); err != nil {
+		p.log.Error("could not reload acceptance policy", zap.Error(err))
+	}
+}
@@ -0,0 +1,75 @@
+package acceptance
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neofs-api-go/refs"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/localstore"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const testPolicy = `rules:
+  - name: max payload
+    max_payload_size: %d
+`
+
+func writePolicy(t *testing.T, path, data string, modTime time.Time) {
+	require.NoError(t, ioutil.WriteFile(path, []byte(data), 0600))
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
+
+func TestPolicy(t *testing.T) {
+	dir, err := ioutil.TempDir("", "acceptance")
+	require.NoError(t, err)
+
+	defer os.RemoveAll(dir)
+
+	var (
+		path = filepath.Join(dir, "policy.yml")
+		now  = time.Now()
+		meta = testObject(refs.CID{}, refs.OwnerID{}, 10)
+	)
+
+	t.Run("empty", func(t *testing.T) {
+		p, err := New(Params{Logger: zap.L()})
+		require.NoError(t, err)
+		require.Equal(t, localstore.CodePass, p.Pass(context.Background(), meta).Code())
+	})
+
+	writePolicy(t, path, fmt.Sprintf(testPolicy, 5), now)
+
+	p, err := New(Params{Path: path, Logger: zap.L()})
+	require.NoError(t, err)
+	require.Equal(t, localstore.CodeFail, p.Pass(context.Background(), meta).Code())
+
+	// file is not modified
+	writePolicy(t, path, fmt.Sprintf(testPolicy, 10), now)
+	p.Watch(context.Background())
+	require.Equal(t, localstore.CodeFail, p.Pass(context.Background(), meta).Code())
+
+	now = now.Add(time.Second)
+
+	writePolicy(t, path, fmt.Sprintf(testPolicy, 10), now)
+	p.Watch(context.Background())
+	require.Equal(t, localstore.CodePass, p.Pass(context.Background(), meta).Code())
+
+	t.Run("invalid policy", func(t *testing.T) {
+		now = now.Add(time.Second)
+
+		writePolicy(t, path, "rules:\n  - max_payload_size: 5\n", now)
+		require.Error(t, p.Reload())
+
+		// previous policy stays in effect
+		require.Equal(t, localstore.CodePass, p.Pass(context.Background(), meta).Code())
+
+		_, err := New(Params{Path: path, Logger: zap.L()})
+		require.Error(t, err)
+	})
+}
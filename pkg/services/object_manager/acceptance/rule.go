@@ -0,0 +1,275 @@
+package acceptance
+
+import (
+	"context"
+	"strings"
+
+	"github.com/mr-tron/base58"
+	"github.com/nspcc-dev/neofs-api-go/object"
+	"github.com/nspcc-dev/neofs-api-go/refs"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/localstore"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/erasure"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/multipart"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/retention"
+	"github.com/pkg/errors"
+)
+
+type (
+	// Rule is a description of the acceptance policy rule.
+	//
+	// Rule applies to the objects of the listed containers and owners,
+	// any container or owner matches if the list is empty. Rule passes
+	// the applicable object if the object meets all the conditions and
+	// fails it otherwise. Not applicable objects are ignored.
+	//
+	// System objects (tombstones, storage groups, multipart upload
+	// records and parts, erasure coded parts and retention locks)
+	// are not applicable unless SystemObjects is set, since the
+	// user conditions would break the node services.
+	//
+	// Rule result is converted by the On* codes: "pass" accepts the
+	// object without the checks of the next rules, "fail" rejects the
+	// object and "ignore" continues with the next rule. By default
+	// passed and ignored objects are checked by the next rule, failed
+	// ones are rejected.
+	Rule struct {
+		// Name identifies the rule in the policy.
+		Name string `mapstructure:"name"`
+
+		// Priority defines the order of the rule checks,
+		// rules with higher priority are checked first.
+		Priority uint64 `mapstructure:"priority"`
+
+		// Containers is a list of the base58 container IDs.
+		Containers []string `mapstructure:"containers"`
+
+		// Owners is a list of the base58 owner IDs.
+		Owners []string `mapstructure:"owners"`
+
+		// RequiredHeaders is a list of the user header keys
+		// the object must contain.
+		RequiredHeaders []string `mapstructure:"required_headers"`
+
+		// Headers is a list of the user headers the object must contain.
+		Headers []Header `mapstructure:"headers"`
+
+		// MaxPayloadSize limits the object payload size if not zero.
+		MaxPayloadSize uint64 `mapstructure:"max_payload_size"`
+
+		// SystemObjects makes the rule applicable to the system objects.
+		SystemObjects bool `mapstructure:"system_objects"`
+
+		OnPass   string `mapstructure:"on_pass"`
+		OnFail   string `mapstructure:"on_fail"`
+		OnIgnore string `mapstructure:"on_ignore"`
+	}
+
+	// Header is a user header key-value pair.
+	Header struct {
+		Key   string `mapstructure:"key"`
+		Value string `mapstructure:"value"`
+	}
+)
+
+const (
+	codePass   = "pass"
+	codeFail   = "fail"
+	codeIgnore = "ignore"
+)
+
+// FilterName is a name of the compiled policy filter pipeline.
+const FilterName = "ACCEPTANCE_POLICY"
+
+// ErrRejected is the error of the object failed by the policy rule.
+var ErrRejected = errors.New("object rejected by acceptance policy")
+
+var errEmptyRuleName = errors.New("empty rule name")
+
+// Compile converts the rules to the filter pipeline with the rule sub-filters.
+//
+// Pipeline passes the object if no rule accepted or rejected it.
+func Compile(rules []Rule) (localstore.FilterPipeline, error) {
+	res := localstore.NewFilter(&localstore.FilterParams{
+		Name:       FilterName,
+		FilterFunc: localstore.SkippingFilterFunc,
+	})
+
+	for i := range rules {
+		params, err := rules[i].subFilter()
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid rule #%d", i)
+		}
+
+		if err := res.PutSubFilter(params); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+func (r Rule) subFilter() (localstore.SubFilterParams, error) {
+	res := localstore.SubFilterParams{
+		PriorityFlag: localstore.PriorityValue,
+	}
+
+	if r.Name == "" {
+		return res, errEmptyRuleName
+	}
+
+	var err error
+
+	if res.OnPass, err = filterCode(r.OnPass, localstore.CodeIgnore); err != nil {
+		return res, errors.Wrapf(err, "rule %s: on_pass", r.Name)
+	} else if res.OnFail, err = filterCode(r.OnFail, localstore.CodeFail); err != nil {
+		return res, errors.Wrapf(err, "rule %s: on_fail", r.Name)
+	} else if res.OnIgnore, err = filterCode(r.OnIgnore, localstore.CodeIgnore); err != nil {
+		return res, errors.Wrapf(err, "rule %s: on_ignore", r.Name)
+	}
+
+	cids, err := decodeIDs(r.Containers, refs.CIDSize)
+	if err != nil {
+		return res, errors.Wrapf(err, "rule %s: containers", r.Name)
+	}
+
+	owners, err := decodeIDs(r.Owners, refs.OwnerIDSize)
+	if err != nil {
+		return res, errors.Wrapf(err, "rule %s: owners", r.Name)
+	}
+
+	res.FilterPipeline = localstore.NewFilter(&localstore.FilterParams{
+		Name:       r.Name,
+		Priority:   r.Priority,
+		FilterFunc: r.filterFunc(cids, owners),
+	})
+
+	return res, nil
+}
+
+func (r Rule) filterFunc(cids, owners map[string]struct{}) localstore.FilterFunc {
+	return func(_ context.Context, meta *localstore.ObjectMeta) *localstore.FilterResult {
+		obj := meta.Object
+
+		if !matches(cids, obj.SystemHeader.CID.String()) || !matches(owners, obj.SystemHeader.OwnerID.String()) {
+			return localstore.ResultIgnore()
+		} else if !r.SystemObjects && isSystemObject(obj) {
+			return localstore.ResultIgnore()
+		}
+
+		if r.MaxPayloadSize > 0 && obj.SystemHeader.PayloadLength > r.MaxPayloadSize {
+			return r.fail("payload size %d exceeds %d", obj.SystemHeader.PayloadLength, r.MaxPayloadSize)
+		}
+
+		for _, key := range r.RequiredHeaders {
+			if _, ok := userHeader(obj, key); !ok {
+				return r.fail("missing %s header", key)
+			}
+		}
+
+		for _, h := range r.Headers {
+			if v, ok := userHeader(obj, h.Key); !ok || v != h.Value {
+				return r.fail("%s header is not %s", h.Key, h.Value)
+			}
+		}
+
+		return localstore.ResultPass()
+	}
+}
+
+func (r Rule) fail(format string, args ...interface{}) *localstore.FilterResult {
+	return localstore.ResultWithError(
+		localstore.CodeFail,
+		errors.Wrapf(errors.Wrapf(ErrRejected, "rule %s", r.Name), format, args...),
+	)
+}
+
+func filterCode(s string, def localstore.FilterCode) (localstore.FilterCode, error) {
+	switch strings.ToLower(s) {
+	case "":
+		return def, nil
+	case codePass:
+		return localstore.CodePass, nil
+	case codeFail:
+		return localstore.CodeFail, nil
+	case codeIgnore:
+		return localstore.CodeIgnore, nil
+	default:
+		return 0, errors.Errorf("unknown code `%s`, expected one of %s, %s, %s",
+			s, codePass, codeFail, codeIgnore)
+	}
+}
+
+// decodeIDs checks the base58 IDs and returns them as a set.
+func decodeIDs(ids []string, size int) (map[string]struct{}, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	res := make(map[string]struct{}, len(ids))
+
+	for _, id := range ids {
+		if data, err := base58.Decode(id); err != nil {
+			return nil, errors.Wrapf(err, "could not decode %s", id)
+		} else if len(data) != size {
+			return nil, errors.Errorf("invalid ID %s length %d, expected %d", id, len(data), size)
+		}
+
+		res[id] = struct{}{}
+	}
+
+	return res, nil
+}
+
+func matches(set map[string]struct{}, id string) bool {
+	if set == nil {
+		return true
+	}
+
+	_, ok := set[id]
+
+	return ok
+}
+
+// systemHeaders is a set of the user header keys of the system objects.
+var systemHeaders = map[string]struct{}{
+	multipart.KeyUpload:    {},
+	multipart.KeyPart:      {},
+	erasure.IndexHeader:    {},
+	retention.Header:       {},
+	retention.TargetHeader: {},
+}
+
+// isSystemObject checks if the object is created by the node services
+// rather than stores the user data.
+func isSystemObject(obj *object.Object) bool {
+	if obj.IsTombstone() {
+		return true
+	}
+
+	for i := range obj.Headers {
+		switch h := obj.Headers[i].Value.(type) {
+		case *object.Header_StorageGroup:
+			return true
+		case *object.Header_UserHeader:
+			if h.UserHeader == nil {
+				continue
+			}
+
+			if _, ok := systemHeaders[h.UserHeader.Key]; ok {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func userHeader(obj *object.Object, key string) (string, bool) {
+	for i := range obj.Headers {
+		if h, ok := obj.Headers[i].Value.(*object.Header_UserHeader); ok && h.UserHeader != nil && h.UserHeader.Key == key {
+			return h.UserHeader.Value, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,132 @@
+package acceptance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nspcc-dev/neofs-api-go/object"
+	"github.com/nspcc-dev/neofs-api-go/refs"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/localstore"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/retention"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func testObject(cid refs.CID, owner refs.OwnerID, size uint64, hs ...object.UserHeader) *localstore.ObjectMeta {
+	obj := &object.Object{
+		SystemHeader: object.SystemHeader{
+			CID:           cid,
+			OwnerID:       owner,
+			PayloadLength: size,
+		},
+	}
+
+	for i := range hs {
+		obj.Headers = append(obj.Headers, object.Header{
+			Value: &object.Header_UserHeader{UserHeader: &hs[i]},
+		})
+	}
+
+	return &localstore.ObjectMeta{Object: obj}
+}
+
+func TestCompile(t *testing.T) {
+	var (
+		cid1, cid2     = refs.CID{1}, refs.CID{2}
+		owner1, owner2 = refs.OwnerID{1}, refs.OwnerID{2}
+
+		contentType = object.UserHeader{Key: "Content-Type", Value: "text/plain"}
+	)
+
+	rules := []Rule{
+		{
+			Name:            "content type",
+			Priority:        1,
+			Containers:      []string{cid1.String()},
+			RequiredHeaders: []string{contentType.Key},
+		},
+		{
+			Name:           "max payload",
+			Owners:         []string{owner1.String()},
+			MaxPayloadSize: 10,
+		},
+		{
+			Name:     "trusted",
+			Priority: 2,
+			Owners:   []string{owner2.String()},
+			Headers:  []Header{{Key: "Trusted", Value: "true"}},
+			OnPass:   "pass",
+			OnFail:   "ignore",
+		},
+	}
+
+	f, err := Compile(rules)
+	require.NoError(t, err)
+
+	items := []struct {
+		meta *localstore.ObjectMeta
+		exp  localstore.FilterCode
+	}{
+		{testObject(cid2, owner2, 100), localstore.CodePass},
+		{testObject(cid1, owner2, 5), localstore.CodeFail},
+		{testObject(cid1, owner2, 5, contentType), localstore.CodePass},
+		{testObject(cid1, owner1, 100, contentType), localstore.CodeFail},
+		{testObject(cid2, owner1, 10), localstore.CodePass},
+		// trusted rule accepts the object before the other checks
+		{testObject(cid1, owner2, 100, object.UserHeader{Key: "Trusted", Value: "true"}), localstore.CodePass},
+	}
+
+	for i := range items {
+		res := f.Pass(context.Background(), items[i].meta)
+		require.Equal(t, items[i].exp, res.Code(), i)
+
+		if res.Code() == localstore.CodeFail {
+			require.True(t, errors.Is(errors.Cause(res.Err()), ErrRejected))
+		}
+	}
+
+	t.Run("invalid rules", func(t *testing.T) {
+		for _, r := range []Rule{
+			{},
+			{Name: "code", OnFail: "reject"},
+			{Name: "container", Containers: []string{"invalid"}},
+			{Name: "owner", Owners: []string{cid1.String()}},
+		} {
+			_, err := Compile([]Rule{r})
+			require.Error(t, err, r.Name)
+		}
+
+		_, err := Compile([]Rule{{Name: "a"}, {Name: "a"}})
+		require.Error(t, err)
+	})
+}
+
+func TestRule_SystemObjects(t *testing.T) {
+	rule := Rule{
+		Name:            "content type",
+		RequiredHeaders: []string{"Content-Type"},
+	}
+
+	tombstone := testObject(refs.CID{1}, refs.OwnerID{1}, 0)
+	tombstone.Object.Headers = append(tombstone.Object.Headers, object.Header{
+		Value: &object.Header_Tombstone{Tombstone: &object.Tombstone{}},
+	})
+
+	lock := testObject(refs.CID{1}, refs.OwnerID{1}, 0, object.UserHeader{Key: retention.Header, Value: "10"})
+
+	f, err := Compile([]Rule{rule})
+	require.NoError(t, err)
+
+	for _, meta := range []*localstore.ObjectMeta{tombstone, lock} {
+		require.Equal(t, localstore.CodePass, f.Pass(context.Background(), meta).Code())
+	}
+
+	t.Run("applicable", func(t *testing.T) {
+		rule.SystemObjects = true
+
+		f, err := Compile([]Rule{rule})
+		require.NoError(t, err)
+
+		require.Equal(t, localstore.CodeFail, f.Pass(context.Background(), tombstone).Code())
+	})
+}
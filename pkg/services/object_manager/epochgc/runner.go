@@ -0,0 +1,103 @@
+package epochgc
+
+import (
+	"context"
+
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/localstore"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/retention"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+type (
+	// Params groups the common parameters of the local storage GCs.
+	Params struct {
+		Localstore localstore.Localstore
+		Logger     *zap.Logger
+
+		// Holder checks the container legal hold,
+		// legal holds are not checked if it is nil.
+		Holder retention.Holder
+
+		// Locator finds the lock objects of the local objects
+		// stored on the other container nodes, only the local
+		// lock objects are checked if it is nil.
+		Locator retention.Locator
+	}
+
+	// CollectFunc collects the local objects in the epoch.
+	CollectFunc func(ctx context.Context, epoch uint64)
+
+	// Runner performs the collection of the local storage in background.
+	//
+	// Collection is triggered by the new epoch and performed by Run,
+	// so HandleEpoch does not block the event listener.
+	//
+	// Working Runner must be created via constructor NewRunner.
+	Runner struct {
+		collect CollectFunc
+
+		// the last epoch that is not collected yet
+		epochs chan uint64
+	}
+)
+
+var (
+	errNilLocalstore = errors.New("localstore is nil")
+	errNilLogger     = errors.New("logger is nil")
+	errNilCollector  = errors.New("collect function is nil")
+)
+
+// Validate checks if the required parameters are set.
+func (p Params) Validate() error {
+	switch {
+	case p.Localstore == nil:
+		return errNilLocalstore
+	case p.Logger == nil:
+		return errNilLogger
+	}
+
+	return nil
+}
+
+// NewRunner creates the Runner of the collect function.
+func NewRunner(collect CollectFunc) (*Runner, error) {
+	if collect == nil {
+		return nil, errNilCollector
+	}
+
+	return &Runner{
+		collect: collect,
+		epochs:  make(chan uint64, 1),
+	}, nil
+}
+
+// HandleEpoch schedules the collection in the epoch.
+// Pending collection of the earlier epoch is replaced.
+func (r *Runner) HandleEpoch(epoch uint64) {
+	for {
+		select {
+		case r.epochs <- epoch:
+			return
+		default:
+		}
+
+		select {
+		case <-r.epochs:
+		default:
+		}
+	}
+}
+
+// Run performs the collection on each scheduled epoch
+// until the context is done.
+func (r *Runner) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case epoch := <-r.epochs:
+			r.collect(ctx, epoch)
+		}
+	}
+}
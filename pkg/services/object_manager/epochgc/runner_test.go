@@ -0,0 +1,39 @@
+package epochgc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestParams_Validate(t *testing.T) {
+	require.EqualError(t, Params{Logger: zap.L()}.Validate(), errNilLocalstore.Error())
+}
+
+func TestNewRunner(t *testing.T) {
+	_, err := NewRunner(nil)
+	require.EqualError(t, err, errNilCollector.Error())
+}
+
+func TestRunner(t *testing.T) {
+	var (
+		ctx, cancel = context.WithCancel(context.Background())
+		collected   []uint64
+	)
+
+	r, err := NewRunner(func(_ context.Context, epoch uint64) {
+		collected = append(collected, epoch)
+		cancel()
+	})
+	require.NoError(t, err)
+
+	// the latest epoch replaces the pending one
+	r.HandleEpoch(2)
+	r.HandleEpoch(3)
+
+	r.Run(ctx)
+
+	require.Equal(t, []uint64{3}, collected)
+}
@@ -0,0 +1,229 @@
+package erasure
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Scheme describes the Reed-Solomon code of the object payload:
+// payload is split into Data parts, Parity parts are computed
+// from them, any Data parts of the whole set restore the payload.
+type Scheme struct {
+	Data   uint32
+	Parity uint32
+}
+
+// MaxParts is a maximum number of the data and parity parts.
+const MaxParts = 256
+
+var (
+	// ErrInvalidScheme is returned if the erasure coding scheme is malformed.
+	ErrInvalidScheme = errors.New("invalid erasure coding scheme")
+
+	// ErrTooFewParts is returned on reconstruction if less than
+	// Data parts are available.
+	ErrTooFewParts = errors.New("too few parts to reconstruct the payload")
+
+	// ErrPartSize is returned on reconstruction if the parts
+	// have the different sizes.
+	ErrPartSize = errors.New("parts have different sizes")
+)
+
+// ParseScheme parses the erasure coding scheme in the "DATA+PARITY" format.
+func ParseScheme(s string) (Scheme, error) {
+	items := strings.Split(s, "+")
+	if len(items) != 2 {
+		return Scheme{}, errors.Wrapf(ErrInvalidScheme, "malformed scheme %s", s)
+	}
+
+	data, err := strconv.ParseUint(items[0], 10, 32)
+	if err != nil {
+		return Scheme{}, errors.Wrapf(ErrInvalidScheme, "malformed data parts number %s", items[0])
+	}
+
+	parity, err := strconv.ParseUint(items[1], 10, 32)
+	if err != nil {
+		return Scheme{}, errors.Wrapf(ErrInvalidScheme, "malformed parity parts number %s", items[1])
+	}
+
+	res := Scheme{Data: uint32(data), Parity: uint32(parity)}
+
+	return res, res.Validate()
+}
+
+// String returns the scheme in the "DATA+PARITY" format.
+func (s Scheme) String() string {
+	return strconv.FormatUint(uint64(s.Data), 10) + "+" + strconv.FormatUint(uint64(s.Parity), 10)
+}
+
+// Parts returns the total number of the parts.
+func (s Scheme) Parts() int {
+	return int(s.Data) + int(s.Parity)
+}
+
+// Validate checks if the scheme can be used for encoding.
+func (s Scheme) Validate() error {
+	switch {
+	case s.Data == 0:
+		return errors.Wrap(ErrInvalidScheme, "no data parts")
+	case s.Parity == 0:
+		return errors.Wrap(ErrInvalidScheme, "no parity parts")
+	case s.Parts() > MaxParts:
+		return errors.Wrapf(ErrInvalidScheme, "more than %d parts", MaxParts)
+	}
+
+	return nil
+}
+
+// Encode splits the payload into the data parts of the equal size
+// and appends the parity parts. The last data part is padded
+// with zeros, payload size is required to join the parts back.
+func (s Scheme) Encode(payload []byte) ([][]byte, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	var (
+		k      = int(s.Data)
+		size   = (len(payload) + k - 1) / k
+		buf    = make([]byte, size*s.Parts())
+		shards = make([][]byte, s.Parts())
+	)
+
+	copy(buf, payload)
+
+	for i := range shards {
+		shards[i] = buf[i*size : (i+1)*size : (i+1)*size]
+	}
+
+	s.encodeParity(shards)
+
+	return shards, nil
+}
+
+// Reconstruct fills the missing (nil) parts from the present ones.
+//
+// At least Data parts must be present, empty parts of the empty
+// payload must be non-nil.
+func (s Scheme) Reconstruct(shards [][]byte) error {
+	if err := s.Validate(); err != nil {
+		return err
+	} else if len(shards) != s.Parts() {
+		return errors.Wrapf(ErrTooFewParts, "expected %d parts, got %d", s.Parts(), len(shards))
+	}
+
+	var (
+		k       = int(s.Data)
+		size    = -1
+		present = make([]int, 0, k)
+	)
+
+	for i := range shards {
+		if shards[i] == nil {
+			continue
+		} else if size < 0 {
+			size = len(shards[i])
+		} else if len(shards[i]) != size {
+			return ErrPartSize
+		}
+
+		if len(present) < k {
+			present = append(present, i)
+		}
+	}
+
+	if len(present) < k {
+		return errors.Wrapf(ErrTooFewParts, "%d of %d", len(present), k)
+	}
+
+	// data parts are restored from the first Data present parts
+	if present[k-1] >= k {
+		m := make([][]byte, k)
+		for i := range present {
+			m[i] = s.row(present[i])
+		}
+
+		inv, err := invert(m)
+		if err != nil {
+			return err
+		}
+
+		for i := 0; i < k; i++ {
+			if shards[i] != nil {
+				continue
+			}
+
+			shards[i] = make([]byte, size)
+
+			for j := range present {
+				mulAdd(shards[i], shards[present[j]], inv[i][j])
+			}
+		}
+	}
+
+	for i := k; i < len(shards); i++ {
+		if shards[i] == nil {
+			shards[i] = make([]byte, size)
+			s.encodeRow(shards, i)
+		}
+	}
+
+	return nil
+}
+
+// Join returns the payload of the given size from the data parts.
+func (s Scheme) Join(shards [][]byte, size uint64) ([]byte, error) {
+	if len(shards) < int(s.Data) {
+		return nil, errors.Wrapf(ErrTooFewParts, "%d of %d", len(shards), s.Data)
+	}
+
+	payload := make([]byte, 0, size)
+
+	for i := 0; i < int(s.Data) && uint64(len(payload)) < size; i++ {
+		if shards[i] == nil {
+			return nil, errors.Wrapf(ErrTooFewParts, "missing data part %d", i)
+		}
+
+		payload = append(payload, shards[i]...)
+	}
+
+	if uint64(len(payload)) < size {
+		return nil, errors.Errorf("payload size %d exceeds the parts size %d", size, len(payload))
+	}
+
+	return payload[:size], nil
+}
+
+func (s Scheme) encodeParity(shards [][]byte) {
+	for i := int(s.Data); i < len(shards); i++ {
+		s.encodeRow(shards, i)
+	}
+}
+
+func (s Scheme) encodeRow(shards [][]byte, i int) {
+	row := s.row(i)
+
+	for j := range row {
+		mulAdd(shards[i], shards[j], row[j])
+	}
+}
+
+// row returns the row of the systematic encoding matrix: the identity
+// rows for the data parts and the Cauchy matrix rows for the parity
+// parts, so any Data rows form the invertible matrix.
+func (s Scheme) row(i int) []byte {
+	row := make([]byte, s.Data)
+
+	if i < int(s.Data) {
+		row[i] = 1
+		return row
+	}
+
+	for j := range row {
+		row[j] = gfInv(byte(i) ^ byte(j))
+	}
+
+	return row
+}
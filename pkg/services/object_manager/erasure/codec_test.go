@@ -0,0 +1,72 @@
+package erasure
+
+import (
+	"crypto/rand"
+	"strconv"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheme(t *testing.T) {
+	s, err := ParseScheme("4+2")
+	require.NoError(t, err)
+	require.Equal(t, Scheme{Data: 4, Parity: 2}, s)
+	require.Equal(t, "4+2", s.String())
+	require.Equal(t, 6, s.Parts())
+
+	for _, str := range []string{"", "4", "4+", "+2", "four+2", "4+2+1", "0+2", "4+0", "200+57"} {
+		_, err := ParseScheme(str)
+		require.True(t, errors.Is(errors.Cause(err), ErrInvalidScheme), str)
+	}
+}
+
+func TestScheme_Reconstruct(t *testing.T) {
+	for _, s := range []Scheme{{1, 1}, {2, 1}, {4, 2}, {3, 3}, {10, 4}} {
+		for _, size := range []int{0, 1, 7, 1001} {
+			payload := make([]byte, size)
+			_, err := rand.Read(payload)
+			require.NoError(t, err)
+
+			shards, err := s.Encode(payload)
+			require.NoError(t, err)
+			require.Len(t, shards, s.Parts())
+
+			// any Parity parts can be lost
+			for first := 0; first+int(s.Parity) <= s.Parts(); first++ {
+				msg := s.String() + " " + strconv.Itoa(size) + " " + strconv.Itoa(first)
+
+				lost := make([][]byte, len(shards))
+				for i := range shards {
+					if i < first || i >= first+int(s.Parity) {
+						lost[i] = append([]byte{}, shards[i]...)
+					}
+				}
+
+				require.NoError(t, s.Reconstruct(lost), msg)
+				require.Equal(t, shards, lost, msg)
+
+				res, err := s.Join(lost, uint64(size))
+				require.NoError(t, err, msg)
+				require.Equal(t, payload, res, msg)
+			}
+
+			lost := make([][]byte, len(shards))
+			copy(lost, shards)
+
+			for i := 0; i <= int(s.Parity); i++ {
+				lost[i] = nil
+			}
+
+			err = s.Reconstruct(lost)
+			require.True(t, errors.Is(errors.Cause(err), ErrTooFewParts))
+		}
+	}
+
+	shards, err := Scheme{Data: 2, Parity: 1}.Encode([]byte{1, 2, 3})
+	require.NoError(t, err)
+
+	shards[0] = shards[0][:1]
+	require.EqualError(t, Scheme{Data: 2, Parity: 1}.Reconstruct(shards), ErrPartSize.Error())
+}
@@ -0,0 +1,504 @@
+package erasure
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/nspcc-dev/neofs-api-go/hash"
+	"github.com/nspcc-dev/neofs-api-go/object"
+	"github.com/nspcc-dev/neofs-api-go/refs"
+	"github.com/pkg/errors"
+)
+
+type (
+	// Part groups the erasure coding parameters of the part object.
+	Part struct {
+		Scheme Scheme
+
+		// Index is the number of the part, data parts go first.
+		Index int
+
+		// Size is the payload size of the parent object.
+		Size uint64
+
+		Parent refs.ObjectID
+
+		// number of the parent object headers carried by the part
+		parentHeaders int
+	}
+
+	// SignFunc is a function that adds the integrity header to the object.
+	SignFunc func(*object.Object) error
+)
+
+const (
+	// SchemeHeader is a user header key of the erasure coding scheme
+	// in the "DATA+PARITY" format. Parent object and parts carry it.
+	SchemeHeader = "__NEOFS__EC_SCHEME"
+
+	// IndexHeader is a user header key of the part index.
+	IndexHeader = "__NEOFS__EC_INDEX"
+
+	// SizeHeader is a user header key of the parent payload size.
+	SizeHeader = "__NEOFS__EC_SIZE"
+
+	// IntegrityHeader is a user header key of the part integrity header
+	// in the "INDEX:BASE64" format. Parent object carries the integrity
+	// headers of all the parts, so the lost part can be recreated.
+	IntegrityHeader = "__NEOFS__EC_INTEGRITY"
+)
+
+// version of the UUID of the part objects
+const partVersion = 8
+
+var (
+	// ErrInvalidPart is returned if the part headers are malformed.
+	ErrInvalidPart = errors.New("invalid erasure coded part")
+
+	// ErrChecksum is returned if the restored payload does not
+	// match the payload checksum of the parent object.
+	ErrChecksum = errors.New("restored payload checksum mismatch")
+
+	errMissingIntegrity = errors.New("missing part integrity header")
+
+	errPartVersion = errors.New("parent ID has the version of the part IDs")
+)
+
+// PartID returns the ID of the part object.
+//
+// Part IDs are the UUIDs of the custom version derived from the hash
+// of the parent ID: the parts of the same parent share all the bytes
+// but the last one that holds the part index, the byte before it holds
+// the number of the parts, so the placement of the part is computed
+// from its address. Index and parent of the part are carried in its
+// headers, see ParsePart.
+func PartID(parent refs.ObjectID, scheme Scheme, index int) refs.ObjectID {
+	id := partPrefix(parent)
+	id[14] = byte(scheme.Parts() - 1)
+	id[15] = byte(index)
+
+	return id
+}
+
+// IsPartOf checks if the ID is the ID of the part of the parent object.
+//
+// Any object ID can have the version of the part IDs,
+// so the parts are recognized by their parent.
+func IsPartOf(id, parent refs.ObjectID) bool {
+	prefix := partPrefix(parent)
+
+	return bytes.Equal(id[:14], prefix[:14])
+}
+
+// partPrefix returns the ID bytes shared by the parts of the parent.
+func partPrefix(parent refs.ObjectID) refs.ObjectID {
+	id := refs.ObjectID(uuid.NewSHA1(uuid.UUID(parent), []byte(SchemeHeader)))
+	id[6] = id[6]&0x0f | partVersion<<4
+
+	return id
+}
+
+// PartIndex returns the part index from the ID of the part object.
+//
+// Objects of the regular containers can have the IDs of the same
+// version, so the result is meaningful for the erasure coded
+// containers only, other objects are recognized by ParsePart.
+func PartIndex(id refs.ObjectID) (int, bool) {
+	if id[6]>>4 != partVersion {
+		return 0, false
+	}
+
+	return int(id[15]), true
+}
+
+// PartCount returns the number of the parts of the same parent
+// from the ID of the part object, see PartIndex.
+func PartCount(id refs.ObjectID) int {
+	return int(id[14]) + 1
+}
+
+// PartSeed returns the placement seed of the parts of the same parent.
+func PartSeed(id refs.ObjectID) []byte {
+	id[15] = 0
+
+	return id[:]
+}
+
+// IsParent checks if the object is the parent object of the parts.
+func IsParent(obj *object.Object) bool {
+	var scheme bool
+
+	for i := range obj.Headers {
+		switch userHeaderKey(obj.Headers[i]) {
+		case SchemeHeader:
+			scheme = true
+		case IndexHeader:
+			return false
+		}
+	}
+
+	return scheme
+}
+
+// ParentScheme returns the erasure coding scheme of the parent object.
+func ParentScheme(obj *object.Object) (Scheme, error) {
+	if !IsParent(obj) {
+		return Scheme{}, errors.New("object is not a parent of the erasure coded parts")
+	}
+
+	for i := range obj.Headers {
+		if userHeaderKey(obj.Headers[i]) == SchemeHeader {
+			return ParseScheme(obj.Headers[i].Value.(*object.Header_UserHeader).UserHeader.Value)
+		}
+	}
+
+	return Scheme{}, nil
+}
+
+// ParsePart returns the erasure coding parameters of the part object.
+//
+// Objects without the part index are not the parts.
+func ParsePart(obj *object.Object) (res Part, ok bool, err error) {
+	var schemeHdr, indexHdr, sizeHdr string
+
+	for i := range obj.Headers {
+		switch h := obj.Headers[i].Value.(type) {
+		case *object.Header_UserHeader:
+			if h.UserHeader == nil {
+				continue
+			}
+
+			switch h.UserHeader.Key {
+			case SchemeHeader:
+				schemeHdr, res.parentHeaders = h.UserHeader.Value, i
+			case IndexHeader:
+				indexHdr, ok = h.UserHeader.Value, true
+			case SizeHeader:
+				sizeHdr = h.UserHeader.Value
+			}
+		case *object.Header_Link:
+			if h.Link != nil && h.Link.Type == object.Link_Parent {
+				res.Parent = h.Link.ID
+			}
+		}
+	}
+
+	if !ok {
+		return res, false, nil
+	} else if res.Scheme, err = ParseScheme(schemeHdr); err != nil {
+		return res, true, errors.Wrap(ErrInvalidPart, err.Error())
+	} else if res.Index, err = strconv.Atoi(indexHdr); err != nil || res.Index < 0 || res.Index >= res.Scheme.Parts() {
+		return res, true, errors.Wrapf(ErrInvalidPart, "invalid index %s", indexHdr)
+	} else if res.Size, err = strconv.ParseUint(sizeHdr, 10, 64); err != nil {
+		return res, true, errors.Wrapf(ErrInvalidPart, "invalid size %s", sizeHdr)
+	} else if obj.SystemHeader.ID != PartID(res.Parent, res.Scheme, res.Index) {
+		return res, true, errors.Wrap(ErrInvalidPart, "part ID does not match the parent")
+	}
+
+	return res, true, nil
+}
+
+// Split encodes the payload of the object into the parts.
+//
+// Parts carry the headers of the parent object with the payload checksum
+// signed by sign, so the parent object is restored from any Data parts.
+// Parts are returned without the integrity headers.
+func Split(obj *object.Object, payload []byte, scheme Scheme, sign SignFunc) ([]*object.Object, error) {
+	// parent would be placed as a part
+	if _, ok := PartIndex(obj.SystemHeader.ID); ok {
+		return nil, errPartVersion
+	}
+
+	shards, err := scheme.Encode(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		src      = sourceHeaders(obj.Headers)
+		checksum = sha256.Sum256(payload)
+		parent   = &object.Object{
+			SystemHeader: obj.SystemHeader,
+			Headers: append(src[:len(src):len(src)],
+				object.Header{Value: &object.Header_HomoHash{HomoHash: hash.Sum(payload)}},
+				object.Header{Value: &object.Header_PayloadChecksum{PayloadChecksum: checksum[:]}},
+			),
+		}
+	)
+
+	parent.SystemHeader.PayloadLength = uint64(len(payload))
+
+	if err := sign(parent); err != nil {
+		return nil, errors.Wrap(err, "could not sign parent headers")
+	}
+
+	parts := make([]*object.Object, 0, len(shards))
+
+	for i := range shards {
+		parts = append(parts, partObject(parent, scheme, i, shards[i]))
+	}
+
+	return parts, nil
+}
+
+// Link returns the parent object of the signed parts.
+//
+// Parent object has an empty payload with its checksum, so it passes
+// the integrity checks of the stored objects. It links the parts
+// as the children and carries their integrity headers.
+func Link(parts []*object.Object) (*object.Object, error) {
+	if len(parts) == 0 {
+		return nil, errors.Wrap(ErrTooFewParts, "no parts")
+	}
+
+	p, ok, err := ParsePart(parts[0])
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, ErrInvalidPart
+	}
+
+	var (
+		src      = sourceHeaders(parts[0].Headers[:p.parentHeaders])
+		checksum = sha256.Sum256(nil)
+		parent   = &object.Object{
+			SystemHeader: parts[0].SystemHeader,
+			Headers: append(src[:len(src):len(src)],
+				userHeader(SchemeHeader, p.Scheme.String()),
+				userHeader(SizeHeader, strconv.FormatUint(p.Size, 10)),
+				object.Header{Value: &object.Header_PayloadChecksum{PayloadChecksum: checksum[:]}},
+			),
+		}
+	)
+
+	parent.SystemHeader.ID = p.Parent
+	parent.SystemHeader.PayloadLength = 0
+
+	for i := range parts {
+		parent.Headers = append(parent.Headers, object.Header{Value: &object.Header_Link{Link: &object.Link{
+			Type: object.Link_Child,
+			ID:   parts[i].SystemHeader.ID,
+		}}})
+	}
+
+	for i := range parts {
+		_, h := parts[i].LastHeader(object.HeaderType(object.IntegrityHdr))
+		if h == nil {
+			return nil, errors.Wrapf(errMissingIntegrity, "part %d", i)
+		}
+
+		data, err := h.Value.(*object.Header_Integrity).Integrity.Marshal()
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not marshal integrity header of part %d", i)
+		}
+
+		parent.Headers = append(parent.Headers, userHeader(IntegrityHeader,
+			strconv.Itoa(i)+":"+base64.StdEncoding.EncodeToString(data)))
+	}
+
+	return parent, nil
+}
+
+// ParentHeader returns the headers of the parent object carried by the part.
+func ParentHeader(part *object.Object) (*object.Object, error) {
+	p, ok, err := ParsePart(part)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, ErrInvalidPart
+	}
+
+	res := &object.Object{
+		SystemHeader: part.SystemHeader,
+		Headers:      append([]object.Header(nil), part.Headers[:p.parentHeaders]...),
+	}
+
+	res.SystemHeader.ID = p.Parent
+	res.SystemHeader.PayloadLength = p.Size
+
+	return res, nil
+}
+
+// Restore returns the parent object with the payload restored from the parts.
+//
+// Parts with the broken payload and the parts of the other parents
+// are ignored, at least Data valid parts are required.
+func Restore(parts []*object.Object) (*object.Object, error) {
+	p, first, shards, err := collect(parts)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := ParentHeader(first)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.Scheme.Reconstruct(shards); err != nil {
+		return nil, err
+	} else if res.Payload, err = p.Scheme.Join(shards, p.Size); err != nil {
+		return nil, err
+	} else if cs := sha256.Sum256(res.Payload); !bytes.Equal(cs[:], payloadChecksum(res)) {
+		return nil, ErrChecksum
+	}
+
+	return res, nil
+}
+
+// RebuildPart recreates the part object of the parent from the other parts.
+//
+// Integrity header of the part is taken from the parent object,
+// so the recreated part is identical to the lost one.
+func RebuildPart(parent *object.Object, parts []*object.Object, index int) (*object.Object, error) {
+	integrity, err := partIntegrity(parent, index)
+	if err != nil {
+		return nil, err
+	}
+
+	p, first, shards, err := collect(parts)
+	if err != nil {
+		return nil, err
+	} else if p.Parent != parent.SystemHeader.ID {
+		return nil, errors.Wrap(ErrInvalidPart, "parts of the other parent")
+	} else if index < 0 || index >= p.Scheme.Parts() {
+		return nil, errors.Wrapf(ErrInvalidPart, "invalid index %d", index)
+	}
+
+	view, err := ParentHeader(first)
+	if err != nil {
+		return nil, err
+	} else if err := p.Scheme.Reconstruct(shards); err != nil {
+		return nil, err
+	}
+
+	res := partObject(view, p.Scheme, index, shards[index])
+	res.AddHeader(&object.Header{Value: &object.Header_Integrity{Integrity: integrity}})
+
+	return res, nil
+}
+
+// collect returns the payloads of the valid parts of the same parent by index.
+func collect(parts []*object.Object) (res Part, first *object.Object, shards [][]byte, err error) {
+	for _, obj := range parts {
+		if obj == nil {
+			continue
+		}
+
+		p, ok, err := ParsePart(obj)
+		if err != nil || !ok {
+			continue
+		} else if first == nil {
+			res, first, shards = p, obj, make([][]byte, p.Scheme.Parts())
+		} else if p.Parent != res.Parent || p.Scheme != res.Scheme {
+			continue
+		}
+
+		if cs := sha256.Sum256(obj.Payload); bytes.Equal(cs[:], payloadChecksum(obj)) {
+			// nil is a missing part, empty payload is decoded as nil
+			shards[p.Index] = append(make([]byte, 0, len(obj.Payload)), obj.Payload...)
+		}
+	}
+
+	if first == nil {
+		return res, nil, nil, errors.Wrap(ErrTooFewParts, "no valid parts")
+	}
+
+	return res, first, shards, nil
+}
+
+func partObject(parent *object.Object, scheme Scheme, index int, shard []byte) *object.Object {
+	var (
+		checksum = sha256.Sum256(shard)
+		res      = &object.Object{
+			SystemHeader: parent.SystemHeader,
+			Headers:      make([]object.Header, 0, len(parent.Headers)+7),
+			Payload:      shard,
+		}
+	)
+
+	res.SystemHeader.ID = PartID(parent.SystemHeader.ID, scheme, index)
+	res.SystemHeader.PayloadLength = uint64(len(shard))
+
+	res.Headers = append(append(res.Headers, parent.Headers...),
+		userHeader(SchemeHeader, scheme.String()),
+		userHeader(IndexHeader, strconv.Itoa(index)),
+		userHeader(SizeHeader, strconv.FormatUint(parent.SystemHeader.PayloadLength, 10)),
+		object.Header{Value: &object.Header_Link{Link: &object.Link{
+			Type: object.Link_Parent,
+			ID:   parent.SystemHeader.ID,
+		}}},
+		object.Header{Value: &object.Header_HomoHash{HomoHash: hash.Sum(shard)}},
+		object.Header{Value: &object.Header_PayloadChecksum{PayloadChecksum: checksum[:]}},
+	)
+
+	return res
+}
+
+func partIntegrity(parent *object.Object, index int) (*object.IntegrityHeader, error) {
+	prefix := strconv.Itoa(index) + ":"
+
+	for i := range parent.Headers {
+		h, ok := parent.Headers[i].Value.(*object.Header_UserHeader)
+		if !ok || h.UserHeader == nil || h.UserHeader.Key != IntegrityHeader ||
+			!strings.HasPrefix(h.UserHeader.Value, prefix) {
+			continue
+		}
+
+		data, err := base64.StdEncoding.DecodeString(h.UserHeader.Value[len(prefix):])
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not decode integrity header of part %d", index)
+		}
+
+		res := new(object.IntegrityHeader)
+		if err := res.Unmarshal(data); err != nil {
+			return nil, errors.Wrapf(err, "could not unmarshal integrity header of part %d", index)
+		}
+
+		return res, nil
+	}
+
+	return nil, errors.Wrapf(errMissingIntegrity, "part %d", index)
+}
+
+// sourceHeaders returns the headers without the verification ones.
+func sourceHeaders(hs []object.Header) []object.Header {
+	res := make([]object.Header, 0, len(hs))
+
+	for i := range hs {
+		switch hs[i].Value.(type) {
+		case *object.Header_PayloadChecksum, *object.Header_HomoHash, *object.Header_Integrity:
+		default:
+			res = append(res, hs[i])
+		}
+	}
+
+	return res
+}
+
+func payloadChecksum(obj *object.Object) []byte {
+	_, h := obj.LastHeader(object.HeaderType(object.PayloadChecksumHdr))
+	if h == nil {
+		return nil
+	}
+
+	return h.Value.(*object.Header_PayloadChecksum).PayloadChecksum
+}
+
+func userHeaderKey(h object.Header) string {
+	if v, ok := h.Value.(*object.Header_UserHeader); ok && v.UserHeader != nil {
+		return v.UserHeader.Key
+	}
+
+	return ""
+}
+
+func userHeader(key, value string) object.Header {
+	return object.Header{Value: &object.Header_UserHeader{UserHeader: &object.UserHeader{
+		Key:   key,
+		Value: value,
+	}}}
+}
@@ -0,0 +1,209 @@
+package erasure
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"strconv"
+	"testing"
+
+	"github.com/nspcc-dev/neofs-api-go/object"
+	"github.com/nspcc-dev/neofs-api-go/refs"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func testSign(obj *object.Object) error {
+	integrity := new(object.IntegrityHeader)
+	integrity.SetHeadersChecksum(obj.SystemHeader.ID.Bytes())
+	integrity.ChecksumSignature = []byte(strconv.Itoa(len(obj.Headers)))
+
+	obj.AddHeader(&object.Header{Value: &object.Header_Integrity{Integrity: integrity}})
+
+	return nil
+}
+
+func testParts(t *testing.T, scheme Scheme, payload []byte) (*object.Object, []*object.Object) {
+	id, err := refs.NewObjectID()
+	require.NoError(t, err)
+
+	obj := &object.Object{
+		SystemHeader: object.SystemHeader{
+			Version: 1,
+			ID:      id,
+			CID:     refs.CIDForBytes([]byte("container")),
+		},
+		Headers: []object.Header{userHeader("Name", "value")},
+	}
+
+	parts, err := Split(obj, payload, scheme, testSign)
+	require.NoError(t, err)
+
+	for i := range parts {
+		require.NoError(t, testSign(parts[i]))
+	}
+
+	parent, err := Link(parts)
+	require.NoError(t, err)
+
+	return parent, parts
+}
+
+func TestPartID(t *testing.T) {
+	parent, err := refs.NewObjectID()
+	require.NoError(t, err)
+
+	_, ok := PartIndex(parent)
+	require.False(t, ok)
+
+	var (
+		scheme = Scheme{Data: MaxParts - 16, Parity: 16}
+		seed   = PartSeed(PartID(parent, scheme, 0))
+	)
+
+	for i := 0; i < MaxParts; i++ {
+		id := PartID(parent, scheme, i)
+
+		index, ok := PartIndex(id)
+		require.True(t, ok)
+		require.Equal(t, i, index)
+		require.Equal(t, MaxParts, PartCount(id))
+		require.Equal(t, seed, PartSeed(id))
+		require.True(t, IsPartOf(id, parent))
+	}
+
+	require.False(t, IsPartOf(parent, parent))
+
+	other := PartID(parent, Scheme{Data: 2, Parity: 1}, 0)
+	require.Equal(t, 3, PartCount(other))
+	require.NotEqual(t, seed, PartSeed(other))
+
+	// parents differing in the bytes of the part index and version
+	for _, i := range []int{6, 15} {
+		other := parent
+		other[i] ^= 0xf0
+
+		require.NotEqual(t, PartID(parent, scheme, 1), PartID(other, scheme, 1))
+		require.NotEqual(t, seed, PartSeed(PartID(other, scheme, 0)))
+	}
+}
+
+func TestSplit(t *testing.T) {
+	var (
+		scheme  = Scheme{Data: 3, Parity: 2}
+		payload = make([]byte, 100)
+	)
+
+	_, err := rand.Read(payload)
+	require.NoError(t, err)
+
+	parent, parts := testParts(t, scheme, payload)
+	require.Len(t, parts, scheme.Parts())
+
+	require.True(t, IsParent(parent))
+	require.Zero(t, parent.SystemHeader.PayloadLength)
+
+	emptyChecksum := sha256.Sum256(nil)
+	require.Equal(t, emptyChecksum[:], payloadChecksum(parent))
+	require.Len(t, parent.Links(object.Link_Child), scheme.Parts())
+
+	parentScheme, err := ParentScheme(parent)
+	require.NoError(t, err)
+	require.Equal(t, scheme, parentScheme)
+
+	for i := range parts {
+		require.False(t, IsParent(parts[i]))
+
+		p, ok, err := ParsePart(parts[i])
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, Part{
+			Scheme:        scheme,
+			Index:         i,
+			Size:          uint64(len(payload)),
+			Parent:        parent.SystemHeader.ID,
+			parentHeaders: 4, // user header, homomorphic hash, checksum, integrity
+		}, p)
+
+		view, err := ParentHeader(parts[i])
+		require.NoError(t, err)
+		require.Equal(t, parent.SystemHeader.ID, view.SystemHeader.ID)
+		require.Equal(t, uint64(len(payload)), view.SystemHeader.PayloadLength)
+	}
+
+	_, ok, err := ParsePart(parent)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	_, err = ParentScheme(parts[0])
+	require.Error(t, err)
+
+	t.Run("restore", func(t *testing.T) {
+		// any Data parts restore the payload
+		for first := 0; first+int(scheme.Data) <= len(parts); first++ {
+			res, err := Restore(parts[first : first+int(scheme.Data)])
+			require.NoError(t, err, strconv.Itoa(first))
+			require.Equal(t, payload, res.Payload, strconv.Itoa(first))
+			require.Equal(t, parent.SystemHeader.ID, res.SystemHeader.ID)
+		}
+
+		broken := *parts[0]
+		broken.Payload = append([]byte{}, broken.Payload...)
+		broken.Payload[0]++
+
+		_, err := Restore([]*object.Object{&broken, parts[1], parts[2]})
+		require.True(t, errors.Is(errors.Cause(err), ErrTooFewParts))
+
+		_, err = Restore([]*object.Object{&broken, parts[1], parts[2], parts[3]})
+		require.NoError(t, err)
+	})
+
+	t.Run("rebuild", func(t *testing.T) {
+		for i := range parts {
+			res, err := RebuildPart(parent, append(parts[:i:i], parts[i+1:]...), i)
+			require.NoError(t, err, strconv.Itoa(i))
+			require.Equal(t, parts[i], res, strconv.Itoa(i))
+		}
+
+		_, err := RebuildPart(parent, parts, scheme.Parts())
+		require.Error(t, err)
+	})
+
+	t.Run("invalid part", func(t *testing.T) {
+		invalid := *parts[0]
+		invalid.SystemHeader.ID = PartID(parent.SystemHeader.ID, scheme, 1)
+
+		_, ok, err := ParsePart(&invalid)
+		require.True(t, ok)
+		require.True(t, errors.Is(errors.Cause(err), ErrInvalidPart))
+	})
+}
+
+func TestSplit_PartVersion(t *testing.T) {
+	parent, err := refs.NewObjectID()
+	require.NoError(t, err)
+
+	obj := &object.Object{
+		SystemHeader: object.SystemHeader{
+			ID:  PartID(parent, Scheme{Data: 2, Parity: 1}, 0),
+			CID: refs.CIDForBytes([]byte("container")),
+		},
+	}
+
+	_, err = Split(obj, make([]byte, 10), Scheme{Data: 2, Parity: 1}, testSign)
+	require.EqualError(t, err, errPartVersion.Error())
+}
+
+func TestSplit_EmptyPayload(t *testing.T) {
+	parent, parts := testParts(t, Scheme{Data: 2, Parity: 1}, nil)
+
+	for i := range parts {
+		parts[i].Payload = nil
+	}
+
+	res, err := Restore(parts[1:])
+	require.NoError(t, err)
+	require.Empty(t, res.Payload)
+
+	_, err = RebuildPart(parent, parts[1:], 0)
+	require.NoError(t, err)
+}
@@ -0,0 +1,102 @@
+package erasure
+
+import (
+	"github.com/pkg/errors"
+)
+
+// arithmetic of GF(2^8) with the x^8 + x^4 + x^3 + x^2 + 1 polynomial
+const gfPoly = 0x11d
+
+var (
+	gfExp [2 * 255]byte
+	gfLog [256]byte
+)
+
+var errSingularMatrix = errors.New("singular matrix")
+
+func init() {
+	x := 1
+
+	for i := 0; i < 255; i++ {
+		gfExp[i], gfExp[i+255] = byte(x), byte(x)
+		gfLog[x] = byte(i)
+
+		if x <<= 1; x&0x100 != 0 {
+			x ^= gfPoly
+		}
+	}
+}
+
+func gfMul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+
+	return gfExp[int(gfLog[a])+int(gfLog[b])]
+}
+
+// gfInv returns the multiplicative inverse of the non-zero element.
+func gfInv(a byte) byte {
+	return gfExp[255-int(gfLog[a])]
+}
+
+// mulAdd adds c * src to dst.
+func mulAdd(dst, src []byte, c byte) {
+	if c == 0 {
+		return
+	}
+
+	for i := range src {
+		dst[i] ^= gfMul(c, src[i])
+	}
+}
+
+// invert returns the inverse of the square matrix
+// by the Gauss-Jordan elimination.
+func invert(m [][]byte) ([][]byte, error) {
+	var (
+		n   = len(m)
+		a   = make([][]byte, n)
+		inv = make([][]byte, n)
+	)
+
+	for i := range m {
+		a[i] = append([]byte(nil), m[i]...)
+		inv[i] = make([]byte, n)
+		inv[i][i] = 1
+	}
+
+	for col := 0; col < n; col++ {
+		pivot := -1
+
+		for row := col; row < n; row++ {
+			if a[row][col] != 0 {
+				pivot = row
+				break
+			}
+		}
+
+		if pivot < 0 {
+			return nil, errSingularMatrix
+		}
+
+		a[col], a[pivot] = a[pivot], a[col]
+		inv[col], inv[pivot] = inv[pivot], inv[col]
+
+		if c := gfInv(a[col][col]); c != 1 {
+			for j := 0; j < n; j++ {
+				a[col][j] = gfMul(a[col][j], c)
+				inv[col][j] = gfMul(inv[col][j], c)
+			}
+		}
+
+		for row := 0; row < n; row++ {
+			if c := a[row][col]; row != col && c != 0 {
+				mulAdd(a[row], a[col], c)
+				mulAdd(inv[row], inv[col], c)
+			}
+		}
+	}
+
+	return inv, nil
+}
@@ -0,0 +1,107 @@
+package erasure
+
+import (
+	"github.com/nspcc-dev/neofs-api-go/refs"
+	"github.com/nspcc-dev/neofs-node/pkg/core/container/storage"
+	"github.com/nspcc-dev/netmap"
+	"github.com/pkg/errors"
+)
+
+type (
+	// Source is an interface of entity
+	// that returns the erasure coding scheme of the container.
+	Source interface {
+		Scheme(refs.CID) (Scheme, bool, error)
+	}
+
+	storageSource struct {
+		storage storage.Storage
+	}
+)
+
+// SelectorKey is a key of the placement rule selector that turns on
+// the erasure coding of the container objects.
+//
+// Count of the selector is the number of the parity parts, the rest
+// of the nodes selected by the rule hold the data parts. Selector is
+// not applied to the network map, so the rule with the "ErasureParity 2"
+// selector and 6 nodes selected stores the objects in 4+2 scheme.
+const SelectorKey = "ErasureParity"
+
+// FromRule returns the erasure coding scheme of the placement rule.
+//
+// Erasure coding rule has the single group of selectors and does not
+// replicate the objects: parts are placed on the distinct nodes instead.
+func FromRule(rule netmap.PlacementRule) (Scheme, bool, error) {
+	var (
+		found         bool
+		parity, nodes uint32 = 0, 1
+	)
+
+	for i := range rule.SFGroups {
+		for _, sel := range rule.SFGroups[i].Selectors {
+			if sel.Key != SelectorKey {
+				nodes *= sel.Count
+			} else if found {
+				return Scheme{}, true, errors.Wrap(ErrInvalidScheme, "multiple erasure selectors")
+			} else {
+				found, parity = true, sel.Count
+			}
+		}
+	}
+
+	switch {
+	case !found:
+		return Scheme{}, false, nil
+	case len(rule.SFGroups) != 1:
+		return Scheme{}, true, errors.Wrap(ErrInvalidScheme, "erasure coding rule with multiple groups")
+	case rule.ReplFactor > 1:
+		return Scheme{}, true, errors.Wrap(ErrInvalidScheme, "erasure coding rule with replication factor")
+	case nodes <= parity:
+		return Scheme{}, true, errors.Wrapf(ErrInvalidScheme, "%d nodes for %d parity parts", nodes, parity)
+	}
+
+	res := Scheme{Data: nodes - parity, Parity: parity}
+
+	return res, true, res.Validate()
+}
+
+// TrimRule returns the placement rule without the erasure selector.
+func TrimRule(rule netmap.PlacementRule) netmap.PlacementRule {
+	res := rule
+	res.SFGroups = make([]netmap.SFGroup, 0, len(rule.SFGroups))
+
+	for _, group := range rule.SFGroups {
+		selectors := make([]netmap.Select, 0, len(group.Selectors))
+
+		for _, sel := range group.Selectors {
+			if sel.Key != SelectorKey {
+				selectors = append(selectors, sel)
+			}
+		}
+
+		group.Selectors = selectors
+		res.SFGroups = append(res.SFGroups, group)
+	}
+
+	return res
+}
+
+// NewSource constructs Source that reads
+// the placement rule of the container.
+func NewSource(s storage.Storage) (Source, error) {
+	if s == nil {
+		return nil, storage.ErrNilStorage
+	}
+
+	return &storageSource{storage: s}, nil
+}
+
+func (s *storageSource) Scheme(cid refs.CID) (Scheme, bool, error) {
+	cnr, err := s.storage.Get(cid)
+	if err != nil {
+		return Scheme{}, false, errors.Wrap(err, "could not get container")
+	}
+
+	return FromRule(cnr.PlacementRule())
+}
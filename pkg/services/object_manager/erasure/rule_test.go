@@ -0,0 +1,114 @@
+package erasure
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neofs-node/pkg/core/container"
+	"github.com/nspcc-dev/neofs-node/pkg/core/container/storage/test"
+	"github.com/nspcc-dev/netmap"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func testRule(repl uint32, selectors ...netmap.Select) netmap.PlacementRule {
+	return netmap.PlacementRule{
+		ReplFactor: repl,
+		SFGroups:   []netmap.SFGroup{{Selectors: selectors}},
+	}
+}
+
+func TestFromRule(t *testing.T) {
+	_, ok, err := FromRule(testRule(2, netmap.Select{Key: netmap.NodesBucket, Count: 3}))
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	s, ok, err := FromRule(testRule(1,
+		netmap.Select{Key: SelectorKey, Count: 2},
+		netmap.Select{Key: "Country", Count: 2},
+		netmap.Select{Key: netmap.NodesBucket, Count: 3},
+	))
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, Scheme{Data: 4, Parity: 2}, s)
+
+	for _, rule := range []netmap.PlacementRule{
+		testRule(2,
+			netmap.Select{Key: SelectorKey, Count: 1},
+			netmap.Select{Key: netmap.NodesBucket, Count: 3},
+		),
+		testRule(0,
+			netmap.Select{Key: SelectorKey, Count: 3},
+			netmap.Select{Key: netmap.NodesBucket, Count: 3},
+		),
+		testRule(0,
+			netmap.Select{Key: SelectorKey, Count: 1},
+			netmap.Select{Key: SelectorKey, Count: 1},
+			netmap.Select{Key: netmap.NodesBucket, Count: 3},
+		),
+		{SFGroups: []netmap.SFGroup{
+			{Selectors: []netmap.Select{{Key: SelectorKey, Count: 1}, {Key: netmap.NodesBucket, Count: 3}}},
+			{Selectors: []netmap.Select{{Key: netmap.NodesBucket, Count: 3}}},
+		}},
+	} {
+		_, ok, err := FromRule(rule)
+		require.True(t, ok)
+		require.True(t, errors.Is(errors.Cause(err), ErrInvalidScheme))
+	}
+}
+
+func TestTrimRule(t *testing.T) {
+	rule := testRule(0,
+		netmap.Select{Key: SelectorKey, Count: 2},
+		netmap.Select{Key: netmap.NodesBucket, Count: 6},
+	)
+
+	require.Equal(t,
+		testRule(0, netmap.Select{Key: netmap.NodesBucket, Count: 6}),
+		TrimRule(rule),
+	)
+
+	// source rule is not changed
+	require.Len(t, rule.SFGroups[0].Selectors, 2)
+}
+
+func TestSource(t *testing.T) {
+	_, err := NewSource(nil)
+	require.Error(t, err)
+
+	s := test.New()
+
+	src, err := NewSource(s)
+	require.NoError(t, err)
+
+	var (
+		replicated = new(container.Container)
+		coded      = new(container.Container)
+	)
+
+	replicated.SetSalt([]byte{1})
+	replicated.SetPlacementRule(testRule(2, netmap.Select{Key: netmap.NodesBucket, Count: 3}))
+
+	coded.SetSalt([]byte{2})
+	coded.SetPlacementRule(testRule(0,
+		netmap.Select{Key: SelectorKey, Count: 2},
+		netmap.Select{Key: netmap.NodesBucket, Count: 6},
+	))
+
+	replicatedID, err := s.Put(replicated)
+	require.NoError(t, err)
+
+	codedID, err := s.Put(coded)
+	require.NoError(t, err)
+
+	_, ok, err := src.Scheme(*replicatedID)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	scheme, ok, err := src.Scheme(*codedID)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, Scheme{Data: 4, Parity: 2}, scheme)
+
+	_, _, err = src.Scheme(container.ID{})
+	require.Error(t, err)
+}
@@ -0,0 +1,57 @@
+package expiration
+
+import (
+	"strconv"
+
+	"github.com/nspcc-dev/neofs-api-go/object"
+	"github.com/pkg/errors"
+)
+
+// Header is a key of the user header with the last epoch of the object
+// lifetime. Object is removed from the storage after the epoch.
+const Header = "__NEOFS__EXPIRATION_EPOCH"
+
+// ErrInvalidEpoch is returned by Epoch if the expiration
+// header value is not a decimal epoch number.
+var ErrInvalidEpoch = errors.New("invalid expiration epoch")
+
+// Epoch returns the expiration epoch of the object.
+//
+// False is returned if the object does not have the expiration header.
+// If there are several headers, the last one is used.
+func Epoch(obj *object.Object) (uint64, bool, error) {
+	var (
+		val string
+		ok  bool
+	)
+
+	for i := range obj.Headers {
+		h, isUser := obj.Headers[i].Value.(*object.Header_UserHeader)
+		if !isUser || h.UserHeader == nil || h.UserHeader.Key != Header {
+			continue
+		}
+
+		val, ok = h.UserHeader.Value, true
+	}
+
+	if !ok {
+		return 0, false, nil
+	}
+
+	e, err := strconv.ParseUint(val, 10, 64)
+	if err != nil {
+		return 0, true, errors.Wrapf(ErrInvalidEpoch, "given '%s'", val)
+	}
+
+	return e, true, nil
+}
+
+// Expired checks if the object lifetime ended before the epoch.
+//
+// Objects with the invalid expiration header are not expired,
+// such objects are rejected on Put.
+func Expired(obj *object.Object, epoch uint64) bool {
+	e, ok, err := Epoch(obj)
+
+	return ok && err == nil && e < epoch
+}
@@ -0,0 +1,107 @@
+package expiration
+
+import (
+	"context"
+
+	"github.com/nspcc-dev/neofs-api-go/refs"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/localstore"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/epochgc"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/retention"
+	"go.uber.org/zap"
+)
+
+type (
+	// Params groups the parameters of the GC constructor.
+	Params struct {
+		epochgc.Params
+	}
+
+	// GC removes the expired objects from the local storage.
+	// Objects under the retention lock or the legal hold are kept.
+	//
+	// Collection is triggered by the new epoch and performed
+	// in background by Run, so HandleEpoch does not block
+	// the event listener.
+	GC struct {
+		*epochgc.Runner
+
+		ls      localstore.Localstore
+		log     *zap.Logger
+		holder  retention.Holder
+		locator retention.Locator
+	}
+)
+
+// New is an expired objects GC constructor.
+func New(p Params) (*GC, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	g := &GC{
+		ls:      p.Localstore,
+		log:     p.Logger,
+		holder:  p.Holder,
+		locator: p.Locator,
+	}
+
+	var err error
+	if g.Runner, err = epochgc.NewRunner(g.collect); err != nil {
+		return nil, err
+	}
+
+	return g, nil
+}
+
+func (g *GC) collect(ctx context.Context, epoch uint64) {
+	var (
+		addrs []refs.Address
+		guard = retention.NewGuard(g.holder, g.locator)
+	)
+
+	if err := g.ls.Iterate(nil, func(meta *localstore.ObjectMeta) bool {
+		guard.Add(meta.Object)
+
+		if Expired(meta.Object, epoch) {
+			addrs = append(addrs, *meta.Object.Address())
+		}
+
+		return false
+	}); err != nil {
+		g.log.Error("could not list expired objects",
+			zap.Uint64("epoch", epoch),
+			zap.Error(err))
+
+		return
+	}
+
+	var removed, retained int
+
+	for i := range addrs {
+		if ctx.Err() != nil {
+			break
+		}
+
+		if guard.CheckRetained(ctx, addrs[i], epoch, g.log) {
+			retained++
+			continue
+		}
+
+		if err := g.ls.Del(addrs[i]); err != nil {
+			g.log.Warn("could not remove expired object",
+				zap.Stringer("oid", addrs[i].ObjectID),
+				zap.Stringer("cid", addrs[i].CID),
+				zap.Error(err))
+
+			continue
+		}
+
+		removed++
+	}
+
+	g.log.Info("expired objects removed",
+		zap.Uint64("epoch", epoch),
+		zap.Int("removed", removed),
+		zap.Int("retained", retained),
+		zap.Int("expired", len(addrs)))
+}
@@ -0,0 +1,147 @@
+package expiration
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/nspcc-dev/neofs-api-go/object"
+	"github.com/nspcc-dev/neofs-api-go/refs"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/localstore"
+	lstest "github.com/nspcc-dev/neofs-node/pkg/local_object_storage/localstore/test"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/epochgc"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/retention"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// testLocator returns the lock objects stored on the other nodes.
+type testLocator []*object.Object
+
+func (s testLocator) Locks(_ context.Context, target refs.Address) ([]*object.Object, error) {
+	var res []*object.Object
+
+	for _, obj := range s {
+		if lock, ok, err := retention.ParseLock(obj); err == nil && ok && lock.Locks(target.ObjectID) {
+			res = append(res, obj)
+		}
+	}
+
+	return res, nil
+}
+
+func testObject(t *testing.T, expiration string) *object.Object {
+	id, err := refs.NewObjectID()
+	require.NoError(t, err)
+
+	obj := &object.Object{
+		SystemHeader: object.SystemHeader{
+			ID:  id,
+			CID: refs.CIDForBytes([]byte("container")),
+		},
+	}
+
+	if expiration != "" {
+		obj.Headers = append(obj.Headers, object.Header{
+			Value: &object.Header_UserHeader{
+				UserHeader: &object.UserHeader{
+					Key:   Header,
+					Value: expiration,
+				},
+			},
+		})
+	}
+
+	return obj
+}
+
+func TestEpoch(t *testing.T) {
+	_, ok, err := Epoch(testObject(t, ""))
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	e, ok, err := Epoch(testObject(t, "10"))
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, uint64(10), e)
+
+	_, ok, err = Epoch(testObject(t, "-1"))
+	require.True(t, ok)
+	require.True(t, errors.Is(errors.Cause(err), ErrInvalidEpoch))
+
+	require.False(t, Expired(testObject(t, "10"), 10))
+	require.True(t, Expired(testObject(t, "10"), 11))
+	require.False(t, Expired(testObject(t, "ten"), 11))
+}
+
+func TestGC(t *testing.T) {
+	ls := lstest.Localstore(t, localstore.Params{})
+
+	objs := []*object.Object{
+		testObject(t, ""),
+		testObject(t, "1"),
+		testObject(t, "2"),
+		testObject(t, "3"),
+	}
+
+	// expired object under the retention lock
+	locked := testObject(t, "1")
+	locked.Headers = append(locked.Headers, object.Header{
+		Value: &object.Header_UserHeader{
+			UserHeader: &object.UserHeader{
+				Key:   retention.Header,
+				Value: "3",
+			},
+		},
+	})
+
+	// expired object locked by the lock object of the other node
+	remoteLocked := testObject(t, "1")
+	lockObj := testObject(t, "")
+	lockObj.Headers = append(lockObj.Headers, object.Header{
+		Value: &object.Header_UserHeader{
+			UserHeader: &object.UserHeader{
+				Key:   retention.Header,
+				Value: "3",
+			},
+		},
+	}, object.Header{
+		Value: &object.Header_UserHeader{
+			UserHeader: &object.UserHeader{
+				Key:   retention.TargetHeader,
+				Value: remoteLocked.SystemHeader.ID.String(),
+			},
+		},
+	})
+
+	for i := range objs {
+		require.NoError(t, ls.Put(context.Background(), objs[i]))
+	}
+
+	require.NoError(t, ls.Put(context.Background(), locked))
+	require.NoError(t, ls.Put(context.Background(), remoteLocked))
+
+	gc, err := New(Params{
+		Params: epochgc.Params{
+			Localstore: ls,
+			Logger:     zap.L(),
+			Locator:    testLocator{lockObj},
+		},
+	})
+	require.NoError(t, err)
+
+	gc.collect(context.Background(), 3)
+
+	for i := range objs {
+		ok, err := ls.Has(*objs[i].Address())
+		require.NoError(t, err)
+		require.Equal(t, i == 0 || i == 3, ok, strconv.Itoa(i))
+	}
+
+	for _, obj := range []*object.Object{locked, remoteLocked} {
+		ok, err := ls.Has(*obj.Address())
+		require.NoError(t, err)
+		require.True(t, ok)
+	}
+}
@@ -0,0 +1,90 @@
+package multipart
+
+import (
+	"context"
+
+	"github.com/multiformats/go-multiaddr"
+	"github.com/nspcc-dev/neofs-api-go/refs"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/transport"
+	"github.com/pkg/errors"
+)
+
+type (
+	// Checker is an interface of entity that checks
+	// if the multipart upload is completed.
+	Checker interface {
+		Completed(context.Context, refs.Address) (bool, error)
+	}
+
+	// NodeLister is an interface of entity
+	// that lists the nodes of the container.
+	NodeLister interface {
+		ContainerNodes(context.Context, refs.CID) ([]multiaddr.Multiaddr, error)
+	}
+
+	// CheckerParams groups the parameters of Checker constructor.
+	CheckerParams struct {
+		SelectiveContainerExecutor transport.SelectiveContainerExecutor
+		NodeLister                 NodeLister
+	}
+
+	remoteChecker struct {
+		executor transport.SelectiveContainerExecutor
+		nodes    NodeLister
+	}
+)
+
+var (
+	errNilExecutor   = errors.New("selective container executor is nil")
+	errNilNodeLister = errors.New("container node lister is nil")
+	errNoResponse    = errors.New("not all container nodes responded")
+)
+
+// NewChecker constructs Checker that searches
+// for the parent object on the container nodes.
+//
+// Upload is completed if any of the nodes stores the parent object.
+// Upload is not completed if all the nodes responded without it,
+// otherwise the check fails.
+func NewChecker(p CheckerParams) (Checker, error) {
+	switch {
+	case p.SelectiveContainerExecutor == nil:
+		return nil, errNilExecutor
+	case p.NodeLister == nil:
+		return nil, errNilNodeLister
+	}
+
+	return &remoteChecker{
+		executor: p.SelectiveContainerExecutor,
+		nodes:    p.NodeLister,
+	}, nil
+}
+
+func (s *remoteChecker) Completed(ctx context.Context, addr refs.Address) (bool, error) {
+	nodes, err := s.nodes.ContainerNodes(ctx, addr.CID)
+	if err != nil {
+		return false, errors.Wrap(err, "could not list container nodes")
+	}
+
+	found, err := transport.SearchObject(ctx, s.executor, addr, nodes)
+	if err != nil {
+		return false, err
+	}
+
+	responded := true
+
+	for i := range nodes {
+		has, ok := found[nodes[i].String()]
+		if has {
+			return true, nil
+		}
+
+		responded = responded && ok
+	}
+
+	if !responded {
+		return false, errNoResponse
+	}
+
+	return false, nil
+}
@@ -0,0 +1,210 @@
+package multipart
+
+import (
+	"context"
+
+	"github.com/nspcc-dev/neofs-api-go/object"
+	"github.com/nspcc-dev/neofs-api-go/refs"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/localstore"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/epochgc"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/retention"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+type (
+	// Params groups the parameters of the GC constructor.
+	Params struct {
+		epochgc.Params
+
+		Checker Checker
+
+		// UploadTTL is a number of epochs after the last stored object
+		// of the upload during which the upload is not collected.
+		UploadTTL uint64
+	}
+
+	// GC removes the abandoned multipart uploads from the local storage.
+	//
+	// Upload is collected when its record and parts are not updated
+	// during the upload TTL. If the parent object of the upload exists,
+	// the upload is completed and only the record is removed, otherwise
+	// the record is removed along with the parts. Completion of the
+	// upload is checked by Checker even if the record is not stored
+	// locally, so the local parts of the abandoned upload are removed
+	// too. Objects under the retention lock or the legal hold are kept.
+	GC struct {
+		*epochgc.Runner
+
+		ls      localstore.Localstore
+		checker Checker
+		log     *zap.Logger
+		ttl     uint64
+		holder  retention.Holder
+		locator retention.Locator
+	}
+
+	upload struct {
+		record *refs.Address
+		parts  []refs.Address
+
+		// the latest store epoch of the upload objects
+		epoch uint64
+	}
+)
+
+var errNilChecker = errors.New("upload completion checker is nil")
+
+// New is a multipart uploads GC constructor.
+func New(p Params) (*GC, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	} else if p.Checker == nil {
+		return nil, errNilChecker
+	}
+
+	g := &GC{
+		ls:      p.Localstore,
+		checker: p.Checker,
+		log:     p.Logger,
+		ttl:     p.UploadTTL,
+		holder:  p.Holder,
+		locator: p.Locator,
+	}
+
+	var err error
+	if g.Runner, err = epochgc.NewRunner(g.collect); err != nil {
+		return nil, err
+	}
+
+	return g, nil
+}
+
+func (g *GC) collect(ctx context.Context, epoch uint64) {
+	var (
+		// uploads by the parent address
+		uploads = make(map[refs.Address]*upload)
+
+		guard = retention.NewGuard(g.holder, g.locator)
+	)
+
+	if err := g.ls.Iterate(nil, func(meta *localstore.ObjectMeta) bool {
+		obj := meta.Object
+
+		guard.Add(obj)
+
+		id, ok := UploadID(obj)
+		if !ok {
+			return false
+		}
+
+		key := refs.Address{ObjectID: id, CID: obj.SystemHeader.CID}
+
+		u := uploads[key]
+		if u == nil {
+			u = new(upload)
+			uploads[key] = u
+		}
+
+		if addr := obj.Address(); isPart(obj) {
+			u.parts = append(u.parts, *addr)
+		} else {
+			u.record = addr
+		}
+
+		if meta.StoreEpoch > u.epoch {
+			u.epoch = meta.StoreEpoch
+		}
+
+		return false
+	}); err != nil {
+		g.log.Error("could not list multipart uploads",
+			zap.Uint64("epoch", epoch),
+			zap.Error(err))
+
+		return
+	}
+
+	var completed, abandoned int
+
+	for addr, u := range uploads {
+		if ctx.Err() != nil {
+			break
+		}
+
+		if u.epoch > epoch || epoch-u.epoch < g.ttl {
+			continue
+		}
+
+		done, err := g.checker.Completed(ctx, addr)
+		if err != nil {
+			g.log.Warn("could not check multipart upload completion",
+				zap.Stringer("upload", addr.ObjectID),
+				zap.Stringer("cid", addr.CID),
+				zap.Error(err))
+
+			continue
+		}
+
+		if !done {
+			for i := range u.parts {
+				if guard.CheckRetained(ctx, u.parts[i], epoch, g.log) {
+					continue
+				}
+
+				if err := g.ls.Del(u.parts[i]); err != nil {
+					g.log.Warn("could not remove part of abandoned upload",
+						zap.Stringer("oid", u.parts[i].ObjectID),
+						zap.Stringer("cid", u.parts[i].CID),
+						zap.Error(err))
+				}
+			}
+		}
+
+		// record can be stored on the other container node, parts
+		// of the completed upload are the children of the parent object
+		if u.record == nil {
+			if !done {
+				abandoned++
+			}
+
+			continue
+		}
+
+		if guard.CheckRetained(ctx, *u.record, epoch, g.log) {
+			continue
+		}
+
+		if err := g.ls.Del(*u.record); err != nil {
+			g.log.Warn("could not remove upload record",
+				zap.Stringer("oid", u.record.ObjectID),
+				zap.Stringer("cid", u.record.CID),
+				zap.Error(err))
+
+			continue
+		}
+
+		if done {
+			completed++
+		} else {
+			abandoned++
+		}
+	}
+
+	g.log.Info("multipart uploads collected",
+		zap.Uint64("epoch", epoch),
+		zap.Int("uploads", len(uploads)),
+		zap.Int("completed", completed),
+		zap.Int("abandoned", abandoned))
+}
+
+func isPart(obj *object.Object) bool {
+	for i := range obj.Headers {
+		h, ok := obj.Headers[i].Value.(*object.Header_UserHeader)
+		if ok && h.UserHeader != nil && h.UserHeader.Key == KeyPart {
+			return true
+		}
+	}
+
+	return false
+}
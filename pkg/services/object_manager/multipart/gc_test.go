@@ -0,0 +1,102 @@
+package multipart
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/nspcc-dev/neofs-api-go/object"
+	"github.com/nspcc-dev/neofs-api-go/refs"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/localstore"
+	lstest "github.com/nspcc-dev/neofs-node/pkg/local_object_storage/localstore/test"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/epochgc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type testChecker map[refs.Address]bool
+
+func (s testChecker) Completed(_ context.Context, addr refs.Address) (bool, error) {
+	return s[addr], nil
+}
+
+var testCID = refs.CIDForBytes([]byte("container"))
+
+func testRecord(u Upload) *object.Object {
+	return &object.Object{
+		SystemHeader: object.SystemHeader{ID: u.RecordID(), CID: testCID},
+		Headers:      u.Record(),
+	}
+}
+
+func testStoredPart(u Upload, n uint64) *object.Object {
+	obj := testPart(u, n, []byte{byte(n)})
+	obj.SystemHeader.CID = testCID
+
+	return obj
+}
+
+func TestGC(t *testing.T) {
+	ls := lstest.Localstore(t, localstore.Params{})
+
+	var (
+		completed = testUpload(t) // TTL is over, parent exists
+		abandoned = testUpload(t) // TTL is over, parent does not exist
+		active    = testUpload(t) // TTL is not over since the last part
+		orphan    = testUpload(t) // parts without the record, parent exists
+		lost      = testUpload(t) // parts without the record, parent does not exist
+
+		completedRec  = testRecord(completed)
+		completedPart = testStoredPart(completed, 1)
+		abandonedRec  = testRecord(abandoned)
+		abandonedPart = testStoredPart(abandoned, 1)
+		activeRec     = testRecord(active)
+		activePart    = testStoredPart(active, 1)
+		orphanPart    = testStoredPart(orphan, 1)
+		lostPart      = testStoredPart(lost, 1)
+	)
+
+	put := func(obj *object.Object, epoch uint64) {
+		ctx := context.WithValue(context.Background(), localstore.StoreEpochValue, epoch)
+		require.NoError(t, ls.Put(ctx, obj))
+	}
+
+	for _, obj := range []*object.Object{completedRec, completedPart, abandonedRec, abandonedPart, activeRec, orphanPart, lostPart} {
+		put(obj, 1)
+	}
+
+	put(activePart, 3)
+
+	gc, err := New(Params{
+		Params: epochgc.Params{
+			Localstore: ls,
+			Logger:     zap.L(),
+		},
+		Checker: testChecker{
+			{ObjectID: completed.ID, CID: testCID}: true,
+			{ObjectID: orphan.ID, CID: testCID}:    true,
+		},
+		UploadTTL: 2,
+	})
+	require.NoError(t, err)
+
+	gc.collect(context.Background(), 3)
+
+	for i, item := range []struct {
+		obj  *object.Object
+		kept bool
+	}{
+		{obj: completedRec, kept: false},
+		{obj: completedPart, kept: true},
+		{obj: abandonedRec, kept: false},
+		{obj: abandonedPart, kept: false},
+		{obj: activeRec, kept: true},
+		{obj: activePart, kept: true},
+		{obj: orphanPart, kept: true},
+		{obj: lostPart, kept: false},
+	} {
+		ok, err := ls.Has(*item.obj.Address())
+		require.NoError(t, err)
+		require.Equal(t, item.kept, ok, strconv.Itoa(i))
+	}
+}
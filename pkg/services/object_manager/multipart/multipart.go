@@ -0,0 +1,345 @@
+package multipart
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/nspcc-dev/neofs-api-go/hash"
+	"github.com/nspcc-dev/neofs-api-go/object"
+	"github.com/nspcc-dev/neofs-api-go/refs"
+	"github.com/nspcc-dev/neofs-api-go/service"
+	"github.com/pkg/errors"
+)
+
+type (
+	// Action is an enumeration of multipart upload request actions.
+	Action int
+
+	// Upload identifies the multipart upload.
+	//
+	// Upload is bound to the session token: the objects of the upload
+	// have the identifiers derived from the upload ID and the token ID,
+	// so the parts uploaded with the other token are not linked.
+	Upload struct {
+		ID    refs.ObjectID
+		Token service.TokenID
+	}
+
+	// Request groups the multipart upload parameters of the Put request.
+	Request struct {
+		Action Action
+
+		// upload ID, empty for ActionInitiate
+		ID refs.ObjectID
+
+		// number of the part for ActionPart,
+		// number of the parts for ActionComplete
+		Part uint64
+	}
+
+	// SignFunc is a function that adds the integrity header to the object.
+	SignFunc func(*object.Object) error
+)
+
+const (
+	// ActionNone means that the request is not a multipart upload request.
+	ActionNone Action = iota
+
+	// ActionInitiate starts the new multipart upload.
+	ActionInitiate
+
+	// ActionPart uploads the numbered part.
+	ActionPart
+
+	// ActionComplete writes the parent object of the uploaded parts.
+	ActionComplete
+)
+
+const (
+	// InitiateHeader is a Put request extended header key that starts the
+	// multipart upload. Upload ID is returned as the object ID of the
+	// response address.
+	InitiateHeader = "MULTIPART_INITIATE"
+
+	// UploadHeader is a Put request extended header key of the upload ID.
+	UploadHeader = "MULTIPART_UPLOAD"
+
+	// PartHeader is a Put request extended header key of the part number.
+	// Parts are numbered from 1.
+	PartHeader = "MULTIPART_PART"
+
+	// CompleteHeader is a Put request extended header key of the number
+	// of the parts to complete the upload with.
+	CompleteHeader = "MULTIPART_COMPLETE"
+)
+
+const (
+	// KeyUpload is a user header key of the upload ID
+	// in the upload record and the parts.
+	KeyUpload = "__NEOFS__MULTIPART_UPLOAD"
+
+	// KeyPart is a user header key of the part number.
+	KeyPart = "__NEOFS__MULTIPART_PART"
+)
+
+// MaxParts is a maximum number of the parts in the upload.
+const MaxParts = 10000
+
+var (
+	// ErrInvalidRequest is returned by ParseRequest
+	// if the multipart headers are malformed.
+	ErrInvalidRequest = errors.New("invalid multipart upload request")
+
+	// ErrMissingPart is returned by Complete if the parts
+	// do not form the sequence from the first one.
+	ErrMissingPart = errors.New("missing part of the multipart upload")
+
+	// ErrMissingChecksum is returned by Complete if the object
+	// template does not contain the payload checksum.
+	ErrMissingChecksum = errors.New("missing payload checksum of the multipart upload")
+)
+
+// ParseRequest returns the multipart upload parameters
+// from the Put request extended headers.
+func ParseRequest(hs []service.ExtendedHeader) (res Request, err error) {
+	var initiate, upload, part, complete bool
+
+	for i := range hs {
+		if hs[i] == nil {
+			continue
+		}
+
+		switch v := hs[i].Value(); hs[i].Key() {
+		case InitiateHeader:
+			initiate = true
+		case UploadHeader:
+			if res.ID, err = parseID(v); err != nil {
+				return res, errors.Wrapf(ErrInvalidRequest, "invalid upload ID %s", v)
+			}
+
+			upload = true
+		case PartHeader, CompleteHeader:
+			if part || complete {
+				return res, errors.Wrap(ErrInvalidRequest, "both part and complete headers")
+			} else if res.Part, err = strconv.ParseUint(v, 10, 64); err != nil || res.Part == 0 || res.Part > MaxParts {
+				return res, errors.Wrapf(ErrInvalidRequest, "invalid part number %s", v)
+			}
+
+			part, complete = hs[i].Key() == PartHeader, hs[i].Key() == CompleteHeader
+		}
+	}
+
+	switch {
+	case initiate && (upload || part || complete):
+		return res, errors.Wrap(ErrInvalidRequest, "initiate header with upload headers")
+	case initiate:
+		res.Action = ActionInitiate
+	case upload != (part || complete):
+		return res, errors.Wrap(ErrInvalidRequest, "incomplete upload headers")
+	case part:
+		res.Action = ActionPart
+	case complete:
+		res.Action = ActionComplete
+	}
+
+	return res, nil
+}
+
+// RecordID returns the ID of the upload record object.
+func (u Upload) RecordID() refs.ObjectID {
+	return u.child(0)
+}
+
+// PartID returns the ID of the numbered part object.
+func (u Upload) PartID(n uint64) refs.ObjectID {
+	return u.child(n)
+}
+
+func (u Upload) child(n uint64) refs.ObjectID {
+	data := make([]byte, len(u.Token)+8)
+	copy(data, u.Token[:])
+	binary.BigEndian.PutUint64(data[len(u.Token):], n)
+
+	return refs.ObjectID(uuid.NewSHA1(uuid.UUID(u.ID), data))
+}
+
+// Record returns the headers of the upload record object.
+//
+// Record is an empty object that marks the upload as initiated,
+// it is removed by GC after the upload is completed or abandoned.
+func (u Upload) Record() []object.Header {
+	return []object.Header{
+		userHeader(KeyUpload, u.ID.String()),
+	}
+}
+
+// Part returns the headers that link the numbered part to the upload.
+//
+// Parts are linked as the children of the split object, the next link
+// of the last part points to the closing child written by Complete.
+func (u Upload) Part(n uint64) []object.Header {
+	var prev refs.ObjectID
+	if n > 1 {
+		prev = u.PartID(n - 1)
+	}
+
+	return []object.Header{
+		userHeader(KeyUpload, u.ID.String()),
+		userHeader(KeyPart, strconv.FormatUint(n, 10)),
+		{Value: &object.Header_Transform{Transform: &object.Transform{Type: object.Transform_Split}}},
+		linkHeader(object.Link_Parent, u.ID),
+		linkHeader(object.Link_Previous, prev),
+		linkHeader(object.Link_Next, u.PartID(n+1)),
+	}
+}
+
+// Complete returns the closing child and the parent object of the upload.
+//
+// Template is the parent object header with the upload ID and the payload
+// checksum of the whole payload. Parts are the headers of the uploaded
+// parts in order. Closing child has an empty payload and carries the
+// parent headers as the last child of the split object does, parent
+// object links all the children.
+func (u Upload) Complete(template *object.Object, parts []*object.Object, sign SignFunc) (*object.Object, *object.Object, error) {
+	var (
+		checksum []byte
+		src      = make([]object.Header, 0, len(template.Headers))
+	)
+
+	for i := range template.Headers {
+		switch h := template.Headers[i].Value.(type) {
+		case *object.Header_PayloadChecksum:
+			checksum = h.PayloadChecksum
+		case *object.Header_HomoHash, *object.Header_Integrity:
+		default:
+			src = append(src, template.Headers[i])
+		}
+	}
+
+	if len(checksum) != sha256.Size {
+		return nil, nil, ErrMissingChecksum
+	} else if len(parts) == 0 {
+		return nil, nil, ErrMissingPart
+	}
+
+	var (
+		n        = uint64(len(parts))
+		length   uint64
+		hashes   = make([]hash.Hash, 0, n)
+		children = make([]object.Header, 0, n+1)
+	)
+
+	for i := range parts {
+		if parts[i] == nil || parts[i].SystemHeader.ID != u.PartID(uint64(i+1)) {
+			return nil, nil, errors.Wrapf(ErrMissingPart, "part %d", i+1)
+		}
+
+		_, h := parts[i].LastHeader(object.HeaderType(object.HomoHashHdr))
+		if h == nil {
+			return nil, nil, errors.Errorf("part %d without homomorphic hash", i+1)
+		}
+
+		length += parts[i].SystemHeader.PayloadLength
+		hashes = append(hashes, h.Value.(*object.Header_HomoHash).HomoHash)
+		children = append(children, linkHeader(object.Link_Child, parts[i].SystemHeader.ID))
+	}
+
+	homo, err := hash.Concat(hashes)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not concatenate homomorphic hashes")
+	}
+
+	sh := template.SystemHeader
+	sh.ID = u.ID
+	sh.PayloadLength = length
+
+	// headers of the parent object are signed within the closing child
+	parentHdrs := &object.Object{
+		SystemHeader: sh,
+		Headers: append(append(make([]object.Header, 0, len(src)+3), src...),
+			object.Header{Value: &object.Header_HomoHash{HomoHash: homo}},
+			object.Header{Value: &object.Header_PayloadChecksum{PayloadChecksum: checksum}},
+		),
+	}
+
+	if err := sign(parentHdrs); err != nil {
+		return nil, nil, errors.Wrap(err, "could not sign parent headers")
+	}
+
+	emptySum := sha256.Sum256(nil)
+
+	last := &object.Object{
+		SystemHeader: object.SystemHeader{
+			Version:   sh.Version,
+			ID:        u.PartID(n + 1),
+			CID:       sh.CID,
+			OwnerID:   sh.OwnerID,
+			CreatedAt: sh.CreatedAt,
+		},
+		Headers: append(parentHdrs.Headers,
+			object.Header{Value: &object.Header_Transform{Transform: &object.Transform{Type: object.Transform_Split}}},
+			linkHeader(object.Link_Parent, u.ID),
+			linkHeader(object.Link_Previous, u.PartID(n)),
+			linkHeader(object.Link_Next, refs.ObjectID{}),
+			object.Header{Value: &object.Header_HomoHash{HomoHash: hash.Sum(nil)}},
+			object.Header{Value: &object.Header_PayloadChecksum{PayloadChecksum: emptySum[:]}},
+		),
+	}
+
+	if err := sign(last); err != nil {
+		return nil, nil, errors.Wrap(err, "could not sign closing child")
+	}
+
+	parent := &object.Object{
+		SystemHeader: sh,
+		Headers: append(append(src[:len(src):len(src)], children...),
+			linkHeader(object.Link_Child, last.SystemHeader.ID),
+		),
+	}
+
+	parent.SystemHeader.PayloadLength = 0
+
+	if err := sign(parent); err != nil {
+		return nil, nil, errors.Wrap(err, "could not sign parent object")
+	}
+
+	return last, parent, nil
+}
+
+// UploadID returns the upload ID from the upload record or part headers.
+func UploadID(obj *object.Object) (refs.ObjectID, bool) {
+	for i := range obj.Headers {
+		h, ok := obj.Headers[i].Value.(*object.Header_UserHeader)
+		if !ok || h.UserHeader == nil || h.UserHeader.Key != KeyUpload {
+			continue
+		}
+
+		id, err := parseID(h.UserHeader.Value)
+
+		return id, err == nil
+	}
+
+	return refs.ObjectID{}, false
+}
+
+func parseID(s string) (refs.ObjectID, error) {
+	id, err := uuid.Parse(s)
+
+	return refs.ObjectID(id), err
+}
+
+func userHeader(key, value string) object.Header {
+	return object.Header{Value: &object.Header_UserHeader{UserHeader: &object.UserHeader{
+		Key:   key,
+		Value: value,
+	}}}
+}
+
+func linkHeader(t object.Link_Type, id refs.ObjectID) object.Header {
+	return object.Header{Value: &object.Header_Link{Link: &object.Link{
+		Type: t,
+		ID:   id,
+	}}}
+}
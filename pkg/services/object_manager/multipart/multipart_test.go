@@ -0,0 +1,174 @@
+package multipart
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/nspcc-dev/neofs-api-go/hash"
+	"github.com/nspcc-dev/neofs-api-go/object"
+	"github.com/nspcc-dev/neofs-api-go/refs"
+	"github.com/nspcc-dev/neofs-api-go/service"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func testUpload(t *testing.T) Upload {
+	id, err := refs.NewObjectID()
+	require.NoError(t, err)
+
+	return Upload{ID: id, Token: service.TokenID{1, 2, 3}}
+}
+
+func testHeaders(kv ...string) []service.ExtendedHeader {
+	hs := make([]service.RequestExtendedHeader_KV, 0, len(kv)/2)
+
+	for i := 0; i < len(kv); i += 2 {
+		h := service.RequestExtendedHeader_KV{}
+		h.SetK(kv[i])
+		h.SetV(kv[i+1])
+
+		hs = append(hs, h)
+	}
+
+	req := new(object.PutRequest)
+	req.SetHeaders(hs)
+
+	return req.ExtendedHeaders()
+}
+
+// testPart returns the part stored by the transformer.
+func testPart(u Upload, n uint64, payload []byte) *object.Object {
+	obj := &object.Object{
+		SystemHeader: object.SystemHeader{
+			ID:            u.PartID(n),
+			PayloadLength: uint64(len(payload)),
+		},
+		Headers: u.Part(n),
+	}
+
+	obj.AddHeader(&object.Header{Value: &object.Header_HomoHash{HomoHash: hash.Sum(payload)}})
+
+	return obj
+}
+
+func testSign(obj *object.Object) error {
+	obj.AddHeader(&object.Header{Value: &object.Header_Integrity{Integrity: new(object.IntegrityHeader)}})
+	return nil
+}
+
+func TestParseRequest(t *testing.T) {
+	id := refs.ObjectID{1, 2, 3}
+
+	res, err := ParseRequest(testHeaders("KEY", "VALUE"))
+	require.NoError(t, err)
+	require.Equal(t, Request{}, res)
+
+	res, err = ParseRequest(testHeaders(InitiateHeader, ""))
+	require.NoError(t, err)
+	require.Equal(t, Request{Action: ActionInitiate}, res)
+
+	res, err = ParseRequest(testHeaders(UploadHeader, id.String(), PartHeader, "3"))
+	require.NoError(t, err)
+	require.Equal(t, Request{Action: ActionPart, ID: id, Part: 3}, res)
+
+	res, err = ParseRequest(testHeaders(CompleteHeader, "2", UploadHeader, id.String()))
+	require.NoError(t, err)
+	require.Equal(t, Request{Action: ActionComplete, ID: id, Part: 2}, res)
+
+	for _, hs := range [][]service.ExtendedHeader{
+		testHeaders(InitiateHeader, "", UploadHeader, id.String()),
+		testHeaders(UploadHeader, id.String()),
+		testHeaders(PartHeader, "1"),
+		testHeaders(UploadHeader, "upload", PartHeader, "1"),
+		testHeaders(UploadHeader, id.String(), PartHeader, "0"),
+		testHeaders(UploadHeader, id.String(), PartHeader, "10001"),
+		testHeaders(UploadHeader, id.String(), PartHeader, "first"),
+		testHeaders(UploadHeader, id.String(), PartHeader, "1", CompleteHeader, "1"),
+	} {
+		_, err := ParseRequest(hs)
+		require.True(t, errors.Is(errors.Cause(err), ErrInvalidRequest))
+	}
+}
+
+func TestUpload_IDs(t *testing.T) {
+	u := testUpload(t)
+
+	require.Equal(t, u.PartID(1), u.PartID(1))
+	require.NotEqual(t, u.PartID(1), u.PartID(2))
+	require.NotEqual(t, u.RecordID(), u.PartID(1))
+
+	other := u
+	other.Token = service.TokenID{4, 5, 6}
+
+	require.NotEqual(t, u.PartID(1), other.PartID(1))
+
+	id, ok := UploadID(&object.Object{Headers: u.Part(1)})
+	require.True(t, ok)
+	require.Equal(t, u.ID, id)
+
+	_, ok = UploadID(new(object.Object))
+	require.False(t, ok)
+}
+
+func TestUpload_Complete(t *testing.T) {
+	var (
+		u        = testUpload(t)
+		payloads = [][]byte{{1, 2, 3}, {4, 5}}
+		parts    = []*object.Object{testPart(u, 1, payloads[0]), testPart(u, 2, payloads[1])}
+		sum      = sha256.Sum256([]byte{1, 2, 3, 4, 5})
+		userHdr  = userHeader("Name", "value")
+	)
+
+	template := &object.Object{
+		SystemHeader: object.SystemHeader{ID: u.ID, CID: refs.CID{1}},
+		Headers: []object.Header{
+			userHdr,
+			{Value: &object.Header_PayloadChecksum{PayloadChecksum: sum[:]}},
+		},
+	}
+
+	t.Run("missing checksum", func(t *testing.T) {
+		_, _, err := u.Complete(&object.Object{Headers: []object.Header{userHdr}}, parts, testSign)
+		require.EqualError(t, err, ErrMissingChecksum.Error())
+	})
+
+	t.Run("missing part", func(t *testing.T) {
+		for _, list := range [][]*object.Object{
+			nil,
+			parts[1:],
+			{parts[0], nil},
+		} {
+			_, _, err := u.Complete(template, list, testSign)
+			require.True(t, errors.Is(errors.Cause(err), ErrMissingPart))
+		}
+	})
+
+	last, parent, err := u.Complete(template, parts, testSign)
+	require.NoError(t, err)
+
+	homo, err := hash.Concat([]hash.Hash{hash.Sum(payloads[0]), hash.Sum(payloads[1])})
+	require.NoError(t, err)
+
+	require.Equal(t, u.PartID(3), last.SystemHeader.ID)
+	require.Zero(t, last.SystemHeader.PayloadLength)
+	require.Equal(t, []refs.ObjectID{u.ID}, last.Links(object.Link_Parent))
+	require.Equal(t, []refs.ObjectID{u.PartID(2)}, last.Links(object.Link_Previous))
+	require.Equal(t, []refs.ObjectID{{}}, last.Links(object.Link_Next))
+
+	// parent headers precede the split headers in the closing child
+	require.Equal(t, []object.Header{
+		userHdr,
+		{Value: &object.Header_HomoHash{HomoHash: homo}},
+		{Value: &object.Header_PayloadChecksum{PayloadChecksum: sum[:]}},
+		{Value: &object.Header_Integrity{Integrity: new(object.IntegrityHeader)}},
+		{Value: &object.Header_Transform{Transform: &object.Transform{Type: object.Transform_Split}}},
+	}, last.Headers[:5])
+
+	require.Equal(t, u.ID, parent.SystemHeader.ID)
+	require.Zero(t, parent.SystemHeader.PayloadLength)
+	require.Equal(t, []refs.ObjectID{u.PartID(1), u.PartID(2), u.PartID(3)}, parent.Links(object.Link_Child))
+	require.Equal(t, userHdr, parent.Headers[0])
+
+	// template is not modified
+	require.Len(t, template.Headers, 2)
+}
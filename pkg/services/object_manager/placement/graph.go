@@ -4,6 +4,7 @@ import (
 	"github.com/gogo/protobuf/proto"
 	"github.com/multiformats/go-multiaddr"
 	netmapcore "github.com/nspcc-dev/neofs-node/pkg/core/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/erasure"
 	"github.com/nspcc-dev/netmap"
 	"github.com/pkg/errors"
 )
@@ -32,9 +33,11 @@ func (g *graph) copy() *graph {
 	place = proto.Clone(g.place).(*netmap.PlacementRule)
 
 	return &graph{
-		roots: roots,
-		items: items,
-		place: place,
+		roots:   roots,
+		items:   items,
+		place:   place,
+		scheme:  g.scheme,
+		erasure: g.erasure,
 	}
 }
 
@@ -86,12 +89,19 @@ func (g *graph) Filter(rule FilterRule) Graph {
 	copy(items, g.items)
 
 	return &graph{
-		roots: roots,
-		items: items,
-		place: sub.place,
+		roots:   roots,
+		items:   items,
+		place:   sub.place,
+		scheme:  g.scheme,
+		erasure: g.erasure,
 	}
 }
 
+// ErasureScheme returns the erasure coding scheme of the container.
+func (g *graph) ErasureScheme() (erasure.Scheme, bool) {
+	return g.scheme, g.erasure
+}
+
 // NodeList returns slice of MultiAddresses for current graph.
 func (g *graph) NodeList() ([]multiaddr.Multiaddr, error) {
 	var (
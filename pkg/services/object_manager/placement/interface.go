@@ -8,7 +8,9 @@ import (
 	"github.com/nspcc-dev/neofs-api-go/refs"
 	"github.com/nspcc-dev/neofs-node/pkg/core/container/storage"
 	netmapcore "github.com/nspcc-dev/neofs-node/pkg/core/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/core/netmap/node"
 	"github.com/nspcc-dev/neofs-node/pkg/network/peers"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/erasure"
 	"github.com/nspcc-dev/netmap"
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
@@ -23,13 +25,18 @@ type (
 		Neighbours(seed, epoch uint64, full bool) []peers.ID
 		Update(epoch uint64, nm *netmapcore.NetMap) error
 		Query(ctx context.Context, opts ...QueryOption) (Graph, error)
+
+		// SetLocalStatus sets the status of the local node in the
+		// current network map until the next network map update.
+		SetLocalStatus(status node.Status) error
 	}
 
 	// QueryOptions for query request
 	QueryOptions struct {
-		CID      refs.CID
-		Previous int
-		Excludes []multiaddr.Multiaddr
+		CID         refs.CID
+		Previous    int
+		Excludes    []multiaddr.Multiaddr
+		ExcludeFull bool
 	}
 
 	// QueryOption settings closure
@@ -44,6 +51,10 @@ type (
 		Exclude(list []multiaddr.Multiaddr) Graph
 		NodeList() ([]multiaddr.Multiaddr, error)
 		NodeInfo() ([]netmapcore.Info, error)
+
+		// ErasureScheme returns the erasure coding
+		// scheme of the container objects.
+		ErasureScheme() (erasure.Scheme, bool)
 	}
 
 	// Key to fetch node-list
@@ -81,6 +92,10 @@ type (
 		roots []*netmap.Bucket
 		items []netmapcore.Info
 		place *netmap.PlacementRule
+
+		// erasure coding scheme of the container
+		scheme  erasure.Scheme
+		erasure bool
 	}
 )
 
@@ -98,6 +113,13 @@ func ContainerID(cid refs.CID) QueryOption {
 	}
 }
 
+// ExcludeFullNodes to ignore the nodes that do not accept new objects.
+func ExcludeFullNodes() QueryOption {
+	return func(opt *QueryOptions) {
+		opt.ExcludeFull = true
+	}
+}
+
 // UsePreviousNetmap for query.
 func UsePreviousNetmap(diff int) QueryOption {
 	return func(opt *QueryOptions) {
@@ -9,7 +9,9 @@ import (
 	"github.com/nspcc-dev/neofs-api-go/refs"
 	crypto "github.com/nspcc-dev/neofs-crypto"
 	"github.com/nspcc-dev/neofs-node/pkg/core/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/core/netmap/node"
 	"github.com/nspcc-dev/neofs-node/pkg/network/peers"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/erasure"
 	libnetmap "github.com/nspcc-dev/netmap"
 	"github.com/pkg/errors"
 	"go.uber.org/atomic"
@@ -27,6 +29,9 @@ var (
 
 	// ErrEmptyContainer when GetMaxSelection or GetSelection returns empty result
 	ErrEmptyContainer = errors.New("could not get container, it's empty")
+
+	// ErrTooFewNodes when container has fewer nodes than the parts of erasure coded object
+	ErrTooFewNodes = errors.New("too few container nodes for erasure coded parts")
 )
 
 var errNilNetMap = errors.New("network map is nil")
@@ -129,6 +134,39 @@ func (p *placement) Update(epoch uint64, nm *NetMap) error {
 	return nil
 }
 
+// SetLocalStatus sets the status of the local node in the current
+// network map, so the state changed by the node itself is applied
+// to the placement before the next network map.
+//
+// Nothing happens if the local node is not in the network map.
+func (p *placement) SetLocalStatus(status node.Status) error {
+	nm := p.nmStore.get(p.nmStore.epoch())
+	if nm == nil {
+		return errNilNetMap
+	}
+
+	pubkey, err := p.ps.GetPublicKey(p.ps.SelfID())
+	if err != nil {
+		return errors.Wrap(err, "could not get local public key")
+	}
+
+	key := crypto.MarshalPublicKey(pubkey)
+
+	// node list can be used by the concurrent queries, so it is copied
+	items := append([]netmap.Info(nil), nm.Nodes()...)
+
+	for i := range items {
+		if bytes.Equal(key, items[i].PublicKey()) {
+			items[i].SetStatus(status)
+			nm.SetNodes(items)
+
+			break
+		}
+	}
+
+	return nil
+}
+
 // NetworkState returns copy of current NetworkMap.
 func (p *placement) NetworkState() *bootstrap.SpreadMap {
 	ns := p.networkState(p.nmStore.epoch())
@@ -202,13 +240,31 @@ func (p *placement) Query(ctx context.Context, opts ...QueryOption) (Graph, erro
 		}
 	}
 
+	if query.ExcludeFull {
+		for i := range items {
+			if items[i].Status().Full() {
+				ignore = append(ignore, uint32(i))
+			}
+		}
+	}
+
 	rule := cnr.PlacementRule()
 
 	return ContainerGraph(state.nm, &rule, ignore, query.CID)
 }
 
 // ContainerGraph applies the placement rules to network map and returns container graph.
+//
+// Erasure coding selector of the rule is not applied to the network map,
+// objects of the container with the invalid erasure coding rule are
+// placed as the regular objects.
 func ContainerGraph(nm *NetMap, rule *libnetmap.PlacementRule, ignore []uint32, cid refs.CID) (Graph, error) {
+	scheme, ok, err := erasure.FromRule(*rule)
+	ok = ok && err == nil
+
+	trimmed := erasure.TrimRule(*rule)
+	rule = &trimmed
+
 	root := nm.Root()
 	roots := make([]*netmap.Bucket, 0, len(rule.SFGroups))
 
@@ -253,8 +309,10 @@ func ContainerGraph(nm *NetMap, rule *libnetmap.PlacementRule, ignore []uint32,
 	}
 
 	return &graph{
-		roots: roots,
-		items: nm.Nodes(),
-		place: rule,
+		roots:   roots,
+		items:   nm.Nodes(),
+		place:   rule,
+		scheme:  scheme,
+		erasure: ok,
 	}, nil
 }
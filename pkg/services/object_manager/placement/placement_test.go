@@ -19,7 +19,9 @@ import (
 	"github.com/nspcc-dev/neofs-node/pkg/core/container/acl/basic"
 	"github.com/nspcc-dev/neofs-node/pkg/core/container/storage"
 	netmapcore "github.com/nspcc-dev/neofs-node/pkg/core/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/core/netmap/node"
 	"github.com/nspcc-dev/neofs-node/pkg/network/peers"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/erasure"
 	testlogger "github.com/nspcc-dev/neofs-node/pkg/util/logger/test"
 	"github.com/nspcc-dev/neofs-node/pkg/util/test"
 	"github.com/nspcc-dev/netmap"
@@ -36,6 +38,14 @@ type (
 		*sync.RWMutex
 		items map[refs.CID]*storage.Container
 	}
+
+	// testSchemeGraph overrides the erasure
+	// coding scheme of the container graph.
+	testSchemeGraph struct {
+		Graph
+
+		scheme erasure.Scheme
+	}
 )
 
 var (
@@ -44,6 +54,10 @@ var (
 
 // -- -- //
 
+func (g testSchemeGraph) ErasureScheme() (erasure.Scheme, bool) {
+	return g.scheme, true
+}
+
 func testContainerStorage() *fakeContainerStorage {
 	return &fakeContainerStorage{
 		RWMutex: new(sync.RWMutex),
@@ -264,6 +278,28 @@ func TestPlacement(t *testing.T) {
 	cid3, err := cnrStorage.Put(cnr3)
 	require.NoError(t, err)
 
+	cnr4 := new(storage.Container)
+	cnr4.SetOwnerID(owner)
+	cnr4.SetBasicACL(basic.FromUint32(0))
+	cnr4.SetPlacementRule(netmap.PlacementRule{
+		SFGroups: []netmap.SFGroup{
+			{
+				Selectors: []netmap.Select{
+					{Key: erasure.SelectorKey, Count: 2},
+					{Key: "Country", Count: 1},
+					{Key: "City", Count: 3},
+					{Key: netmap.NodesBucket, Count: 2},
+				},
+				Filters: []netmap.Filter{
+					{Key: "Country", F: netmap.FilterIn("Germany", "Spain")},
+				},
+			},
+		},
+	})
+
+	cid4, err := cnrStorage.Put(cnr4)
+	require.NoError(t, err)
+
 	t.Run("Should fail on empty container", func(t *testing.T) {
 		_, err = p.Query(ctx, ContainerID(*cid2))
 		require.EqualError(t, errors.Cause(err), ErrEmptyContainer.Error())
@@ -378,6 +414,89 @@ func TestPlacement(t *testing.T) {
 		require.EqualError(t, errors.Cause(err), "failed to parse multiaddr \"BadAddress\": must begin with /")
 	})
 
+	t.Run("Should place erasure coded parts on distinct nodes", func(t *testing.T) {
+		g, err := p.Query(ctx, ContainerID(*cid4))
+		require.NoError(t, err)
+
+		// 1 Country, 3 Cities, 2 Nodes = 6 Nodes for 4+2 parts
+		all, err := ObjectNodes(g, oid)
+		require.NoError(t, err)
+		require.Len(t, all, 6)
+
+		var (
+			scheme = erasure.Scheme{Data: 4, Parity: 2}
+			used   = make(map[string]struct{}, len(all))
+		)
+
+		for i := 0; i < len(all); i++ {
+			nodes, err := ObjectNodes(g, erasure.PartID(oid, scheme, i))
+			require.NoError(t, err)
+			require.Len(t, nodes, 1)
+			require.NotContains(t, used, nodes[0].String(), strconv.Itoa(i))
+
+			used[nodes[0].String()] = struct{}{}
+		}
+	})
+
+	t.Run("Should keep erasure coded part node on exclusion", func(t *testing.T) {
+		g, err := p.Query(ctx, ContainerID(*cid4))
+		require.NoError(t, err)
+
+		var (
+			scheme = erasure.Scheme{Data: 4, Parity: 2}
+			id     = erasure.PartID(oid, scheme, 3)
+		)
+
+		nodes, err := ObjectNodes(g, id)
+		require.NoError(t, err)
+		require.Len(t, nodes, 1)
+
+		// nodes of the other parts are excluded
+		var excl []multiaddr.Multiaddr
+
+		for i := 0; i < scheme.Parts(); i++ {
+			if i != 3 {
+				other, err := ObjectNodes(g, erasure.PartID(oid, scheme, i))
+				require.NoError(t, err)
+
+				excl = append(excl, other...)
+			}
+		}
+
+		res, err := ObjectNodes(g, id, excl...)
+		require.NoError(t, err)
+		require.Equal(t, nodes, res)
+
+		res, err = ObjectNodes(g, id, nodes...)
+		require.NoError(t, err)
+		require.Empty(t, res)
+	})
+
+	t.Run("Should not place erasure coded parts on too few nodes", func(t *testing.T) {
+		g, err := p.Query(ctx, ContainerID(*cid4))
+		require.NoError(t, err)
+
+		// 6 Nodes for 5+2 parts
+		scheme := erasure.Scheme{Data: 5, Parity: 2}
+		g = testSchemeGraph{Graph: g, scheme: scheme}
+
+		for i := 0; i < scheme.Parts(); i++ {
+			_, err := ObjectNodes(g, erasure.PartID(oid, scheme, i))
+			require.True(t, errors.Is(errors.Cause(err), ErrTooFewNodes), strconv.Itoa(i))
+		}
+	})
+
+	t.Run("Should place part IDs of regular containers as regular objects", func(t *testing.T) {
+		g, err := p.Query(ctx, ContainerID(*cid1))
+		require.NoError(t, err)
+
+		id := erasure.PartID(oid, erasure.Scheme{Data: 2, Parity: 1}, 1)
+
+		nodes, err := ObjectNodes(g, id)
+		require.NoError(t, err)
+		require.Greater(t, len(nodes), 1)
+	})
+
 	list, err := g.
 		Filter(filter).
 		// must return same graph on empty filter
@@ -409,3 +528,35 @@ func TestContainerGraph(t *testing.T) {
 		})
 	})
 }
+
+func TestPlacement_SetLocalStatus(t *testing.T) {
+	nm := netmapcore.New()
+
+	for _, i := range []int{-1, 0} {
+		info := netmapcore.Info{}
+		info.SetAddress(address + idFromString(t, "NODE"+strconv.Itoa(i)))
+		info.SetPublicKey(crypto.MarshalPublicKey(&test.DecodeKey(i).PublicKey))
+		require.NoError(t, nm.AddNode(info))
+	}
+
+	p := New(Params{
+		Log:       testlogger.NewLogger(false),
+		Peerstore: testPeerstore(t),
+	})
+
+	require.NoError(t, p.Update(1, nm))
+
+	items := nm.Nodes()
+
+	var status node.Status
+	status.SetFull()
+
+	require.NoError(t, p.SetLocalStatus(status))
+
+	updated := nm.Nodes()
+	require.True(t, updated[0].Status().Full())
+	require.False(t, updated[1].Status().Full())
+
+	// node list used before the update is not changed
+	require.False(t, items[0].Status().Full())
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/nspcc-dev/neofs-api-go/object"
 	"github.com/nspcc-dev/neofs-api-go/refs"
 	netmapcore "github.com/nspcc-dev/neofs-node/pkg/core/netmap"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/erasure"
 	"github.com/nspcc-dev/netmap"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc/codes"
@@ -85,6 +86,48 @@ func (v PlacementWrapper) GetNodes(ctx context.Context, addr Address, usePreviou
 		queryOptions = append(queryOptions, UsePreviousNetmap(1))
 	}
 
+	return v.getNodes(ctx, addr, queryOptions, false, excl)
+}
+
+// GetStorageNodes returns the nodes of the current network map
+// that can store the new object. Full nodes are ignored.
+func (v PlacementWrapper) GetStorageNodes(ctx context.Context, addr Address, excl ...multiaddr.Multiaddr) ([]multiaddr.Multiaddr, error) {
+	return v.getNodes(ctx, addr, []QueryOption{ContainerID(addr.CID)}, true, excl)
+}
+
+func (v PlacementWrapper) getNodes(ctx context.Context, addr Address, queryOptions []QueryOption, excludeFull bool, excl []multiaddr.Multiaddr) ([]multiaddr.Multiaddr, error) {
+	// node of the part is selected from the whole container,
+	// so the full nodes are not excluded from the graph
+	if _, part := erasure.PartIndex(addr.ObjectID); part {
+		graph, err := v.query(ctx, queryOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		if info, ok, err := partNode(graph, addr.ObjectID); err != nil {
+			return nil, err
+		} else if ok {
+			return partNodes(info, excludeFull, excl)
+		}
+	}
+
+	if excludeFull {
+		queryOptions = append(queryOptions, ExcludeFullNodes())
+	}
+
+	graph, err := v.query(ctx, queryOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	if !addr.ObjectID.Empty() {
+		return ObjectNodes(graph, addr.ObjectID, excl...)
+	}
+
+	return graph.Exclude(excl).NodeList()
+}
+
+func (v PlacementWrapper) query(ctx context.Context, queryOptions []QueryOption) (Graph, error) {
 	graph, err := v.pl.Query(ctx, queryOptions...)
 	if err != nil {
 		if st, ok := status.FromError(errors.Cause(err)); ok && st.Code() == codes.NotFound {
@@ -94,17 +137,80 @@ func (v PlacementWrapper) GetNodes(ctx context.Context, addr Address, usePreviou
 		return nil, errors.Wrap(err, "placer.GetNodes failed on graph query")
 	}
 
-	filter := func(group netmap.SFGroup, bucket *netmap.Bucket) *netmap.Bucket {
-		return bucket
+	return graph, nil
+}
+
+// ObjectNodes returns the nodes of the container graph selected
+// for the object except the excluded nodes.
+//
+// Parts of the erasure coded object are selected by the common seed and
+// each part is placed on the single node by its index in the selection
+// of the whole container graph, so the parts are stored on the distinct
+// nodes and the node of the part does not depend on the excluded nodes.
+// No nodes are returned if the node of the part is excluded,
+// ErrTooFewNodes is returned if the container has fewer nodes than
+// the parts. Objects of the other containers are never the parts.
+func ObjectNodes(g Graph, id ObjectID, excl ...multiaddr.Multiaddr) ([]multiaddr.Multiaddr, error) {
+	if info, ok, err := partNode(g, id); err != nil {
+		return nil, err
+	} else if ok {
+		return partNodes(info, false, excl)
+	}
+
+	return objectGraph(g.Exclude(excl), id.Bytes()).NodeList()
+}
+
+// partNode returns the node of the erasure coded part.
+// Object is not a part if the container is not erasure coded
+// or its scheme does not match the number of the parts.
+func partNode(g Graph, id ObjectID) (netmapcore.Info, bool, error) {
+	index, part := erasure.PartIndex(id)
+	if !part {
+		return netmapcore.Info{}, false, nil
+	}
+
+	count := erasure.PartCount(id)
+	if scheme, ok := g.ErasureScheme(); !ok || scheme.Parts() != count {
+		return netmapcore.Info{}, false, nil
+	} else if index >= count {
+		return netmapcore.Info{}, true, errors.Wrapf(erasure.ErrInvalidPart, "index %d of %d parts", index, count)
 	}
 
-	if !addr.ObjectID.Empty() {
-		filter = func(group netmap.SFGroup, bucket *netmap.Bucket) *netmap.Bucket {
-			return bucket.GetSelection(group.Selectors, addr.ObjectID.Bytes())
+	nodes, err := objectGraph(g, erasure.PartSeed(id)).NodeInfo()
+	if err != nil {
+		return netmapcore.Info{}, true, err
+	} else if len(nodes) < count {
+		return netmapcore.Info{}, true, errors.Wrapf(ErrTooFewNodes, "%d nodes for %d parts", len(nodes), count)
+	}
+
+	return nodes[index], true, nil
+}
+
+// partNodes returns the node of the part unless it is excluded.
+func partNodes(info netmapcore.Info, excludeFull bool, excl []multiaddr.Multiaddr) ([]multiaddr.Multiaddr, error) {
+	if excludeFull && info.Status().Full() {
+		return nil, nil
+	}
+
+	node, err := multiaddr.NewMultiaddr(info.Address())
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not convert multi address(%s)", info.Address())
+	}
+
+	for i := range excl {
+		if excl[i].Equal(node) {
+			return nil, nil
 		}
 	}
 
-	return graph.Exclude(excl).Filter(filter).NodeList()
+	return []multiaddr.Multiaddr{node}, nil
+}
+
+// objectGraph returns the graph of the nodes selected by the seed.
+func objectGraph(g Graph, seed []byte) Graph {
+	return g.Filter(func(group netmap.SFGroup, bucket *netmap.Bucket) *netmap.Bucket {
+		return bucket.GetSelection(group.Selectors, seed)
+	})
 }
 
 func (v PlacementWrapper) IsContainerNode(ctx context.Context, addr multiaddr.Multiaddr, cid CID, previousNetMap bool) (bool, error) {
@@ -0,0 +1,211 @@
+package query
+
+import (
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/nspcc-dev/neofs-api-go/object"
+	"github.com/pkg/errors"
+)
+
+// Matcher checks whether the object satisfies the compiled query.
+type Matcher func(*object.Object) bool
+
+// Compile checks the query structure and returns its Matcher.
+func (q Query) Compile() (Matcher, error) {
+	m, err := q.Filter.compile(0)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidQuery, err.Error())
+	}
+
+	return m, nil
+}
+
+func (f Filter) compile(depth int) (Matcher, error) {
+	switch f.Op {
+	case OpAnd, OpOr, OpNot:
+		return f.compileGroup(depth)
+	}
+
+	if f.Key == "" {
+		return nil, errors.Errorf("%s filter without key", f.Op)
+	} else if len(f.Filters) > 0 {
+		return nil, errors.Errorf("%s filter with nested filters", f.Op)
+	}
+
+	switch f.Op {
+	case OpEQ:
+		return f.any(func(v string) bool { return v == f.Value }), nil
+	case OpNE:
+		eq := f.any(func(v string) bool { return v == f.Value })
+
+		return func(obj *object.Object) bool { return !eq(obj) }, nil
+	case OpPrefix:
+		return f.any(func(v string) bool { return strings.HasPrefix(v, f.Value) }), nil
+	case OpRegex:
+		re, err := regexp.Compile(f.Value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid %s regular expression", f.Key)
+		}
+
+		return f.any(re.MatchString), nil
+	case OpGT, OpGE, OpLT, OpLE:
+		num, ok := parseNumber(f.Value)
+		if !ok {
+			return nil, errors.Errorf("%s filter of %s with non-numeric value %s", f.Op, f.Key, f.Value)
+		}
+
+		cmp := comparisons[f.Op]
+
+		return f.any(func(v string) bool {
+			x, ok := parseNumber(v)
+			return ok && cmp(x.Cmp(num))
+		}), nil
+	case OpExists:
+		return f.any(func(string) bool { return true }), nil
+	default:
+		return nil, errors.Errorf("unknown filter operation `%s`", f.Op)
+	}
+}
+
+var comparisons = map[Op]func(int) bool{
+	OpGT: func(c int) bool { return c > 0 },
+	OpGE: func(c int) bool { return c >= 0 },
+	OpLT: func(c int) bool { return c < 0 },
+	OpLE: func(c int) bool { return c <= 0 },
+}
+
+func (f Filter) compileGroup(depth int) (Matcher, error) {
+	if depth >= maxDepth {
+		return nil, errors.Errorf("filter nesting exceeds %d", maxDepth)
+	} else if f.Key != "" || f.Value != "" {
+		return nil, errors.Errorf("%s filter with key or value", f.Op)
+	} else if len(f.Filters) == 0 {
+		return nil, errors.Errorf("empty %s filter", f.Op)
+	} else if f.Op == OpNot && len(f.Filters) != 1 {
+		return nil, errors.Errorf("%s filter with %d nested filters", f.Op, len(f.Filters))
+	}
+
+	ms := make([]Matcher, 0, len(f.Filters))
+
+	for i := range f.Filters {
+		m, err := f.Filters[i].compile(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+
+		ms = append(ms, m)
+	}
+
+	switch f.Op {
+	case OpNot:
+		return func(obj *object.Object) bool { return !ms[0](obj) }, nil
+	case OpOr:
+		return func(obj *object.Object) bool {
+			for i := range ms {
+				if ms[i](obj) {
+					return true
+				}
+			}
+
+			return false
+		}, nil
+	default:
+		return func(obj *object.Object) bool {
+			for i := range ms {
+				if !ms[i](obj) {
+					return false
+				}
+			}
+
+			return true
+		}, nil
+	}
+}
+
+// any returns the Matcher of the objects with any field value matching the predicate.
+func (f Filter) any(pred func(string) bool) Matcher {
+	key := f.Key
+
+	return func(obj *object.Object) bool {
+		for _, v := range values(obj, key) {
+			if pred(v) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+func parseNumber(s string) (*big.Float, bool) {
+	return new(big.Float).SetString(s)
+}
+
+// values returns the values of the object field by key.
+// Presence fields have an empty value if exist.
+func values(obj *object.Object, key string) []string {
+	sh := &obj.SystemHeader
+
+	switch key {
+	case KeyID:
+		return []string{sh.ID.String()}
+	case KeyCID:
+		return []string{sh.CID.String()}
+	case KeyOwnerID:
+		return []string{sh.OwnerID.String()}
+	case KeyPayloadLength:
+		return []string{strconv.FormatUint(sh.PayloadLength, 10)}
+	case KeyCreationEpoch:
+		return []string{strconv.FormatUint(sh.CreatedAt.Epoch, 10)}
+	case KeyCreationTime:
+		return []string{strconv.FormatInt(sh.CreatedAt.UnixTime, 10)}
+	case KeyParent:
+		return links(obj, object.Link_Parent)
+	case KeyChild:
+		return links(obj, object.Link_Child)
+	case KeyPrev:
+		return links(obj, object.Link_Previous)
+	case KeyNext:
+		return links(obj, object.Link_Next)
+	case KeyRoot:
+		return presence(len(links(obj, object.Link_Parent)) == 0)
+	case KeyTombstone:
+		return presence(obj.IsTombstone())
+	case KeyStorageGroup:
+		_, err := obj.StorageGroup()
+		return presence(err == nil)
+	}
+
+	var res []string
+
+	for i := range obj.Headers {
+		if h, ok := obj.Headers[i].Value.(*object.Header_UserHeader); ok && h.UserHeader != nil && h.UserHeader.Key == key {
+			res = append(res, h.UserHeader.Value)
+		}
+	}
+
+	return res
+}
+
+func links(obj *object.Object, t object.Link_Type) []string {
+	var res []string
+
+	for i := range obj.Headers {
+		if h, ok := obj.Headers[i].Value.(*object.Header_Link); ok && h.Link != nil && h.Link.Type == t {
+			res = append(res, h.Link.ID.String())
+		}
+	}
+
+	return res
+}
+
+func presence(ok bool) []string {
+	if ok {
+		return []string{""}
+	}
+
+	return nil
+}
@@ -0,0 +1,165 @@
+package query
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+type (
+	// Op is an enumeration of filter operations.
+	Op string
+
+	// Query is a search query of version 2.
+	//
+	// Query is transmitted in JSON encoding.
+	Query struct {
+		Filter Filter `json:"filter"`
+	}
+
+	// Filter is a condition on the object fields or a group of conditions.
+	//
+	// Group operations (AND, OR, NOT) combine the nested filters, NOT
+	// contains exactly one filter. Field operations check the values of
+	// the field by key: user header key or one of the system field keys.
+	// Field operation matches the object if any of the field values matches,
+	// except NE that matches if none of the values equals to the filter value.
+	// Numeric operations compare the decimal numbers, non-numeric field values
+	// never match. EXISTS matches if the field has any value.
+	Filter struct {
+		Op Op `json:"op"`
+
+		Key   string `json:"key,omitempty"`
+		Value string `json:"value,omitempty"`
+
+		Filters []Filter `json:"filters,omitempty"`
+	}
+)
+
+// Version is a search query format version of Query.
+const Version = 2
+
+const (
+	// OpAnd matches if all the nested filters match.
+	OpAnd Op = "AND"
+
+	// OpOr matches if any of the nested filters matches.
+	OpOr Op = "OR"
+
+	// OpNot matches if the nested filter does not match.
+	OpNot Op = "NOT"
+
+	// OpEQ matches the field values equal to the filter value.
+	OpEQ Op = "EQ"
+
+	// OpNE matches if none of the field values equals to the filter value.
+	OpNE Op = "NE"
+
+	// OpPrefix matches the field values with the filter value prefix.
+	OpPrefix Op = "PREFIX"
+
+	// OpRegex matches the field values by the filter value regular expression.
+	OpRegex Op = "REGEX"
+
+	// OpGT matches the numeric field values greater than the filter value.
+	OpGT Op = "GT"
+
+	// OpGE matches the numeric field values greater than or equal to the filter value.
+	OpGE Op = "GE"
+
+	// OpLT matches the numeric field values less than the filter value.
+	OpLT Op = "LT"
+
+	// OpLE matches the numeric field values less than or equal to the filter value.
+	OpLE Op = "LE"
+
+	// OpExists matches the fields with any value.
+	OpExists Op = "EXISTS"
+)
+
+const (
+	// KeyID is a system field key of the object ID.
+	KeyID = "$ID"
+
+	// KeyCID is a system field key of the container ID.
+	KeyCID = "$CID"
+
+	// KeyOwnerID is a system field key of the owner ID.
+	KeyOwnerID = "$OWNER_ID"
+
+	// KeyPayloadLength is a system field key of the payload length.
+	KeyPayloadLength = "$PAYLOAD_LENGTH"
+
+	// KeyCreationEpoch is a system field key of the creation epoch.
+	KeyCreationEpoch = "$CREATION_EPOCH"
+
+	// KeyCreationTime is a system field key of the creation unix time.
+	KeyCreationTime = "$CREATION_TIME"
+
+	// KeyParent is a system field key of the parent object ID.
+	KeyParent = "$PARENT"
+
+	// KeyChild is a system field key of the child object IDs.
+	KeyChild = "$CHILD"
+
+	// KeyPrev is a system field key of the previous object ID.
+	KeyPrev = "$PREV"
+
+	// KeyNext is a system field key of the next object ID.
+	KeyNext = "$NEXT"
+
+	// KeyRoot is a system field key that exists in the objects without parent.
+	KeyRoot = "$ROOT"
+
+	// KeyTombstone is a system field key that exists in the tombstones.
+	KeyTombstone = "$TOMBSTONE"
+
+	// KeyStorageGroup is a system field key that exists in the storage groups.
+	KeyStorageGroup = "$STORAGE_GROUP"
+)
+
+// maxDepth limits the nesting of the filter groups.
+const maxDepth = 16
+
+// ErrInvalidQuery is returned by Unmarshal and Compile
+// if the query is malformed.
+var ErrInvalidQuery = errors.New("invalid search query")
+
+// And returns the filter that matches if all the filters match.
+func And(fs ...Filter) Filter {
+	return Filter{Op: OpAnd, Filters: fs}
+}
+
+// Or returns the filter that matches if any of the filters matches.
+func Or(fs ...Filter) Filter {
+	return Filter{Op: OpOr, Filters: fs}
+}
+
+// Not returns the filter that matches if the filter does not match.
+func Not(f Filter) Filter {
+	return Filter{Op: OpNot, Filters: []Filter{f}}
+}
+
+// Field returns the filter of the field operation.
+func Field(op Op, key, value string) Filter {
+	return Filter{Op: op, Key: key, Value: value}
+}
+
+// Exists returns the filter that matches if the field exists.
+func Exists(key string) Filter {
+	return Filter{Op: OpExists, Key: key}
+}
+
+// Marshal encodes the query.
+func (q Query) Marshal() ([]byte, error) {
+	return json.Marshal(q)
+}
+
+// Unmarshal decodes the query, the query structure is checked by Compile.
+func (q *Query) Unmarshal(data []byte) error {
+	if err := json.Unmarshal(data, q); err != nil {
+		return errors.Wrap(ErrInvalidQuery, err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neofs-api-go/object"
+	"github.com/nspcc-dev/neofs-api-go/refs"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func testObject() *object.Object {
+	return &object.Object{
+		SystemHeader: object.SystemHeader{
+			ID:            refs.ObjectID{1},
+			CID:           refs.CID{2},
+			PayloadLength: 1024,
+			CreatedAt:     object.CreationPoint{Epoch: 10, UnixTime: 1600000000},
+		},
+		Headers: []object.Header{
+			{Value: &object.Header_UserHeader{UserHeader: &object.UserHeader{Key: "Content-Type", Value: "image/png"}}},
+			{Value: &object.Header_UserHeader{UserHeader: &object.UserHeader{Key: "Tag", Value: "a"}}},
+			{Value: &object.Header_UserHeader{UserHeader: &object.UserHeader{Key: "Tag", Value: "b"}}},
+			{Value: &object.Header_UserHeader{UserHeader: &object.UserHeader{Key: "Rating", Value: "4.5"}}},
+			{Value: &object.Header_Link{Link: &object.Link{Type: object.Link_Child, ID: refs.ObjectID{3}}}},
+		},
+	}
+}
+
+func TestQuery_Compile(t *testing.T) {
+	obj := testObject()
+
+	items := []struct {
+		name string
+		f    Filter
+		exp  bool
+	}{
+		{"eq", Field(OpEQ, "Tag", "b"), true},
+		{"eq mismatch", Field(OpEQ, "Tag", "c"), false},
+		{"ne", Field(OpNE, "Tag", "c"), true},
+		{"ne of any value", Field(OpNE, "Tag", "a"), false},
+		{"ne of missing header", Field(OpNE, "Missing", "a"), true},
+		{"prefix", Field(OpPrefix, "Content-Type", "image/"), true},
+		{"regex", Field(OpRegex, "Content-Type", "^image/(png|jpeg)$"), true},
+		{"gt", Field(OpGT, "Rating", "4"), true},
+		{"ge", Field(OpGE, "Rating", "4.5"), true},
+		{"lt", Field(OpLT, "Rating", "4.5"), false},
+		{"non-numeric value", Field(OpLT, "Tag", "10"), false},
+		{"exists", Exists("Rating"), true},
+		{"not exists", Not(Exists("Missing")), true},
+		{"id", Field(OpEQ, KeyID, refs.ObjectID{1}.String()), true},
+		{"cid", Field(OpEQ, KeyCID, refs.CID{2}.String()), true},
+		{"payload length", Field(OpLE, KeyPayloadLength, "1024"), true},
+		{"creation epoch", Field(OpGT, KeyCreationEpoch, "10"), false},
+		{"creation time", Field(OpGE, KeyCreationTime, "1600000000"), true},
+		{"child", Field(OpEQ, KeyChild, refs.ObjectID{3}.String()), true},
+		{"parent", Exists(KeyParent), false},
+		{"root", Exists(KeyRoot), true},
+		{"tombstone", Exists(KeyTombstone), false},
+		{"and", And(Exists("Tag"), Field(OpEQ, "Tag", "c")), false},
+		{"or", Or(Field(OpEQ, "Tag", "c"), Field(OpEQ, "Tag", "a")), true},
+		{"nested", And(
+			Field(OpPrefix, "Content-Type", "image/"),
+			Not(Or(Field(OpGT, KeyPayloadLength, "4096"), Exists(KeyTombstone))),
+		), true},
+	}
+
+	for _, item := range items {
+		m, err := Query{Filter: item.f}.Compile()
+		require.NoError(t, err, item.name)
+		require.Equal(t, item.exp, m(obj), item.name)
+	}
+
+	t.Run("invalid query", func(t *testing.T) {
+		deep := Exists("Tag")
+		for i := 0; i <= maxDepth; i++ {
+			deep = Not(deep)
+		}
+
+		for _, f := range []Filter{
+			{},
+			{Op: "LIKE", Key: "Tag"},
+			{Op: OpEQ},
+			{Op: OpEQ, Key: "Tag", Filters: []Filter{Exists("Tag")}},
+			{Op: OpAnd},
+			{Op: OpOr, Key: "Tag", Filters: []Filter{Exists("Tag")}},
+			{Op: OpNot, Filters: []Filter{Exists("Tag"), Exists("Rating")}},
+			Field(OpRegex, "Tag", "("),
+			Field(OpGT, "Rating", "high"),
+			And(Exists("Tag"), Field(OpEQ, "", "a")),
+			deep,
+		} {
+			_, err := Query{Filter: f}.Compile()
+			require.True(t, errors.Is(errors.Cause(err), ErrInvalidQuery))
+		}
+	})
+}
+
+func TestQuery_Marshal(t *testing.T) {
+	q := Query{Filter: And(Field(OpEQ, "Tag", "a"), Not(Exists(KeyTombstone)))}
+
+	data, err := q.Marshal()
+	require.NoError(t, err)
+
+	var res Query
+	require.NoError(t, res.Unmarshal(data))
+	require.Equal(t, q, res)
+
+	err = res.Unmarshal([]byte("{"))
+	require.True(t, errors.Is(errors.Cause(err), ErrInvalidQuery))
+}
@@ -163,8 +163,25 @@ type (
 	AddressStore interface {
 		SelfAddr() (multiaddr.Multiaddr, error)
 	}
+
+	// PartRestorer is an interface of entity for recreating
+	// the erasure coded parts from the other parts of the object.
+	PartRestorer interface {
+		// RestorePart returns the part object recreated from the other parts.
+		RestorePart(ctx context.Context, addr Address) (*Object, error)
+
+		// RestoreParts recreates the lost parts of the locally stored
+		// parent object and returns the number of the recreated parts.
+		// Parts are recreated by the first node of the parent placement,
+		// other nodes and other objects are ignored.
+		RestoreParts(ctx context.Context, addr Address) (int, error)
+	}
 )
 
+// ErrNotPart is returned by PartRestorer.RestorePart
+// if the object is not an erasure coded part.
+var ErrNotPart = errors.New("object is not an erasure coded part")
+
 const (
 	writeResultTimeout = "write result timeout"
 
@@ -183,6 +200,7 @@ const (
 	presenceCheckerPart          = "object presence checker"
 	weightComparatorPart         = "weight comparator"
 	addrStorePart                = "address store"
+	partRestorerPart             = "part restorer"
 )
 
 func instanceError(entity, part string) error {
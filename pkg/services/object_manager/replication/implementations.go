@@ -8,7 +8,6 @@ import (
 	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/localstore"
 	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/placement"
 	"github.com/nspcc-dev/neofs-node/pkg/util/rand"
-	"github.com/nspcc-dev/netmap"
 	"github.com/pkg/errors"
 )
 
@@ -168,9 +167,7 @@ func (s *multiSolver) ReservationRatio(ctx context.Context, addr Address) (int,
 		return 0, errors.Wrap(err, "reservation ratio computation failed on placement query")
 	}
 
-	nodes, err := graph.Filter(func(group netmap.SFGroup, bucket *netmap.Bucket) *netmap.Bucket {
-		return bucket.GetSelection(group.Selectors, addr.ObjectID.Bytes())
-	}).NodeList()
+	nodes, err := placement.ObjectNodes(graph, addr.ObjectID)
 	if err != nil {
 		return 0, errors.Wrap(err, "reservation ratio computation failed on graph node list")
 	}
@@ -220,14 +217,11 @@ func (s *multiSolver) selectNodes(ctx context.Context, addr Address, excl ...mul
 		return nil, errors.Wrap(err, "select remote storage nodes failed on placement query")
 	}
 
-	filter := func(group netmap.SFGroup, bucket *netmap.Bucket) *netmap.Bucket { return bucket }
 	if !addr.ObjectID.Empty() {
-		filter = func(group netmap.SFGroup, bucket *netmap.Bucket) *netmap.Bucket {
-			return bucket.GetSelection(group.Selectors, addr.ObjectID.Bytes())
-		}
+		return placement.ObjectNodes(graph, addr.ObjectID, excl...)
 	}
 
-	return graph.Exclude(excl).Filter(filter).NodeList()
+	return graph.Exclude(excl).NodeList()
 }
 
 func (s *multiSolver) Actual(ctx context.Context, cid CID) bool {
@@ -269,7 +263,7 @@ func (s *multiSolver) CompareWeight(ctx context.Context, addr Address, node mult
 
 	for {
 		nodes, err := s.selectNodes(ctx, addr, excl...)
-		if err != nil {
+		if err != nil || len(nodes) == 0 {
 			return -1
 		}
 
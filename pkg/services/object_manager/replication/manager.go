@@ -13,6 +13,10 @@ type (
 	Manager interface {
 		Process(ctx context.Context)
 		HandleEpoch(ctx context.Context, epoch uint64)
+
+		// Restore plans the restoration of the object
+		// that is found corrupted in the local storage.
+		Restore(ctx context.Context, addr Address)
 	}
 
 	manager struct {
@@ -26,10 +30,15 @@ type (
 		replicator       ObjectReplicator
 		restorer         ObjectRestorer
 		placementHonorer PlacementHonorer
+		partRestorer     PartRestorer
 
 		// internal task channels
 		detectLocationTaskChan chan<- Address
 		restoreTaskChan        chan<- Address
+		partTaskChan           chan Address
+
+		// external restore requests
+		restoreReqChan chan Address
 
 		pushTaskTimeout time.Duration
 
@@ -71,6 +80,10 @@ type (
 		ObjectReplicator
 		ObjectRestorer
 
+		// PartRestorer recreates the lost erasure coded parts
+		// of the stored objects, parts are not checked if it is not set.
+		PartRestorer
+
 		*zap.Logger
 
 		Scheduler
@@ -104,6 +117,16 @@ func (s *manager) HandleEpoch(ctx context.Context, epoch uint64) {
 	}
 }
 
+func (s *manager) Restore(ctx context.Context, addr Address) {
+	select {
+	case s.restoreReqChan <- addr:
+	case <-ctx.Done():
+	case <-time.After(s.pushTaskTimeout):
+		s.log.Warn("replication manager is busy, restore request dropped",
+			addressFields(addr)...)
+	}
+}
+
 func (s *manager) Process(ctx context.Context) {
 	// starting object restorer
 	// bind manager to push restore tasks to restorer
@@ -143,6 +166,11 @@ func (s *manager) Process(ctx context.Context) {
 	s.garbageChan = garbageChan
 	s.storageValidator.SubscribeGarbage(garbageChan)
 
+	if s.partRestorer != nil {
+		s.partTaskChan = make(chan Address, s.restoreResultChanCap)
+		go s.partRoutine(ctx)
+	}
+
 	go s.taskRoutine(ctx)
 	go s.resultRoutine(ctx)
 	s.processRoutine(ctx)
@@ -174,6 +202,40 @@ func (s *manager) writeRestoreTask(addr Address) {
 	}
 }
 
+func (s *manager) writePartTask(addr Address) {
+	if s.partTaskChan == nil {
+		return
+	}
+	select {
+	case s.partTaskChan <- addr:
+	case <-time.After(s.pushTaskTimeout):
+		s.log.Warn(writeResultTimeout)
+	}
+}
+
+func (s *manager) partRoutine(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			s.log.Warn(resultLog("part", ctxDoneMsg), zap.Error(ctx.Err()))
+			return
+		case addr, ok := <-s.partTaskChan:
+			if !ok {
+				s.log.Warn(resultLog("part", "part task channel closed"))
+				return
+			}
+
+			n, err := s.partRestorer.RestoreParts(ctx, addr)
+			if err != nil {
+				s.log.Warn("could not recreate erasure coded parts", append(addressFields(addr), zap.Error(err))...)
+			} else if n > 0 {
+				s.log.Info("erasure coded parts successfully recreated",
+					append(addressFields(addr), zap.Int("parts", n))...)
+			}
+		}
+	}
+}
+
 func (s *manager) resultRoutine(ctx context.Context) {
 loop:
 	for {
@@ -208,6 +270,15 @@ loop:
 func (s *manager) taskRoutine(ctx context.Context) {
 loop:
 	for {
+		// restore requests are handled in the same routine,
+		// because it closes the restore task channel
+		select {
+		case addr := <-s.restoreReqChan:
+			s.writeRestoreTask(addr)
+			continue loop
+		default:
+		}
+
 		if task, err := s.objectPool.Pop(); err == nil {
 			select {
 			case <-ctx.Done():
@@ -223,6 +294,10 @@ loop:
 	}
 	close(s.restoreTaskChan)
 	close(s.detectLocationTaskChan)
+
+	if s.partTaskChan != nil {
+		close(s.partTaskChan)
+	}
 }
 
 func (s *manager) processRoutine(ctx context.Context) {
@@ -267,7 +342,7 @@ func (s *manager) processRoutine(ctx context.Context) {
 
 // Function takes object from storage by address (if verify
 // If verify flag is set object stored incorrectly (Verify returned error) - restore task is planned
-// otherwise validate task is planned.
+// otherwise validate task and the check of the erasure coded parts are planned.
 func (s *manager) distributeTask(ctx context.Context, addr Address) {
 	if !s.objectVerifier.Verify(ctx, &ObjectVerificationParams{Address: addr}) {
 		s.writeRestoreTask(addr)
@@ -275,6 +350,7 @@ func (s *manager) distributeTask(ctx context.Context, addr Address) {
 	}
 
 	s.writeDetectLocationTask(addr)
+	s.writePartTask(addr)
 }
 
 // NewManager is an object manager's constructor.
@@ -334,10 +410,12 @@ func NewManager(p ManagerParams) (Manager, error) {
 		replicator:             p.ObjectReplicator,
 		restorer:               p.ObjectRestorer,
 		placementHonorer:       p.PlacementHonorer,
+		partRestorer:           p.PartRestorer,
 		pushTaskTimeout:        p.PushTaskTimeout,
 		garbageChanCap:         p.GarbageChanCap,
 		replicateResultChanCap: p.ReplicateTaskChanCap,
 		restoreResultChanCap:   p.RestoreTaskChanCap,
+		restoreReqChan:         make(chan Address, p.RestoreTaskChanCap),
 		garbageStore:           newGarbageStore(),
 		epochCh:                make(chan uint64),
 		scheduler:              p.Scheduler,
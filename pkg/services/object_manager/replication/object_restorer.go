@@ -6,6 +6,7 @@ import (
 
 	"github.com/multiformats/go-multiaddr"
 	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/localstore"
+	"github.com/pkg/errors"
 	"go.uber.org/zap"
 )
 
@@ -25,6 +26,7 @@ type (
 		objectReceptacle      ObjectReceptacle
 		epochReceiver         EpochReceiver
 		presenceChecker       PresenceChecker
+		partRestorer          PartRestorer
 		log                   *zap.Logger
 
 		taskChanCap   int
@@ -41,6 +43,10 @@ type (
 		PresenceChecker
 		*zap.Logger
 
+		// PartRestorer recreates the erasure coded parts,
+		// parts are not restored if it is not set.
+		PartRestorer
+
 		TaskChanCap   int
 		ResultTimeout time.Duration
 	}
@@ -93,10 +99,37 @@ loop:
 }
 
 func (s *objectRestorer) handleTask(ctx context.Context, addr Address) {
-	var (
-		receivedObj *Object
-		exclNodes   = make([]multiaddr.Multiaddr, 0)
-	)
+	var receivedObj *Object
+
+	// erasure coded part is stored on the single node, so it is
+	// recreated from the other parts, parts are recognized by
+	// their headers since any object ID can look like a part ID
+	if s.partRestorer != nil {
+		obj, err := s.partRestorer.RestorePart(ctx, addr)
+		if err == nil {
+			receivedObj = obj
+		} else if !errors.Is(errors.Cause(err), ErrNotPart) {
+			s.log.Warn("could not recreate erasure coded part", append(addressFields(addr), zap.Error(err))...)
+		}
+	}
+
+	if receivedObj == nil {
+		receivedObj = s.receiveObject(ctx, addr)
+	}
+
+	if err := s.objectReceptacle.Put(
+		context.WithValue(ctx, localstore.StoreEpochValue, s.epochReceiver.Epoch()),
+		ObjectStoreParams{Object: receivedObj},
+	); err != nil {
+		s.log.Warn("put object to local storage failure", append(addressFields(addr), zap.Error(err))...)
+		return
+	}
+
+	s.writeResult(addr)
+}
+
+func (s *objectRestorer) receiveObject(ctx context.Context, addr Address) (receivedObj *Object) {
+	exclNodes := make([]multiaddr.Multiaddr, 0)
 
 loop:
 	for {
@@ -124,15 +157,7 @@ loop:
 		}
 	}
 
-	if err := s.objectReceptacle.Put(
-		context.WithValue(ctx, localstore.StoreEpochValue, s.epochReceiver.Epoch()),
-		ObjectStoreParams{Object: receivedObj},
-	); err != nil {
-		s.log.Warn("put object to local storage failure", append(addressFields(addr), zap.Error(err))...)
-		return
-	}
-
-	s.writeResult(addr)
+	return
 }
 
 // NewObjectRestorer is an object restorer's constructor.
@@ -166,6 +191,7 @@ func NewObjectRestorer(p *ObjectRestorerParams) (ObjectRestorer, error) {
 		objectReceptacle:      p.ObjectReceptacle,
 		epochReceiver:         p.EpochReceiver,
 		presenceChecker:       p.PresenceChecker,
+		partRestorer:          p.PartRestorer,
 		log:                   p.Logger,
 		taskChanCap:           p.TaskChanCap,
 		resultTimeout:         p.ResultTimeout,
@@ -0,0 +1,276 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/multiformats/go-multiaddr"
+	"github.com/nspcc-dev/neofs-api-go/object"
+	"github.com/nspcc-dev/neofs-api-go/refs"
+	"github.com/nspcc-dev/neofs-api-go/service"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/localstore"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/erasure"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/replication"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/transport"
+	"github.com/nspcc-dev/neofs-node/pkg/util/logger"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+type (
+	partRestorer struct {
+		ls       localstore.Localstore
+		executor transport.SelectiveContainerExecutor
+		selector replication.RemoteStorageSelector
+		log      *zap.Logger
+	}
+
+	// PartRestorerParams groups the parameters of PartRestorer constructor.
+	PartRestorerParams struct {
+		Localstore                 localstore.Localstore
+		SelectiveContainerExecutor transport.SelectiveContainerExecutor
+		RemoteStorageSelector      replication.RemoteStorageSelector
+		Logger                     *zap.Logger
+	}
+)
+
+const partRestorerInstanceFailMsg = "could not create part restorer"
+
+var (
+	errEmptyStorageSelector = errors.New("empty remote storage selector")
+)
+
+func (s *partRestorer) RestorePart(ctx context.Context, addr refs.Address) (*object.Object, error) {
+	meta, err := s.ls.Meta(addr)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get part meta")
+	}
+
+	p, ok, err := erasure.ParsePart(meta.Object)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, replication.ErrNotPart
+	}
+
+	parent, err := s.get(ctx, refs.Address{CID: addr.CID, ObjectID: p.Parent})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get parent object")
+	}
+
+	return s.rebuild(ctx, parent, p.Index)
+}
+
+func (s *partRestorer) RestoreParts(ctx context.Context, addr refs.Address) (int, error) {
+	meta, err := s.ls.Meta(addr)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not get object meta")
+	} else if !erasure.IsParent(meta.Object) {
+		return 0, nil
+	}
+
+	// parent object is stored on several nodes, the parts
+	// are recreated by the first node of its placement only
+	if first, err := s.first(ctx, addr); err != nil {
+		return 0, err
+	} else if !first {
+		return 0, nil
+	}
+
+	parent := meta.Object
+	children := parent.Links(object.Link_Child)
+	found := make(map[refs.ObjectID]struct{}, len(children))
+
+	if err := s.executor.Head(ctx, &transport.HeadParams{
+		GetParams: transport.GetParams{
+			SelectiveParams: transport.SelectiveParams{
+				CID:    addr.CID,
+				TTL:    service.NonForwardingTTL,
+				IDList: children,
+				Raw:    true,
+				Breaker: func(a refs.Address) transport.ProgressControlFlag {
+					if _, ok := found[a.ObjectID]; ok {
+						return transport.NextAddress
+					}
+
+					return 0
+				},
+			},
+			Handler: func(_ multiaddr.Multiaddr, obj *object.Object) {
+				found[obj.SystemHeader.ID] = struct{}{}
+			},
+		},
+	}); err != nil {
+		return 0, errors.Wrap(err, "could not head the parts")
+	}
+
+	var restored int
+
+	for i := range children {
+		if _, ok := found[children[i]]; ok {
+			continue
+		}
+
+		part, err := s.rebuild(ctx, parent, i)
+		if err != nil {
+			return restored, errors.Wrapf(err, "could not recreate part %d", i)
+		} else if err := s.put(ctx, part); err != nil {
+			return restored, errors.Wrapf(err, "could not store part %d", i)
+		}
+
+		restored++
+	}
+
+	return restored, nil
+}
+
+// first checks if the local node is the first one in the object placement.
+func (s *partRestorer) first(ctx context.Context, addr refs.Address) (bool, error) {
+	nodes, err := s.selector.SelectRemoteStorages(ctx, addr)
+	if err != nil {
+		return false, errors.Wrap(err, "could not select object nodes")
+	}
+
+	return len(nodes) == 0 || !nodes[0].WeightGreater, nil
+}
+
+// rebuild recreates the part of the parent object from the other parts.
+func (s *partRestorer) rebuild(ctx context.Context, parent *object.Object, index int) (*object.Object, error) {
+	scheme, err := erasure.ParentScheme(parent)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		parts = make([]*object.Object, 0, scheme.Data)
+		ids   = make([]refs.ObjectID, 0, scheme.Parts()-1)
+		got   = make(map[refs.ObjectID]struct{}, scheme.Data)
+	)
+
+	for i := 0; i < scheme.Parts(); i++ {
+		if i == index {
+			continue
+		}
+
+		addr := refs.Address{CID: parent.SystemHeader.CID, ObjectID: erasure.PartID(parent.SystemHeader.ID, scheme, i)}
+
+		if obj, err := s.getLocal(addr); err == nil {
+			parts = append(parts, obj)
+			got[addr.ObjectID] = struct{}{}
+		} else {
+			ids = append(ids, addr.ObjectID)
+		}
+	}
+
+	if len(parts) < int(scheme.Data) && len(ids) > 0 {
+		if err := s.executor.Get(ctx, &transport.GetParams{
+			SelectiveParams: transport.SelectiveParams{
+				CID:    parent.SystemHeader.CID,
+				TTL:    service.NonForwardingTTL,
+				IDList: ids,
+				Raw:    true,
+				Breaker: func(a refs.Address) transport.ProgressControlFlag {
+					if len(parts) >= int(scheme.Data) {
+						return transport.BreakProgress
+					} else if _, ok := got[a.ObjectID]; ok {
+						return transport.NextAddress
+					}
+
+					return 0
+				},
+			},
+			Handler: func(_ multiaddr.Multiaddr, obj *object.Object) {
+				if _, ok := got[obj.SystemHeader.ID]; !ok {
+					parts = append(parts, obj)
+					got[obj.SystemHeader.ID] = struct{}{}
+				}
+			},
+		}); err != nil {
+			return nil, errors.Wrap(err, "could not get the parts")
+		}
+	}
+
+	return erasure.RebuildPart(parent, parts, index)
+}
+
+// put stores the part on the node selected for it.
+func (s *partRestorer) put(ctx context.Context, part *object.Object) error {
+	addr := *part.Address()
+
+	nodes, err := s.selector.SelectRemoteStorages(ctx, addr)
+	if err != nil {
+		return err
+	} else if len(nodes) == 0 {
+		// local node is selected for the part
+		return s.ls.Put(ctx, part)
+	}
+
+	return s.executor.Put(ctx, &transport.PutParams{
+		SelectiveParams: transport.SelectiveParams{
+			CID:    addr.CID,
+			Nodes:  []multiaddr.Multiaddr{nodes[0].Node},
+			TTL:    service.NonForwardingTTL,
+			IDList: make([]object.ID, 1),
+		},
+		Object: part,
+	})
+}
+
+func (s *partRestorer) get(ctx context.Context, addr refs.Address) (res *object.Object, err error) {
+	if res, err = s.getLocal(addr); err == nil {
+		return
+	}
+
+	if err = s.executor.Get(ctx, &transport.GetParams{
+		SelectiveParams: transport.SelectiveParams{
+			CID:    addr.CID,
+			TTL:    service.NonForwardingTTL,
+			IDList: []object.ID{addr.ObjectID},
+			Raw:    true,
+			Breaker: func(refs.Address) (cFlag transport.ProgressControlFlag) {
+				if res != nil {
+					cFlag = transport.BreakProgress
+				}
+				return
+			},
+		},
+		Handler: func(_ multiaddr.Multiaddr, obj *object.Object) { res = obj },
+	}); err != nil {
+		return
+	} else if res == nil {
+		return nil, errCouldNotGetObject
+	}
+
+	return
+}
+
+func (s *partRestorer) getLocal(addr refs.Address) (*object.Object, error) {
+	if has, err := s.ls.Has(addr); err != nil {
+		return nil, err
+	} else if !has {
+		return nil, errCouldNotGetObject
+	}
+
+	return s.ls.Get(addr)
+}
+
+// NewPartRestorer constructs replication.PartRestorer that recreates
+// the erasure coded parts from the parts stored in the container.
+func NewPartRestorer(p PartRestorerParams) (replication.PartRestorer, error) {
+	switch {
+	case p.Logger == nil:
+		return nil, errors.Wrap(logger.ErrNilLogger, partRestorerInstanceFailMsg)
+	case p.Localstore == nil:
+		return nil, errors.Wrap(errEmptyLocalstore, partRestorerInstanceFailMsg)
+	case p.SelectiveContainerExecutor == nil:
+		return nil, errors.Wrap(errEmptyObjectsContainerHandler, partRestorerInstanceFailMsg)
+	case p.RemoteStorageSelector == nil:
+		return nil, errors.Wrap(errEmptyStorageSelector, partRestorerInstanceFailMsg)
+	}
+
+	return &partRestorer{
+		ls:       p.Localstore,
+		executor: p.SelectiveContainerExecutor,
+		selector: p.RemoteStorageSelector,
+		log:      p.Logger,
+	}, nil
+}
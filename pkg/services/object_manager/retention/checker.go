@@ -0,0 +1,274 @@
+package retention
+
+import (
+	"context"
+
+	"github.com/multiformats/go-multiaddr"
+	"github.com/nspcc-dev/neofs-api-go/object"
+	"github.com/nspcc-dev/neofs-api-go/query"
+	"github.com/nspcc-dev/neofs-api-go/refs"
+	"github.com/nspcc-dev/neofs-api-go/service"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/localstore"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/transport"
+	"github.com/pkg/errors"
+)
+
+type (
+	// Checker is an interface of entity that checks
+	// if the object cannot be removed in the epoch.
+	Checker interface {
+		Locked(context.Context, refs.Address, uint64) (bool, error)
+	}
+
+	// Locator is an interface of entity that finds
+	// the lock objects of the target in its container.
+	Locator interface {
+		Locks(context.Context, refs.Address) ([]*object.Object, error)
+	}
+
+	// LocalCheckerParams groups the parameters of local Checker constructor.
+	LocalCheckerParams struct {
+		Localstore localstore.Localstore
+
+		// Holder checks the container legal hold,
+		// legal holds are not checked if it is nil.
+		Holder Holder
+
+		// Locator finds the lock objects stored on the other
+		// container nodes, only the local lock objects
+		// are checked if it is nil.
+		Locator Locator
+	}
+
+	// RemoteCheckerParams groups the parameters of remote Checker constructor.
+	RemoteCheckerParams struct {
+		SelectiveContainerExecutor transport.SelectiveContainerExecutor
+
+		// Holder checks the container legal hold,
+		// legal holds are not checked if it is nil.
+		Holder Holder
+	}
+
+	localChecker struct {
+		ls      localstore.Localstore
+		holder  Holder
+		locator Locator
+	}
+
+	remoteChecker struct {
+		executor transport.SelectiveContainerExecutor
+		holder   Holder
+		locator  Locator
+	}
+
+	remoteLocator struct {
+		executor transport.SelectiveContainerExecutor
+	}
+)
+
+var (
+	errNilLocalstore = errors.New("localstore is nil")
+	errNilExecutor   = errors.New("selective container executor is nil")
+)
+
+// NewLocalChecker constructs Checker that looks for the locks
+// of the object in the local storage.
+//
+// Object is locked by its own retention header or by the lock
+// objects. Lock objects are placed in the container by their own ID,
+// so the locks of the local object are usually stored on the other
+// nodes, such locks are found by the locator. Without the locator
+// only the lock objects stored locally protect the object.
+func NewLocalChecker(p LocalCheckerParams) (Checker, error) {
+	if p.Localstore == nil {
+		return nil, errNilLocalstore
+	}
+
+	return &localChecker{
+		ls:      p.Localstore,
+		holder:  p.Holder,
+		locator: p.Locator,
+	}, nil
+}
+
+// NewRemoteChecker constructs Checker that looks for the locks
+// of the object on the container nodes.
+//
+// Object is locked by its own retention header or by the lock
+// objects found by the container search.
+func NewRemoteChecker(p RemoteCheckerParams) (Checker, error) {
+	locator, err := NewRemoteLocator(p.SelectiveContainerExecutor)
+	if err != nil {
+		return nil, err
+	}
+
+	return &remoteChecker{
+		executor: p.SelectiveContainerExecutor,
+		holder:   p.Holder,
+		locator:  locator,
+	}, nil
+}
+
+// NewRemoteLocator constructs Locator that searches
+// for the lock objects on the container nodes.
+func NewRemoteLocator(executor transport.SelectiveContainerExecutor) (Locator, error) {
+	if executor == nil {
+		return nil, errNilExecutor
+	}
+
+	return &remoteLocator{
+		executor: executor,
+	}, nil
+}
+
+func (s *localChecker) Locked(ctx context.Context, addr refs.Address, epoch uint64) (bool, error) {
+	var (
+		guard = NewGuard(s.holder, s.locator)
+
+		// the object and its parent are locked by the lock objects
+		targets = []refs.ObjectID{addr.ObjectID}
+	)
+
+	if meta, err := s.ls.Meta(addr); err == nil {
+		guard.Add(meta.Object)
+
+		if parent, ok := parentID(meta.Object); ok {
+			targets = append(targets, parent)
+		}
+	} else if !errors.Is(errors.Cause(err), bucket.ErrNotFound) {
+		return false, errors.Wrap(err, "could not get object meta")
+	}
+
+	handler := func(meta *localstore.ObjectMeta) bool {
+		if meta.Object.SystemHeader.CID == addr.CID {
+			guard.Add(meta.Object)
+		}
+
+		return false
+	}
+
+	for i := range targets {
+		if err := s.searchLocks(addr.CID, targets[i], handler); err != nil {
+			return false, errors.Wrap(err, "could not list lock objects")
+		}
+	}
+
+	return guard.Retained(ctx, addr, epoch)
+}
+
+// searchLocks calls handler for the local lock objects of the target
+// through the localstore indexes, if indexes are not maintained,
+// handler is called for all the local objects.
+func (s *localChecker) searchLocks(cid refs.CID, target refs.ObjectID, handler localstore.MetaHandler) error {
+	err := localstore.ErrIndexDisabled
+
+	if searcher, ok := s.ls.(localstore.Searcher); ok {
+		err = searcher.Search([]localstore.IndexFilter{
+			{Attr: localstore.IndexCID, Value: cid.String()},
+			{Attr: localstore.IndexUserHeader, Key: TargetHeader, Value: target.String()},
+		}, handler)
+	}
+
+	if errors.Is(errors.Cause(err), localstore.ErrIndexDisabled) {
+		err = s.ls.Iterate(nil, handler)
+	}
+
+	return err
+}
+
+func (s *remoteChecker) Locked(ctx context.Context, addr refs.Address, epoch uint64) (bool, error) {
+	guard := NewGuard(s.holder, s.locator)
+
+	// the object header contains its own lock and the parent link
+	if err := head(ctx, s.executor, addr.CID, []refs.ObjectID{addr.ObjectID}, func(obj *object.Object) {
+		guard.Add(obj)
+	}); err != nil {
+		return false, errors.Wrap(err, "could not head object")
+	}
+
+	return guard.Retained(ctx, addr, epoch)
+}
+
+func (s *remoteLocator) Locks(ctx context.Context, target refs.Address) ([]*object.Object, error) {
+	q := query.Query{
+		Filters: []query.Filter{
+			{
+				Type:  query.Filter_Exact,
+				Name:  TargetHeader,
+				Value: target.ObjectID.String(),
+			},
+		},
+	}
+
+	queryBytes, err := q.Marshal()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal search query")
+	}
+
+	var (
+		ids   []refs.ObjectID
+		found = make(map[refs.ObjectID]struct{})
+	)
+
+	if err := s.executor.Search(ctx, &transport.SearchParams{
+		SelectiveParams: transport.SelectiveParams{
+			CID:    target.CID,
+			TTL:    service.NonForwardingTTL,
+			IDList: make([]refs.ObjectID, 1),
+		},
+		SearchCID:   target.CID,
+		SearchQuery: queryBytes,
+		Handler: func(_ multiaddr.Multiaddr, addrList []refs.Address) {
+			for i := range addrList {
+				if _, ok := found[addrList[i].ObjectID]; !ok {
+					found[addrList[i].ObjectID] = struct{}{}
+					ids = append(ids, addrList[i].ObjectID)
+				}
+			}
+		},
+	}); err != nil {
+		return nil, errors.Wrap(err, "could not search lock objects")
+	} else if len(ids) == 0 {
+		return nil, nil
+	}
+
+	locks := make([]*object.Object, 0, len(ids))
+
+	if err := head(ctx, s.executor, target.CID, ids, func(obj *object.Object) {
+		locks = append(locks, obj)
+	}); err != nil {
+		return nil, errors.Wrap(err, "could not head lock objects")
+	}
+
+	return locks, nil
+}
+
+// head calls handler for the header of each object once.
+func head(ctx context.Context, executor transport.SelectiveContainerExecutor, cid refs.CID, ids []refs.ObjectID, handler func(*object.Object)) error {
+	headed := make(map[refs.ObjectID]struct{}, len(ids))
+
+	return executor.Head(ctx, &transport.HeadParams{
+		GetParams: transport.GetParams{
+			SelectiveParams: transport.SelectiveParams{
+				CID:    cid,
+				TTL:    service.NonForwardingTTL,
+				IDList: ids,
+				Breaker: func(addr refs.Address) (f transport.ProgressControlFlag) {
+					if _, ok := headed[addr.ObjectID]; ok {
+						f = transport.NextAddress
+					}
+
+					return
+				},
+			},
+			Handler: func(_ multiaddr.Multiaddr, obj *object.Object) {
+				if _, ok := headed[obj.SystemHeader.ID]; !ok {
+					headed[obj.SystemHeader.ID] = struct{}{}
+					handler(obj)
+				}
+			},
+		},
+		FullHeaders: true,
+	})
+}
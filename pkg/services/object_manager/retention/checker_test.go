@@ -0,0 +1,190 @@
+package retention
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nspcc-dev/neofs-api-go/object"
+	"github.com/nspcc-dev/neofs-api-go/refs"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket/test"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/localstore"
+	lstest "github.com/nspcc-dev/neofs-node/pkg/local_object_storage/localstore/test"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/transport"
+	"github.com/stretchr/testify/require"
+)
+
+// testExecutor finds the lock objects by the target
+// and returns the headers of the stored objects.
+type testExecutor struct {
+	transport.SelectiveContainerExecutor
+
+	objs []*object.Object
+}
+
+func (s *testExecutor) Search(_ context.Context, p *transport.SearchParams) error {
+	var res []refs.Address
+
+	for _, obj := range s.objs {
+		if lock, ok, err := ParseLock(obj); err == nil && ok && len(lock.Targets) > 1 {
+			res = append(res, *obj.Address())
+		}
+	}
+
+	p.Handler(nil, res)
+
+	return nil
+}
+
+func (s *testExecutor) Head(_ context.Context, p *transport.HeadParams) error {
+	for _, id := range p.IDList {
+		for _, obj := range s.objs {
+			if obj.SystemHeader.ID == id {
+				p.Handler(nil, obj)
+			}
+		}
+	}
+
+	return nil
+}
+
+func testLocalstore(t *testing.T, index bucket.Bucket) localstore.Localstore {
+	return lstest.Localstore(t, localstore.Params{IndexBucket: index})
+}
+
+func TestLocalChecker(t *testing.T) {
+	var (
+		ctx        = context.Background()
+		selfLocked = testObject(t, Header, "10")
+		target     = testObject(t)
+		child      = testChild(t, target)
+		regular    = testObject(t)
+		lockObj    = testObject(t,
+			Header, "5",
+			TargetHeader, target.SystemHeader.ID.String(),
+		)
+	)
+
+	for _, index := range []bucket.Bucket{nil, test.Bucket()} {
+		ls := testLocalstore(t, index)
+
+		for _, obj := range []*object.Object{selfLocked, child, regular, lockObj} {
+			require.NoError(t, ls.Put(ctx, obj))
+		}
+
+		c, err := NewLocalChecker(LocalCheckerParams{Localstore: ls})
+		require.NoError(t, err)
+
+		for _, item := range []struct {
+			obj    *object.Object
+			epoch  uint64
+			locked bool
+		}{
+			{obj: selfLocked, epoch: 10, locked: true},
+			{obj: selfLocked, epoch: 11, locked: false},
+			{obj: target, epoch: 5, locked: true},
+			{obj: child, epoch: 5, locked: true},
+			{obj: child, epoch: 6, locked: false},
+			{obj: lockObj, epoch: 5, locked: true},
+			{obj: regular, epoch: 1, locked: false},
+		} {
+			ok, err := c.Locked(ctx, *item.obj.Address(), item.epoch)
+			require.NoError(t, err)
+			require.Equal(t, item.locked, ok)
+		}
+	}
+
+	_, err := NewLocalChecker(LocalCheckerParams{})
+	require.EqualError(t, err, errNilLocalstore.Error())
+}
+
+func TestLocalChecker_RemoteLocks(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		target  = testObject(t)
+		child   = testChild(t, target)
+		regular = testObject(t)
+		lockObj = testObject(t,
+			Header, "5",
+			TargetHeader, target.SystemHeader.ID.String(),
+		)
+	)
+
+	// lock object is placed on the other node
+	ls := testLocalstore(t, test.Bucket())
+
+	for _, obj := range []*object.Object{target, child, regular} {
+		require.NoError(t, ls.Put(ctx, obj))
+	}
+
+	locator, err := NewRemoteLocator(&testExecutor{
+		objs: []*object.Object{lockObj},
+	})
+	require.NoError(t, err)
+
+	for _, item := range []struct {
+		locator Locator
+		obj     *object.Object
+		epoch   uint64
+		locked  bool
+	}{
+		{obj: target, epoch: 5, locked: false},
+		{locator: locator, obj: target, epoch: 5, locked: true},
+		{locator: locator, obj: target, epoch: 6, locked: false},
+		{locator: locator, obj: child, epoch: 5, locked: true},
+		{locator: locator, obj: regular, epoch: 1, locked: false},
+	} {
+		c, err := NewLocalChecker(LocalCheckerParams{
+			Localstore: ls,
+			Locator:    item.locator,
+		})
+		require.NoError(t, err)
+
+		ok, err := c.Locked(ctx, *item.obj.Address(), item.epoch)
+		require.NoError(t, err)
+		require.Equal(t, item.locked, ok)
+	}
+
+	_, err = NewRemoteLocator(nil)
+	require.EqualError(t, err, errNilExecutor.Error())
+}
+
+func TestRemoteChecker(t *testing.T) {
+	var (
+		ctx        = context.Background()
+		selfLocked = testObject(t, Header, "10")
+		target     = testObject(t)
+		regular    = testObject(t)
+		lockObj    = testObject(t,
+			Header, "5",
+			TargetHeader, target.SystemHeader.ID.String(),
+		)
+	)
+
+	c, err := NewRemoteChecker(RemoteCheckerParams{
+		SelectiveContainerExecutor: &testExecutor{
+			objs: []*object.Object{selfLocked, target, regular, lockObj},
+		},
+		Holder: testHolder{testCID: false},
+	})
+	require.NoError(t, err)
+
+	for _, item := range []struct {
+		obj    *object.Object
+		epoch  uint64
+		locked bool
+	}{
+		{obj: selfLocked, epoch: 10, locked: true},
+		{obj: selfLocked, epoch: 11, locked: false},
+		{obj: target, epoch: 5, locked: true},
+		{obj: target, epoch: 6, locked: false},
+		{obj: regular, epoch: 1, locked: false},
+	} {
+		ok, err := c.Locked(ctx, *item.obj.Address(), item.epoch)
+		require.NoError(t, err)
+		require.Equal(t, item.locked, ok)
+	}
+
+	_, err = NewRemoteChecker(RemoteCheckerParams{})
+	require.EqualError(t, err, errNilExecutor.Error())
+}
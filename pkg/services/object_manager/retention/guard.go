@@ -0,0 +1,172 @@
+package retention
+
+import (
+	"context"
+
+	"github.com/nspcc-dev/neofs-api-go/object"
+	"github.com/nspcc-dev/neofs-api-go/refs"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+// Guard checks if the local objects are retained.
+//
+// Guard is filled with the locks of the local objects during
+// the iteration over the local storage. Lock objects are placed
+// by their own ID, so the locks of the local objects may be
+// stored on the other container nodes, such locks are found by
+// Locator on the check. Guard caches the found locks and the
+// container legal holds, so it is constructed for each collection.
+type Guard struct {
+	holder  Holder
+	locator Locator
+
+	// the latest retention epoch by the target address
+	locks map[refs.Address]uint64
+
+	// parent object address by the child address
+	parents map[refs.Address]refs.Address
+
+	// targets with the located locks
+	located map[refs.Address]struct{}
+
+	holds map[refs.CID]bool
+}
+
+// NewGuard is a Guard constructor.
+//
+// Container legal holds are not checked if holder is nil,
+// only the added locks are checked if locator is nil.
+func NewGuard(holder Holder, locator Locator) *Guard {
+	return &Guard{
+		holder:  holder,
+		locator: locator,
+		locks:   make(map[refs.Address]uint64),
+		parents: make(map[refs.Address]refs.Address),
+		located: make(map[refs.Address]struct{}),
+		holds:   make(map[refs.CID]bool),
+	}
+}
+
+// Add registers the retention lock and the parent of the object.
+//
+// Objects with the invalid lock are ignored,
+// such objects are rejected on Put.
+func (g *Guard) Add(obj *object.Object) {
+	if parent, ok := parentID(obj); ok {
+		g.parents[*obj.Address()] = refs.Address{ObjectID: parent, CID: obj.SystemHeader.CID}
+	}
+
+	lock, ok, err := ParseLock(obj)
+	if !ok || err != nil {
+		return
+	}
+
+	for i := range lock.Targets {
+		addr := refs.Address{ObjectID: lock.Targets[i], CID: obj.SystemHeader.CID}
+
+		if until, ok := g.locks[addr]; !ok || until < lock.Until {
+			g.locks[addr] = lock.Until
+		}
+	}
+}
+
+// Retained checks if the object cannot be removed in the epoch.
+//
+// Children of the locked object are retained along with the parent.
+func (g *Guard) Retained(ctx context.Context, addr refs.Address, epoch uint64) (bool, error) {
+	targets := []refs.Address{addr}
+	if parent, ok := g.parents[addr]; ok {
+		targets = append(targets, parent)
+	}
+
+	for i := range targets {
+		if g.locked(targets[i], epoch) {
+			return true, nil
+		}
+	}
+
+	for i := range targets {
+		if err := g.locate(ctx, targets[i]); err != nil {
+			return false, err
+		} else if g.locked(targets[i], epoch) {
+			return true, nil
+		}
+	}
+
+	if g.holder == nil {
+		return false, nil
+	}
+
+	hold, ok := g.holds[addr.CID]
+	if !ok {
+		var err error
+		if hold, err = g.holder.LegalHold(addr.CID); err != nil {
+			return false, err
+		}
+
+		g.holds[addr.CID] = hold
+	}
+
+	return hold, nil
+}
+
+// CheckRetained is a Retained variant for the collectors.
+//
+// Failure of the check is logged and the object is considered
+// retained, so the objects are not removed when the locks or
+// the legal hold cannot be checked.
+func (g *Guard) CheckRetained(ctx context.Context, addr refs.Address, epoch uint64, log *zap.Logger) bool {
+	ok, err := g.Retained(ctx, addr, epoch)
+	if err != nil {
+		log.Warn("could not check retention of object",
+			zap.Stringer("oid", addr.ObjectID),
+			zap.Stringer("cid", addr.CID),
+			zap.Error(err))
+
+		return true
+	}
+
+	return ok
+}
+
+// locate adds the locks of the target found by the locator.
+func (g *Guard) locate(ctx context.Context, target refs.Address) error {
+	if g.locator == nil {
+		return nil
+	} else if _, ok := g.located[target]; ok {
+		return nil
+	}
+
+	locks, err := g.locator.Locks(ctx, target)
+	if err != nil {
+		return errors.Wrap(err, "could not locate lock objects")
+	}
+
+	for i := range locks {
+		if locks[i].SystemHeader.CID == target.CID {
+			g.Add(locks[i])
+		}
+	}
+
+	g.located[target] = struct{}{}
+
+	return nil
+}
+
+func (g *Guard) locked(addr refs.Address, epoch uint64) bool {
+	until, ok := g.locks[addr]
+
+	return ok && epoch <= until
+}
+
+func parentID(obj *object.Object) (refs.ObjectID, bool) {
+	for i := range obj.Headers {
+		h, ok := obj.Headers[i].Value.(*object.Header_Link)
+		if ok && h.Link != nil && h.Link.Type == object.Link_Parent {
+			return h.Link.ID, true
+		}
+	}
+
+	return refs.ObjectID{}, false
+}
@@ -0,0 +1,169 @@
+package retention
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/nspcc-dev/neofs-api-go/object"
+	"github.com/nspcc-dev/neofs-api-go/refs"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type (
+	testHolder map[refs.CID]bool
+
+	// testLocator returns the lock objects
+	// of the target stored on the other nodes.
+	testLocator struct {
+		objs []*object.Object
+		err  error
+
+		calls map[refs.Address]int
+	}
+)
+
+func (s testHolder) LegalHold(cid refs.CID) (bool, error) {
+	hold, ok := s[cid]
+	if !ok {
+		return false, errors.New("container not found")
+	}
+
+	return hold, nil
+}
+
+func (s testLocator) Locks(_ context.Context, target refs.Address) ([]*object.Object, error) {
+	if s.err != nil {
+		return nil, s.err
+	} else if s.calls != nil {
+		s.calls[target]++
+	}
+
+	var res []*object.Object
+
+	for _, obj := range s.objs {
+		if lock, ok, err := ParseLock(obj); err == nil && ok && lock.Locks(target.ObjectID) {
+			res = append(res, obj)
+		}
+	}
+
+	return res, nil
+}
+
+func testChild(t *testing.T, parent *object.Object) *object.Object {
+	obj := testObject(t)
+	obj.AddHeader(&object.Header{Value: &object.Header_Link{
+		Link: &object.Link{Type: object.Link_Parent, ID: parent.SystemHeader.ID},
+	}})
+
+	return obj
+}
+
+func TestGuard(t *testing.T) {
+	var (
+		ctx        = context.Background()
+		selfLocked = testObject(t, Header, "10")
+		target     = testObject(t)
+		child      = testChild(t, target)
+		regular    = testObject(t)
+		lockObj    = testObject(t,
+			Header, "5",
+			TargetHeader, target.SystemHeader.ID.String(),
+		)
+		invalid = testObject(t,
+			Header, "ten",
+			TargetHeader, regular.SystemHeader.ID.String(),
+		)
+	)
+
+	t.Run("locks", func(t *testing.T) {
+		g := NewGuard(nil, nil)
+
+		for _, obj := range []*object.Object{selfLocked, target, child, regular, lockObj, invalid} {
+			g.Add(obj)
+		}
+
+		for i, item := range []struct {
+			obj      *object.Object
+			epoch    uint64
+			retained bool
+		}{
+			{obj: selfLocked, epoch: 10, retained: true},
+			{obj: selfLocked, epoch: 11, retained: false},
+			{obj: target, epoch: 5, retained: true},
+			{obj: target, epoch: 6, retained: false},
+			{obj: child, epoch: 5, retained: true},
+			{obj: child, epoch: 6, retained: false},
+			{obj: lockObj, epoch: 5, retained: true},
+			{obj: regular, epoch: 1, retained: false},
+		} {
+			ok, err := g.Retained(ctx, *item.obj.Address(), item.epoch)
+			require.NoError(t, err)
+			require.Equal(t, item.retained, ok, strconv.Itoa(i))
+		}
+	})
+
+	t.Run("locator", func(t *testing.T) {
+		locator := testLocator{
+			objs:  []*object.Object{lockObj},
+			calls: make(map[refs.Address]int),
+		}
+
+		g := NewGuard(nil, locator)
+
+		// lock object is stored on the other node
+		for _, obj := range []*object.Object{target, child, regular} {
+			g.Add(obj)
+		}
+
+		for i, item := range []struct {
+			obj      *object.Object
+			epoch    uint64
+			retained bool
+		}{
+			{obj: target, epoch: 5, retained: true},
+			{obj: target, epoch: 6, retained: false},
+			{obj: child, epoch: 5, retained: true},
+			{obj: child, epoch: 6, retained: false},
+			{obj: regular, epoch: 1, retained: false},
+		} {
+			ok, err := g.Retained(ctx, *item.obj.Address(), item.epoch)
+			require.NoError(t, err)
+			require.Equal(t, item.retained, ok, strconv.Itoa(i))
+		}
+
+		// locks of the target are located once
+		require.Equal(t, 1, locator.calls[*target.Address()])
+
+		g = NewGuard(nil, testLocator{err: errors.New("node unavailable")})
+
+		_, err := g.Retained(ctx, *regular.Address(), 1)
+		require.Error(t, err)
+		require.True(t, g.CheckRetained(ctx, *regular.Address(), 1, zap.L()))
+	})
+
+	t.Run("legal hold", func(t *testing.T) {
+		var (
+			heldCID    = refs.CIDForBytes([]byte("held"))
+			missingCID = refs.CIDForBytes([]byte("missing"))
+		)
+
+		g := NewGuard(testHolder{
+			testCID: false,
+			heldCID: true,
+		}, nil)
+
+		ok, err := g.Retained(ctx, *regular.Address(), 1)
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		ok, err = g.Retained(ctx, refs.Address{ObjectID: regular.SystemHeader.ID, CID: heldCID}, 1)
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		_, err = g.Retained(ctx, refs.Address{ObjectID: regular.SystemHeader.ID, CID: missingCID}, 1)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,147 @@
+package retention
+
+import (
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/nspcc-dev/neofs-api-go/object"
+	"github.com/nspcc-dev/neofs-api-go/refs"
+	"github.com/nspcc-dev/neofs-node/pkg/core/container/storage"
+	"github.com/pkg/errors"
+)
+
+type (
+	// Lock is a retention lock of the objects.
+	Lock struct {
+		// Until is the last epoch of the retention period.
+		Until uint64
+
+		// Targets are the locked objects of the lock container.
+		// Lock object is always the first target.
+		Targets []refs.ObjectID
+	}
+
+	// Holder is an interface of entity
+	// that checks the container legal hold.
+	Holder interface {
+		LegalHold(refs.CID) (bool, error)
+	}
+
+	storageHolder struct {
+		storage storage.Storage
+	}
+)
+
+const (
+	// Header is a key of the user header with the last epoch
+	// of the retention period. Object with the header cannot
+	// be removed until the epoch ends.
+	Header = "__NEOFS__RETENTION_UNTIL_EPOCH"
+
+	// TargetHeader is a key of the user header with the ID of the object
+	// locked by the lock object. Lock object has the retention header
+	// and one or more target headers, targets are in the same container.
+	TargetHeader = "__NEOFS__RETENTION_TARGET"
+
+	// LegalHoldBit is a number of the reserved basic ACL bit
+	// of the container legal hold. All the objects of the container
+	// under the legal hold cannot be removed.
+	LegalHoldBit uint8 = 0
+)
+
+var (
+	// ErrInvalidEpoch is returned by ParseLock if the retention
+	// header value is not a decimal epoch number.
+	ErrInvalidEpoch = errors.New("invalid retention epoch")
+
+	// ErrInvalidTarget is returned by ParseLock if the target
+	// header value is not an object ID.
+	ErrInvalidTarget = errors.New("invalid retention target")
+
+	// ErrMissingEpoch is returned by ParseLock if the object
+	// has target headers without the retention header.
+	ErrMissingEpoch = errors.New("missing retention epoch")
+)
+
+// ParseLock returns the retention lock of the object.
+//
+// False is returned if the object does not have the retention header.
+// If there are several retention headers, the last one is used.
+func ParseLock(obj *object.Object) (Lock, bool, error) {
+	var (
+		val string
+		ok  bool
+		res = Lock{Targets: []refs.ObjectID{obj.SystemHeader.ID}}
+	)
+
+	for i := range obj.Headers {
+		h, isUser := obj.Headers[i].Value.(*object.Header_UserHeader)
+		if !isUser || h.UserHeader == nil {
+			continue
+		}
+
+		switch h.UserHeader.Key {
+		case Header:
+			val, ok = h.UserHeader.Value, true
+		case TargetHeader:
+			id, err := uuid.Parse(h.UserHeader.Value)
+			if err != nil {
+				return Lock{}, true, errors.Wrapf(ErrInvalidTarget, "given '%s'", h.UserHeader.Value)
+			}
+
+			res.Targets = append(res.Targets, refs.ObjectID(id))
+		}
+	}
+
+	if !ok {
+		if len(res.Targets) > 1 {
+			return Lock{}, true, ErrMissingEpoch
+		}
+
+		return Lock{}, false, nil
+	}
+
+	e, err := strconv.ParseUint(val, 10, 64)
+	if err != nil {
+		return Lock{}, true, errors.Wrapf(ErrInvalidEpoch, "given '%s'", val)
+	}
+
+	res.Until = e
+
+	return res, true, nil
+}
+
+// Active checks if the retention period includes the epoch.
+func (l Lock) Active(epoch uint64) bool {
+	return epoch <= l.Until
+}
+
+// Locks checks if the object is one of the lock targets.
+func (l Lock) Locks(id refs.ObjectID) bool {
+	for i := range l.Targets {
+		if l.Targets[i] == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NewHolder constructs Holder that reads
+// the legal hold bit of the container basic ACL.
+func NewHolder(s storage.Storage) (Holder, error) {
+	if s == nil {
+		return nil, storage.ErrNilStorage
+	}
+
+	return &storageHolder{storage: s}, nil
+}
+
+func (s *storageHolder) LegalHold(cid refs.CID) (bool, error) {
+	cnr, err := s.storage.Get(cid)
+	if err != nil {
+		return false, errors.Wrap(err, "could not get container")
+	}
+
+	return cnr.BasicACL().Reserved(LegalHoldBit), nil
+}
@@ -0,0 +1,116 @@
+package retention
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neofs-api-go/object"
+	"github.com/nspcc-dev/neofs-api-go/refs"
+	"github.com/nspcc-dev/neofs-node/pkg/core/container"
+	"github.com/nspcc-dev/neofs-node/pkg/core/container/storage/test"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+var testCID = refs.CIDForBytes([]byte("container"))
+
+func testObject(t *testing.T, kv ...string) *object.Object {
+	id, err := refs.NewObjectID()
+	require.NoError(t, err)
+
+	obj := &object.Object{
+		SystemHeader: object.SystemHeader{ID: id, CID: testCID},
+	}
+
+	for i := 0; i < len(kv); i += 2 {
+		obj.Headers = append(obj.Headers, object.Header{Value: &object.Header_UserHeader{
+			UserHeader: &object.UserHeader{Key: kv[i], Value: kv[i+1]},
+		}})
+	}
+
+	return obj
+}
+
+func TestParseLock(t *testing.T) {
+	target := testObject(t)
+
+	_, ok, err := ParseLock(testObject(t, "Name", "value"))
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	obj := testObject(t, Header, "1", Header, "10")
+
+	lock, ok, err := ParseLock(obj)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, Lock{Until: 10, Targets: []refs.ObjectID{obj.SystemHeader.ID}}, lock)
+
+	obj = testObject(t, TargetHeader, target.SystemHeader.ID.String(), Header, "10")
+
+	lock, ok, err = ParseLock(obj)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, Lock{Until: 10, Targets: []refs.ObjectID{obj.SystemHeader.ID, target.SystemHeader.ID}}, lock)
+
+	_, ok, err = ParseLock(testObject(t, Header, "ten"))
+	require.True(t, ok)
+	require.True(t, errors.Is(errors.Cause(err), ErrInvalidEpoch))
+
+	_, ok, err = ParseLock(testObject(t, Header, "10", TargetHeader, "object"))
+	require.True(t, ok)
+	require.True(t, errors.Is(errors.Cause(err), ErrInvalidTarget))
+
+	_, ok, err = ParseLock(testObject(t, TargetHeader, target.SystemHeader.ID.String()))
+	require.True(t, ok)
+	require.EqualError(t, err, ErrMissingEpoch.Error())
+}
+
+func TestLock(t *testing.T) {
+	lock := Lock{Until: 10, Targets: []refs.ObjectID{{1}, {2}}}
+
+	require.True(t, lock.Active(9))
+	require.True(t, lock.Active(10))
+	require.False(t, lock.Active(11))
+
+	require.True(t, lock.Locks(refs.ObjectID{2}))
+	require.False(t, lock.Locks(refs.ObjectID{3}))
+}
+
+func TestHolder(t *testing.T) {
+	_, err := NewHolder(nil)
+	require.Error(t, err)
+
+	s := test.New()
+
+	holder, err := NewHolder(s)
+	require.NoError(t, err)
+
+	var (
+		free = new(container.Container)
+		held = new(container.Container)
+		acl  container.BasicACL
+	)
+
+	free.SetSalt([]byte{1})
+	free.SetBasicACL(acl)
+
+	acl.SetReserved(LegalHoldBit)
+	held.SetSalt([]byte{2})
+	held.SetBasicACL(acl)
+
+	freeID, err := s.Put(free)
+	require.NoError(t, err)
+
+	heldID, err := s.Put(held)
+	require.NoError(t, err)
+
+	ok, err := holder.LegalHold(*freeID)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	ok, err = holder.LegalHold(*heldID)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	_, err = holder.LegalHold(testCID)
+	require.Error(t, err)
+}
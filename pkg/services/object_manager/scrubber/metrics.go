@@ -0,0 +1,54 @@
+package scrubber
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	scrubbedObjects = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "neofs",
+		Name:      "scrub_objects_total",
+		Help:      "Number of the objects verified by the scrubber",
+	})
+
+	scrubbedBytes = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "neofs",
+		Name:      "scrub_bytes_total",
+		Help:      "Size of the payload verified by the scrubber",
+	})
+
+	corruptedObjects = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "neofs",
+		Name:      "scrub_corrupted_objects_total",
+		Help:      "Number of the corrupted objects found by the scrubber",
+	})
+
+	passObjects = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "neofs",
+		Name:      "scrub_pass_objects",
+		Help:      "Number of the objects to verify in the current scrub pass",
+	})
+
+	passScrubbed = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "neofs",
+		Name:      "scrub_pass_scrubbed_objects",
+		Help:      "Number of the objects verified in the current scrub pass",
+	})
+
+	passDuration = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "neofs",
+		Name:      "scrub_last_pass_duration_seconds",
+		Help:      "Duration of the last finished scrub pass",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(
+		scrubbedObjects,
+		scrubbedBytes,
+		corruptedObjects,
+		passObjects,
+		passScrubbed,
+		passDuration,
+	)
+}
@@ -0,0 +1,215 @@
+package scrubber
+
+import (
+	"context"
+	"time"
+
+	"github.com/nspcc-dev/neofs-api-go/hash"
+	"github.com/nspcc-dev/neofs-api-go/refs"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/localstore"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/verifier"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+type (
+	// Restorer is an interface of the entity that fetches
+	// a healthy copy of the corrupted object.
+	Restorer interface {
+		Restore(ctx context.Context, addr refs.Address)
+	}
+
+	// Params groups the parameters of the scrubber constructor.
+	Params struct {
+		Localstore localstore.Localstore
+		Restorer   Restorer
+
+		// Verifier checks payload checksum and integrity headers,
+		// it should be the same verifier the object service uses
+		// for the incoming objects.
+		Verifier verifier.Verifier
+
+		Logger *zap.Logger
+
+		// Rate is a limit of the scrubbed payload bytes per second,
+		// zero means no limit.
+		Rate uint64
+	}
+
+	// Scrubber re-verifies the stored objects.
+	Scrubber struct {
+		ls       localstore.Localstore
+		restorer Restorer
+		verifier verifier.Verifier
+		log      *zap.Logger
+		rate     uint64
+	}
+)
+
+var (
+	errNilLocalstore = errors.New("localstore is nil")
+	errNilRestorer   = errors.New("restorer is nil")
+	errNilVerifier   = errors.New("verifier is nil")
+	errNilLogger     = errors.New("logger is nil")
+
+	errPayloadSize = errors.New("payload size differs from meta")
+	errPayloadHash = errors.New("payload homomorphic hash differs from meta")
+)
+
+// New is a scrubber constructor.
+func New(p Params) (*Scrubber, error) {
+	switch {
+	case p.Localstore == nil:
+		return nil, errNilLocalstore
+	case p.Restorer == nil:
+		return nil, errNilRestorer
+	case p.Verifier == nil:
+		return nil, errNilVerifier
+	case p.Logger == nil:
+		return nil, errNilLogger
+	}
+
+	return &Scrubber{
+		ls:       p.Localstore,
+		restorer: p.Restorer,
+		verifier: p.Verifier,
+		log:      p.Logger,
+		rate:     p.Rate,
+	}, nil
+}
+
+// Scrub makes a single pass over all stored objects.
+//
+// Corrupted objects are moved to the quarantine if localstore supports it
+// and their restoration is requested. Pass is interrupted when the context
+// is done.
+func (s *Scrubber) Scrub(ctx context.Context) {
+	var addrs []refs.Address
+
+	if err := s.ls.Iterate(nil, func(meta *localstore.ObjectMeta) bool {
+		addrs = append(addrs, *meta.Object.Address())
+		return false
+	}); err != nil {
+		s.log.Error("could not list objects to scrub", zap.Error(err))
+		return
+	}
+
+	s.log.Info("scrub pass started",
+		zap.Int("objects", len(addrs)))
+
+	var (
+		start = time.Now()
+		read  uint64
+	)
+
+	passObjects.Set(float64(len(addrs)))
+	passScrubbed.Set(0)
+
+	for i := range addrs {
+		if ctx.Err() != nil {
+			s.log.Info("scrub pass interrupted",
+				zap.Int("scrubbed", i))
+
+			return
+		}
+
+		sz, err := s.check(ctx, addrs[i])
+		if err != nil {
+			s.handleCorrupted(ctx, addrs[i], err)
+		}
+
+		read += sz
+
+		scrubbedObjects.Inc()
+		scrubbedBytes.Add(float64(sz))
+		passScrubbed.Set(float64(i + 1))
+
+		s.throttle(ctx, start, read)
+	}
+
+	passDuration.Set(time.Since(start).Seconds())
+
+	s.log.Info("scrub pass finished",
+		zap.Int("objects", len(addrs)),
+		zap.Duration("duration", time.Since(start)))
+}
+
+// check verifies the stored object and returns the size of its payload.
+// Objects removed during the pass are skipped.
+func (s *Scrubber) check(ctx context.Context, addr refs.Address) (uint64, error) {
+	meta, err := s.ls.Meta(addr)
+	if err != nil {
+		if !errors.Is(errors.Cause(err), bucket.ErrNotFound) {
+			s.log.Warn("could not read object meta",
+				append(addressFields(addr), zap.Error(err))...)
+		}
+
+		return 0, nil
+	}
+
+	obj, err := s.ls.Get(addr)
+	if err != nil {
+		if errors.Is(errors.Cause(err), bucket.ErrNotFound) {
+			return 0, nil
+		}
+
+		return 0, err
+	}
+
+	sz := uint64(len(obj.Payload))
+
+	switch {
+	case sz != meta.PayloadSize:
+		return sz, errPayloadSize
+	case hash.Sum(obj.Payload) != meta.PayloadHash:
+		return sz, errPayloadHash
+	}
+
+	return sz, s.verifier.Verify(ctx, obj)
+}
+
+func (s *Scrubber) handleCorrupted(ctx context.Context, addr refs.Address, reason error) {
+	corruptedObjects.Inc()
+
+	s.log.Warn("corrupted object found",
+		append(addressFields(addr), zap.Error(reason))...)
+
+	// without quarantine the corrupted copy is overwritten on restoration
+	if q, ok := s.ls.(localstore.Quarantiner); ok {
+		if err := q.Quarantine(addr); err != nil &&
+			!errors.Is(errors.Cause(err), localstore.ErrQuarantineDisabled) {
+			s.log.Error("could not quarantine corrupted object",
+				append(addressFields(addr), zap.Error(err))...)
+		}
+	}
+
+	s.restorer.Restore(ctx, addr)
+}
+
+func addressFields(addr refs.Address) []zap.Field {
+	return []zap.Field{
+		zap.Stringer("oid", addr.ObjectID),
+		zap.Stringer("cid", addr.CID),
+	}
+}
+
+// throttle sleeps to keep the read rate under the limit.
+func (s *Scrubber) throttle(ctx context.Context, start time.Time, read uint64) {
+	if s.rate == 0 {
+		return
+	}
+
+	wait := time.Duration(float64(read)/float64(s.rate)*float64(time.Second)) - time.Since(start)
+	if wait <= 0 {
+		return
+	}
+
+	t := time.NewTimer(wait)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+	case <-t.C:
+	}
+}
@@ -0,0 +1,98 @@
+package scrubber
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/nspcc-dev/neofs-api-go/object"
+	"github.com/nspcc-dev/neofs-api-go/refs"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket/test"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/localstore"
+	lstest "github.com/nspcc-dev/neofs-node/pkg/local_object_storage/localstore/test"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type (
+	testRestorer struct {
+		mtx   sync.Mutex
+		addrs []refs.Address
+	}
+
+	testVerifier struct{}
+)
+
+func (r *testRestorer) Restore(_ context.Context, addr refs.Address) {
+	r.mtx.Lock()
+	r.addrs = append(r.addrs, addr)
+	r.mtx.Unlock()
+}
+
+func (testVerifier) Verify(context.Context, *object.Object) error { return nil }
+
+func testObject(t *testing.T, payload string) *object.Object {
+	id, err := refs.NewObjectID()
+	require.NoError(t, err)
+
+	obj := &object.Object{
+		SystemHeader: object.SystemHeader{
+			ID:  id,
+			CID: refs.CIDForBytes([]byte("container")),
+		},
+	}
+	obj.SetPayload([]byte(payload))
+
+	return obj
+}
+
+func TestScrubber_Scrub(t *testing.T) {
+	blob, quarantine := test.Bucket(), test.Bucket()
+
+	ls := lstest.Localstore(t, localstore.Params{
+		BlobBucket:       blob,
+		QuarantineBucket: quarantine,
+	})
+
+	healthy, corrupted := testObject(t, "healthy payload"), testObject(t, "corrupted payload")
+
+	for _, obj := range []*object.Object{healthy, corrupted} {
+		require.NoError(t, ls.Put(context.Background(), obj))
+	}
+
+	// simulate bit rot of the payload
+	k, err := corrupted.Address().Hash()
+	require.NoError(t, err)
+
+	v, err := blob.Get(k)
+	require.NoError(t, err)
+
+	v[len(v)-1] ^= 0xFF
+	require.NoError(t, blob.Set(k, v))
+
+	r := new(testRestorer)
+
+	s, err := New(Params{
+		Localstore: ls,
+		Restorer:   r,
+		Verifier:   testVerifier{},
+		Logger:     zap.L(),
+	})
+	require.NoError(t, err)
+
+	s.Scrub(context.Background())
+
+	require.Equal(t, []refs.Address{*corrupted.Address()}, r.addrs)
+
+	ok, err := ls.Has(*corrupted.Address())
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	ok, err = ls.Has(*healthy.Address())
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	q, err := quarantine.Get(k)
+	require.NoError(t, err)
+	require.Equal(t, v, q)
+}
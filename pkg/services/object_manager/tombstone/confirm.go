@@ -0,0 +1,104 @@
+package tombstone
+
+import (
+	"context"
+
+	"github.com/multiformats/go-multiaddr"
+	"github.com/nspcc-dev/neofs-api-go/query"
+	"github.com/nspcc-dev/neofs-api-go/refs"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/transport"
+	"github.com/pkg/errors"
+)
+
+type (
+	// Confirmer is an interface of entity that checks
+	// if the object is removed on all the container nodes.
+	Confirmer interface {
+		Confirm(context.Context, refs.Address) (bool, error)
+	}
+
+	// NodeLister is an interface of entity
+	// that lists the nodes of the container.
+	NodeLister interface {
+		ContainerNodes(context.Context, refs.CID) ([]multiaddr.Multiaddr, error)
+	}
+
+	// ConfirmerParams groups the parameters of Confirmer constructor.
+	ConfirmerParams struct {
+		SelectiveContainerExecutor transport.SelectiveContainerExecutor
+		NodeLister                 NodeLister
+	}
+
+	remoteConfirmer struct {
+		executor transport.SelectiveContainerExecutor
+		nodes    NodeLister
+	}
+)
+
+var (
+	errNilExecutor   = errors.New("selective container executor is nil")
+	errNilNodeLister = errors.New("container node lister is nil")
+)
+
+// NewConfirmer constructs Confirmer that searches
+// for the object on the container nodes.
+//
+// Node confirms the removal if it does not store the object
+// or stores its tombstone. Nodes that did not respond do not
+// confirm the removal.
+func NewConfirmer(p ConfirmerParams) (Confirmer, error) {
+	switch {
+	case p.SelectiveContainerExecutor == nil:
+		return nil, errNilExecutor
+	case p.NodeLister == nil:
+		return nil, errNilNodeLister
+	}
+
+	return &remoteConfirmer{
+		executor: p.SelectiveContainerExecutor,
+		nodes:    p.NodeLister,
+	}, nil
+}
+
+func (s *remoteConfirmer) Confirm(ctx context.Context, addr refs.Address) (bool, error) {
+	nodes, err := s.nodes.ContainerNodes(ctx, addr.CID)
+	if err != nil {
+		return false, errors.Wrap(err, "could not list container nodes")
+	}
+
+	found, err := transport.SearchObject(ctx, s.executor, addr, nodes)
+	if err != nil {
+		return false, err
+	}
+
+	holders := make([]multiaddr.Multiaddr, 0, len(nodes))
+
+	for i := range nodes {
+		has, ok := found[nodes[i].String()]
+		if !ok {
+			return false, nil
+		} else if has {
+			holders = append(holders, nodes[i])
+		}
+	}
+
+	if len(holders) == 0 {
+		return true, nil
+	}
+
+	found, err = transport.SearchObject(ctx, s.executor, addr, holders, query.Filter{
+		Type: query.Filter_Exact,
+		Name: transport.KeyTombstone,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	for i := range holders {
+		if !found[holders[i].String()] {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
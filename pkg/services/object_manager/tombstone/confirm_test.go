@@ -0,0 +1,136 @@
+package tombstone
+
+import (
+	"context"
+	"testing"
+
+	"github.com/multiformats/go-multiaddr"
+	"github.com/nspcc-dev/neofs-api-go/query"
+	"github.com/nspcc-dev/neofs-api-go/refs"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/transport"
+	"github.com/stretchr/testify/require"
+)
+
+type (
+	testNodeLister []multiaddr.Multiaddr
+
+	// nodeState is a state of the object on the node.
+	nodeState int
+
+	testExecutor struct {
+		transport.SelectiveContainerExecutor
+
+		addr   refs.Address
+		states map[string]nodeState
+	}
+)
+
+const (
+	stateMissing nodeState = iota
+	stateObject
+	stateTombstone
+	stateUnavailable
+)
+
+func (s testNodeLister) ContainerNodes(context.Context, refs.CID) ([]multiaddr.Multiaddr, error) {
+	return s, nil
+}
+
+func (s *testExecutor) Search(_ context.Context, p *transport.SearchParams) error {
+	q := new(query.Query)
+	if err := q.Unmarshal(p.SearchQuery); err != nil {
+		return err
+	}
+
+	tombstone := false
+
+	for i := range q.Filters {
+		if q.Filters[i].Name == transport.KeyTombstone {
+			tombstone = true
+		}
+	}
+
+	for _, node := range p.Nodes {
+		var res []refs.Address
+
+		switch s.states[node.String()] {
+		case stateUnavailable:
+			continue
+		case stateObject:
+			if !tombstone {
+				res = append(res, s.addr)
+			}
+		case stateTombstone:
+			res = append(res, s.addr)
+		}
+
+		p.Handler(node, res)
+	}
+
+	return nil
+}
+
+func TestRemoteConfirmer_Confirm(t *testing.T) {
+	var nodes testNodeLister
+
+	for _, a := range []string{
+		"/ip4/127.0.0.1/tcp/8080",
+		"/ip4/127.0.0.1/tcp/8081",
+		"/ip4/127.0.0.1/tcp/8082",
+	} {
+		node, err := multiaddr.NewMultiaddr(a)
+		require.NoError(t, err)
+
+		nodes = append(nodes, node)
+	}
+
+	addr := *testObject(t).Address()
+
+	for _, item := range []struct {
+		name      string
+		states    []nodeState
+		confirmed bool
+	}{
+		{
+			name:      "removed everywhere",
+			states:    []nodeState{stateMissing, stateMissing, stateMissing},
+			confirmed: true,
+		},
+		{
+			name:      "tombstones",
+			states:    []nodeState{stateTombstone, stateMissing, stateTombstone},
+			confirmed: true,
+		},
+		{
+			name:      "object replica",
+			states:    []nodeState{stateTombstone, stateObject, stateTombstone},
+			confirmed: false,
+		},
+		{
+			name:      "unavailable node",
+			states:    []nodeState{stateTombstone, stateUnavailable, stateMissing},
+			confirmed: false,
+		},
+	} {
+		t.Run(item.name, func(t *testing.T) {
+			exec := &testExecutor{
+				addr:   addr,
+				states: make(map[string]nodeState, len(nodes)),
+			}
+
+			for i := range nodes {
+				exec.states[nodes[i].String()] = item.states[i]
+			}
+
+			c, err := NewConfirmer(ConfirmerParams{
+				SelectiveContainerExecutor: exec,
+				NodeLister:                 nodes,
+			})
+			require.NoError(t, err)
+
+			ok, err := c.Confirm(context.Background(), addr)
+			require.NoError(t, err)
+			require.Equal(t, item.confirmed, ok)
+		})
+	}
+}
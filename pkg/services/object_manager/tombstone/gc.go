@@ -0,0 +1,240 @@
+package tombstone
+
+import (
+	"context"
+
+	"github.com/nspcc-dev/neofs-api-go/object"
+	"github.com/nspcc-dev/neofs-api-go/refs"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/localstore"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/epochgc"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/retention"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+)
+
+type (
+	// Params groups the parameters of the GC constructor.
+	Params struct {
+		epochgc.Params
+
+		Confirmer Confirmer
+
+		// GracePeriod is a number of epochs after the tombstone
+		// storing epoch during which the tombstone is not collected.
+		GracePeriod uint64
+	}
+
+	// GC physically removes the tombstoned objects from the local storage.
+	//
+	// Tombstone replaces the removed object in the local storage, so GC
+	// removes the children of the object found by their parent link after
+	// the grace period. Tombstone itself is removed when all container
+	// nodes confirmed the removal, until that it rejects the replicas
+	// of the removed object. Tombstones of the objects under the
+	// retention lock or the legal hold are not collected, children
+	// under the retention lock are kept.
+	GC struct {
+		*epochgc.Runner
+
+		ls        localstore.Localstore
+		confirmer Confirmer
+		log       *zap.Logger
+		grace     uint64
+		holder    retention.Holder
+		locator   retention.Locator
+	}
+)
+
+var errNilConfirmer = errors.New("removal confirmer is nil")
+
+// New is a tombstoned objects GC constructor.
+func New(p Params) (*GC, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	} else if p.Confirmer == nil {
+		return nil, errNilConfirmer
+	}
+
+	g := &GC{
+		ls:        p.Localstore,
+		confirmer: p.Confirmer,
+		log:       p.Logger,
+		grace:     p.GracePeriod,
+		holder:    p.Holder,
+		locator:   p.Locator,
+	}
+
+	var err error
+	if g.Runner, err = epochgc.NewRunner(g.collect); err != nil {
+		return nil, err
+	}
+
+	return g, nil
+}
+
+func (g *GC) collect(ctx context.Context, epoch uint64) {
+	var (
+		tombs []refs.Address
+		guard = retention.NewGuard(g.holder, g.locator)
+	)
+
+	if err := g.ls.Iterate(nil, func(meta *localstore.ObjectMeta) bool {
+		obj := meta.Object
+
+		if _, ok, _ := retention.ParseLock(obj); ok {
+			guard.Add(obj)
+		}
+
+		if obj.IsTombstone() && meta.StoreEpoch <= epoch && epoch-meta.StoreEpoch >= g.grace {
+			tombs = append(tombs, *obj.Address())
+		}
+
+		return false
+	}); err != nil {
+		g.log.Error("could not list tombstones",
+			zap.Uint64("epoch", epoch),
+			zap.Error(err))
+
+		return
+	}
+
+	children, err := g.children(tombs, guard)
+	if err != nil {
+		g.log.Error("could not list children of tombstoned objects",
+			zap.Uint64("epoch", epoch),
+			zap.Error(err))
+
+		return
+	}
+
+	var removedChildren, removedTombs, retained int
+
+	for i := range tombs {
+		if ctx.Err() != nil {
+			break
+		}
+
+		if guard.CheckRetained(ctx, tombs[i], epoch, g.log) {
+			retained++
+			continue
+		}
+
+		for _, child := range children[tombs[i]] {
+			if guard.CheckRetained(ctx, child, epoch, g.log) {
+				continue
+			}
+
+			if err := g.ls.Del(child); err != nil {
+				g.log.Warn("could not remove child of tombstoned object",
+					zap.Stringer("oid", child.ObjectID),
+					zap.Stringer("cid", child.CID),
+					zap.Error(err))
+
+				continue
+			}
+
+			removedChildren++
+		}
+
+		confirmed, err := g.confirmer.Confirm(ctx, tombs[i])
+		if err != nil {
+			g.log.Warn("could not confirm object removal",
+				zap.Stringer("oid", tombs[i].ObjectID),
+				zap.Stringer("cid", tombs[i].CID),
+				zap.Error(err))
+
+			continue
+		} else if !confirmed {
+			continue
+		}
+
+		if err := g.ls.Del(tombs[i]); err != nil {
+			g.log.Warn("could not remove tombstone",
+				zap.Stringer("oid", tombs[i].ObjectID),
+				zap.Stringer("cid", tombs[i].CID),
+				zap.Error(err))
+
+			continue
+		}
+
+		removedTombs++
+	}
+
+	g.log.Info("tombstoned objects collected",
+		zap.Uint64("epoch", epoch),
+		zap.Int("tombstones", len(tombs)),
+		zap.Int("removed children", removedChildren),
+		zap.Int("removed tombstones", removedTombs),
+		zap.Int("retained", retained))
+}
+
+// children returns the children addresses of the tombstoned objects
+// by the parent address. Children are selected through the localstore
+// indexes, if indexes are not maintained, the storage is scanned for
+// the children of the tombstoned objects only.
+func (g *GC) children(tombs []refs.Address, guard *retention.Guard) (map[refs.Address][]refs.Address, error) {
+	res := make(map[refs.Address][]refs.Address, len(tombs))
+	if len(tombs) == 0 {
+		return res, nil
+	}
+
+	handler := func(meta *localstore.ObjectMeta) bool {
+		obj := meta.Object
+
+		parent, ok := parentID(obj)
+		if !ok || obj.IsTombstone() {
+			return false
+		}
+
+		addr := refs.Address{ObjectID: parent, CID: obj.SystemHeader.CID}
+
+		if children, ok := res[addr]; ok {
+			// parent link of the child is checked for the retention
+			guard.Add(obj)
+			res[addr] = append(children, *obj.Address())
+		}
+
+		return false
+	}
+
+	reset := func() {
+		for i := range tombs {
+			res[tombs[i]] = nil
+		}
+	}
+
+	reset()
+
+	err := localstore.ErrIndexDisabled
+
+	if searcher, ok := g.ls.(localstore.Searcher); ok {
+		for i := range tombs {
+			if err = searcher.Search([]localstore.IndexFilter{
+				{Attr: localstore.IndexCID, Value: tombs[i].CID.String()},
+				{Attr: localstore.IndexParent, Value: tombs[i].ObjectID.String()},
+			}, handler); err != nil {
+				break
+			}
+		}
+	}
+
+	if errors.Is(errors.Cause(err), localstore.ErrIndexDisabled) {
+		// drop the children found before the failure
+		reset()
+
+		err = g.ls.Iterate(nil, handler)
+	}
+
+	return res, err
+}
+
+func parentID(obj *object.Object) (refs.ObjectID, bool) {
+	for i := range obj.Headers {
+		h, ok := obj.Headers[i].Value.(*object.Header_Link)
+		if ok && h.Link != nil && h.Link.Type == object.Link_Parent {
+			return h.Link.ID, true
+		}
+	}
+
+	return refs.ObjectID{}, false
+}
@@ -0,0 +1,139 @@
+package tombstone
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/nspcc-dev/neofs-api-go/object"
+	"github.com/nspcc-dev/neofs-api-go/refs"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/bucket/test"
+	"github.com/nspcc-dev/neofs-node/pkg/local_object_storage/localstore"
+	lstest "github.com/nspcc-dev/neofs-node/pkg/local_object_storage/localstore/test"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/epochgc"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/retention"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type testConfirmer map[refs.Address]bool
+
+func (s testConfirmer) Confirm(_ context.Context, addr refs.Address) (bool, error) {
+	return s[addr], nil
+}
+
+func testObject(t *testing.T) *object.Object {
+	id, err := refs.NewObjectID()
+	require.NoError(t, err)
+
+	return &object.Object{
+		SystemHeader: object.SystemHeader{
+			ID:  id,
+			CID: refs.CIDForBytes([]byte("container")),
+		},
+	}
+}
+
+func testTombstone(t *testing.T) *object.Object {
+	obj := testObject(t)
+	obj.AddHeader(&object.Header{Value: &object.Header_Tombstone{Tombstone: new(object.Tombstone)}})
+
+	return obj
+}
+
+func testChild(t *testing.T, parent *object.Object) *object.Object {
+	obj := testObject(t)
+	obj.AddHeader(&object.Header{Value: &object.Header_Link{
+		Link: &object.Link{Type: object.Link_Parent, ID: parent.SystemHeader.ID},
+	}})
+
+	return obj
+}
+
+func TestGC(t *testing.T) {
+	t.Run("scan", func(t *testing.T) {
+		testGC(t, nil)
+	})
+
+	// children are selected through the parent index
+	t.Run("index", func(t *testing.T) {
+		testGC(t, test.Bucket())
+	})
+}
+
+func testGC(t *testing.T, index bucket.Bucket) {
+	ls := lstest.Localstore(t, localstore.Params{IndexBucket: index})
+
+	var (
+		oldTomb  = testTombstone(t) // grace period is over, removal is confirmed
+		unconf   = testTombstone(t) // grace period is over, removal is not confirmed
+		newTomb  = testTombstone(t) // grace period is not over
+		regular  = testObject(t)
+		oldChild = testChild(t, oldTomb)
+		uncChild = testChild(t, unconf)
+		newChild = testChild(t, newTomb)
+		regChild = testChild(t, regular)
+		locked   = testTombstone(t) // grace period is over, object is locked
+		lockObj  = testObject(t)
+		lckChild = testChild(t, locked)
+	)
+
+	lockObj.AddHeader(&object.Header{Value: &object.Header_UserHeader{
+		UserHeader: &object.UserHeader{Key: retention.Header, Value: "3"},
+	}})
+	lockObj.AddHeader(&object.Header{Value: &object.Header_UserHeader{
+		UserHeader: &object.UserHeader{Key: retention.TargetHeader, Value: locked.SystemHeader.ID.String()},
+	}})
+
+	put := func(obj *object.Object, epoch uint64) {
+		ctx := context.WithValue(context.Background(), localstore.StoreEpochValue, epoch)
+		require.NoError(t, ls.Put(ctx, obj))
+	}
+
+	put(oldTomb, 1)
+	put(unconf, 1)
+	put(newTomb, 3)
+	put(regular, 1)
+
+	for _, obj := range []*object.Object{oldChild, uncChild, newChild, regChild, locked, lockObj, lckChild} {
+		put(obj, 1)
+	}
+
+	gc, err := New(Params{
+		Params: epochgc.Params{
+			Localstore: ls,
+			Logger:     zap.L(),
+		},
+		Confirmer: testConfirmer{
+			*oldTomb.Address(): true,
+			*newTomb.Address(): true,
+			*locked.Address():  true,
+		},
+		GracePeriod: 2,
+	})
+	require.NoError(t, err)
+
+	gc.collect(context.Background(), 3)
+
+	for i, item := range []struct {
+		obj  *object.Object
+		kept bool
+	}{
+		{obj: oldTomb, kept: false},
+		{obj: unconf, kept: true},
+		{obj: newTomb, kept: true},
+		{obj: regular, kept: true},
+		{obj: oldChild, kept: false},
+		{obj: uncChild, kept: false},
+		{obj: newChild, kept: true},
+		{obj: regChild, kept: true},
+		{obj: locked, kept: true},
+		{obj: lockObj, kept: true},
+		{obj: lckChild, kept: true},
+	} {
+		ok, err := ls.Has(*item.obj.Address())
+		require.NoError(t, err)
+		require.Equal(t, item.kept, ok, strconv.Itoa(i))
+	}
+}
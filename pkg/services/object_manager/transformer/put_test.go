@@ -16,6 +16,7 @@ import (
 	"github.com/nspcc-dev/neofs-api-go/session"
 	"github.com/nspcc-dev/neofs-api-go/storagegroup"
 	crypto "github.com/nspcc-dev/neofs-crypto"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/erasure"
 	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/replication/storage"
 	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/verifier"
 	"github.com/nspcc-dev/neofs-node/pkg/util/test"
@@ -45,6 +46,7 @@ var (
 	_ Transformer               = (*testPutEntity)(nil)
 	_ storagegroup.InfoReceiver = (*testPutEntity)(nil)
 	_ verifier.Verifier         = (*testPutEntity)(nil)
+	_ erasure.Source            = (*testPutEntity)(nil)
 )
 
 func (s *testPutEntity) Verify(_ context.Context, obj *Object) error {
@@ -80,6 +82,16 @@ func (s *testPutEntity) GetSGInfo(_ context.Context, cid CID, group []ObjectID)
 
 func (s *testPutEntity) Epoch() uint64 { return s.res.(uint64) }
 
+func (s *testPutEntity) Scheme(cid CID) (erasure.Scheme, bool, error) {
+	if s.f != nil {
+		s.f(cid)
+	}
+	if s.err != nil || s.res == nil {
+		return erasure.Scheme{}, false, s.err
+	}
+	return s.res.(erasure.Scheme), true, nil
+}
+
 func TestNewTransformer(t *testing.T) {
 	validParams := Params{
 		SGInfoReceiver: new(testPutEntity),
@@ -727,6 +739,82 @@ func TestIntegration(t *testing.T) {
 				Payload: bytes.NewBuffer(payload),
 			}, tr, payload)
 		})
+
+		t.Run("erasure coded", func(t *testing.T) {
+			scheme := erasure.Scheme{Data: 3, Parity: 2}
+
+			tr, err := NewTransformer(Params{
+				SGInfoReceiver: new(testPutEntity),
+				EpochReceiver:  &testPutEntity{res: uint64(1)},
+				SizeLimit:      13,
+				Verifier: &testPutEntity{
+					err: errors.New(""), // force verifier to return non-nil error
+				},
+				ErasureSource: &testPutEntity{res: scheme},
+			})
+			require.NoError(t, err)
+
+			payload := make([]byte, 20)
+			_, err = rand.Read(payload)
+			require.NoError(t, err)
+
+			obj := &Object{
+				SystemHeader: object.SystemHeader{
+					PayloadLength: uint64(len(payload)),
+					OwnerID:       ownerID,
+					CID:           CID{4},
+				},
+			}
+
+			obj.SetHeader(&object.Header{
+				Value: &object.Header_Token{
+					Token: pubToken,
+				},
+			})
+
+			headVerifier, err := storage.NewLocalHeadIntegrityVerifier()
+			require.NoError(t, err)
+
+			objList := make([]*Object, 0, scheme.Parts()+1)
+
+			require.NoError(t, tr.Transform(ctx, ProcUnit{
+				Head:    obj,
+				Payload: bytes.NewBuffer(payload),
+			}, func(_ context.Context, unit ProcUnit) error {
+				require.NoError(t, headVerifier.Verify(ctx, unit.Head))
+				objList = append(objList, unit.Head.Copy())
+				return nil
+			}))
+
+			require.Len(t, objList, scheme.Parts()+1)
+
+			parts, parent := objList[:scheme.Parts()], objList[scheme.Parts()]
+			require.True(t, erasure.IsParent(parent))
+			require.Len(t, parent.Links(object.Link_Child), scheme.Parts())
+
+			res, err := erasure.Restore(parts[scheme.Parity:])
+			require.NoError(t, err)
+			require.Equal(t, payload, res.Payload)
+			require.Equal(t, parent.SystemHeader.ID, res.SystemHeader.ID)
+
+			integrityVerifier, err := storage.NewLocalIntegrityVerifier()
+			require.NoError(t, err)
+			require.NoError(t, integrityVerifier.Verify(ctx, res))
+			require.NoError(t, integrityVerifier.Verify(ctx, parent))
+
+			part, err := erasure.RebuildPart(parent, parts[1:], 0)
+			require.NoError(t, err)
+			require.NoError(t, integrityVerifier.Verify(ctx, part))
+
+			t.Run("too big", func(t *testing.T) {
+				require.Error(t, tr.Transform(ctx, ProcUnit{
+					Head: &Object{
+						SystemHeader: object.SystemHeader{PayloadLength: 13*uint64(scheme.Data) + 1},
+					},
+					Payload: bytes.NewBuffer(make([]byte, 13*scheme.Data+1)),
+				}))
+			})
+		})
 	})
 }
 
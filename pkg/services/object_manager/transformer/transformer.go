@@ -13,6 +13,7 @@ import (
 	"github.com/nspcc-dev/neofs-api-go/service"
 	"github.com/nspcc-dev/neofs-api-go/session"
 	"github.com/nspcc-dev/neofs-api-go/storagegroup"
+	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/erasure"
 	"github.com/nspcc-dev/neofs-node/pkg/services/object_manager/verifier"
 	"github.com/pkg/errors"
 )
@@ -69,6 +70,12 @@ type (
 		verifier verifier.Verifier
 	}
 
+	erasureCoder struct {
+		source   erasure.Source
+		limit    uint64
+		tSizeLim Transformer
+	}
+
 	emptyReader struct{}
 
 	// Params groups the parameters of object transformer's constructor.
@@ -77,6 +84,10 @@ type (
 		EpochReceiver  EpochReceiver
 		SizeLimit      uint64
 		Verifier       verifier.Verifier
+
+		// ErasureSource provides the erasure coding schemes of the containers,
+		// objects are not erasure coded if it is not set.
+		ErasureSource erasure.Source
 	}
 )
 
@@ -111,6 +122,7 @@ var (
 	errInvalidSizeLimit    = errors.New("non-positive object size limit")
 	errEmptyEpochReceiver  = errors.New("empty epoch receiver")
 	errEmptyVerifier       = errors.New("empty object verifier")
+	errErasureObjectTooBig = errors.New("erasure coded object payload exceeds the parts size limit")
 )
 
 // NewTransformer is an object transformer's constructor.
@@ -126,6 +138,19 @@ func NewTransformer(p Params) (Transformer, error) {
 		return nil, errors.Wrap(errEmptyVerifier, transformerInstanceFailMsg)
 	}
 
+	var tSizeLim Transformer = &sizeLimiter{
+		limit:     p.SizeLimit,
+		epochRecv: p.EpochReceiver,
+	}
+
+	if p.ErasureSource != nil {
+		tSizeLim = &erasureCoder{
+			source:   p.ErasureSource,
+			limit:    p.SizeLimit,
+			tSizeLim: tSizeLim,
+		}
+	}
+
 	return &transformer{
 		tPrelim: &preliminaryTransformer{
 			fMoulder: &fieldMoulder{
@@ -135,10 +160,7 @@ func NewTransformer(p Params) (Transformer, error) {
 				sgInfoRecv: p.SGInfoReceiver,
 			},
 		},
-		tSizeLim: &sizeLimiter{
-			limit:     p.SizeLimit,
-			epochRecv: p.EpochReceiver,
-		},
+		tSizeLim: tSizeLim,
 		tSign: &headSigner{
 			verifier: p.Verifier,
 		},
@@ -258,7 +280,7 @@ func (s *sizeLimiter) Transform(ctx context.Context, unit ProcUnit, handlers ...
 
 			resObj.Head.Headers = pObj.Headers[:srcHdrLen+verifyHeadersCount]
 
-			if err = signWithToken(ctx, &Object{
+			if err = SignWithToken(ctx, &Object{
 				SystemHeader: pObj.SystemHeader,
 				Headers:      resObj.Head.Headers,
 			}); err != nil {
@@ -295,6 +317,49 @@ func (s *sizeLimiter) Transform(ctx context.Context, unit ProcUnit, handlers ...
 	return procHandlers(ctx, EmptyPayloadUnit(pObj), handlers...)
 }
 
+// Transform splits the object of the erasure coded container into the parts
+// and passes the parts and their parent to the handlers. Objects of the other
+// containers are passed to the size limiter.
+//
+// Parts are not split by the size limit, so the payload of the erasure coded
+// object must not exceed the size limit multiplied by the number of data parts.
+func (s *erasureCoder) Transform(ctx context.Context, unit ProcUnit, handlers ...ProcUnitHandler) error {
+	scheme, ok, err := s.source.Scheme(unit.Head.SystemHeader.CID)
+	if err != nil {
+		return errors.Wrap(err, "could not get erasure coding scheme")
+	} else if !ok {
+		return s.tSizeLim.Transform(ctx, unit, handlers...)
+	} else if unit.Head.SystemHeader.PayloadLength > s.limit*uint64(scheme.Data) {
+		return errors.Wrapf(errErasureObjectTooBig, "%d data parts of %d bytes", scheme.Data, s.limit)
+	}
+
+	payload := make([]byte, unit.Head.SystemHeader.PayloadLength)
+
+	if err := readChunk(unit, payload, nil, nil); err != nil {
+		return err
+	}
+
+	parts, err := erasure.Split(unit.Head, payload, scheme, func(obj *Object) error {
+		return SignWithToken(ctx, obj)
+	})
+	if err != nil {
+		return err
+	}
+
+	for i := range parts {
+		if err := procHandlers(ctx, EmptyPayloadUnit(parts[i]), handlers...); err != nil {
+			return err
+		}
+	}
+
+	parent, err := erasure.Link(parts)
+	if err != nil {
+		return err
+	}
+
+	return procHandlers(ctx, EmptyPayloadUnit(parent), handlers...)
+}
+
 func readChunk(unit ProcUnit, buf []byte, hAcc io.Writer, homoHashAcc *object.Header_HomoHash) (err error) {
 	var csHdr *object.Header_PayloadChecksum
 
@@ -332,7 +397,7 @@ func readChunk(unit ProcUnit, buf []byte, hAcc io.Writer, homoHashAcc *object.He
 
 func (s *headSigner) Transform(ctx context.Context, unit ProcUnit, handlers ...ProcUnitHandler) error {
 	if s.verifier.Verify(ctx, unit.Head) != nil {
-		if err := signWithToken(ctx, unit.Head); err != nil {
+		if err := SignWithToken(ctx, unit.Head); err != nil {
 			return err
 		}
 	}
@@ -340,7 +405,9 @@ func (s *headSigner) Transform(ctx context.Context, unit ProcUnit, handlers ...P
 	return procHandlers(ctx, unit, handlers...)
 }
 
-func signWithToken(ctx context.Context, obj *Object) error {
+// SignWithToken adds the integrity header to the object signed
+// by the private session token from the context.
+func SignWithToken(ctx context.Context, obj *Object) error {
 	integrityHdr := new(object.IntegrityHeader)
 
 	if pToken, ok := ctx.Value(PrivateSessionToken).(session.PrivateToken); !ok {
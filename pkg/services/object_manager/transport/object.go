@@ -47,6 +47,13 @@ type (
 		GetQuery() []byte
 	}
 
+	// QueryVersionSource is an interface of the container of the search
+	// query version. SearchInfo queries are of version 1 unless SearchInfo
+	// implements QueryVersionSource.
+	QueryVersionSource interface {
+		GetQueryVersion() uint32
+	}
+
 	// PutInfo is an interface of the container of object Put operation parameters.
 	PutInfo interface {
 		MetaInfo
@@ -0,0 +1,64 @@
+package transport
+
+import (
+	"context"
+
+	"github.com/multiformats/go-multiaddr"
+	"github.com/nspcc-dev/neofs-api-go/query"
+	"github.com/nspcc-dev/neofs-api-go/refs"
+	"github.com/nspcc-dev/neofs-api-go/service"
+	"github.com/pkg/errors"
+)
+
+// SearchObject searches for the object on the nodes with the additional
+// query filters and returns the result of each responded node: true if
+// the node stores the matching object. Nodes that did not respond are
+// absent in the result.
+func SearchObject(ctx context.Context, executor SelectiveContainerExecutor, addr refs.Address, nodes []multiaddr.Multiaddr, filters ...query.Filter) (map[string]bool, error) {
+	q := query.Query{
+		Filters: append([]query.Filter{
+			{
+				Type:  query.Filter_Exact,
+				Name:  KeyID,
+				Value: addr.ObjectID.String(),
+			},
+		}, filters...),
+	}
+
+	queryBytes, err := q.Marshal()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal search query")
+	}
+
+	res := make(map[string]bool, len(nodes))
+
+	err = executor.Search(ctx, &SearchParams{
+		SelectiveParams: SelectiveParams{
+			CID:   addr.CID,
+			TTL:   service.NonForwardingTTL,
+			Nodes: nodes,
+
+			// search is performed once on each node
+			IDList: make([]refs.ObjectID, 1),
+		},
+		SearchCID:   addr.CID,
+		SearchQuery: queryBytes,
+		Handler: func(node multiaddr.Multiaddr, addrList []refs.Address) {
+			key := node.String()
+
+			for i := range addrList {
+				if addrList[i].ObjectID == addr.ObjectID {
+					res[key] = true
+					return
+				}
+			}
+
+			// node can respond several times
+			if _, ok := res[key]; !ok {
+				res[key] = false
+			}
+		},
+	})
+
+	return res, err
+}